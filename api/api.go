@@ -4,35 +4,104 @@ import (
 	"Projects/Car24/car24_api_gateway/api/handlers"
 	"Projects/Car24/car24_api_gateway/config"
 
-	_ "Projects/Car24/car24_api_gateway/api/docs"
+	"Projects/Car24/car24_api_gateway/api/docs"
+	"Projects/Car24/car24_api_gateway/pkg/transcode"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
 )
 
+// gatewayDocs is the swagger instance with the transcoded routes added to the generated docs
+const gatewayDocs = "gateway"
+
 func SetUpAPI(r *gin.Engine, h handlers.Handler, cfg config.Config) {
 
+	r.Use(h.CompressionMiddleware())
+	r.Use(h.AuthMiddleware())
+
+	if cfg.Environment == config.DebugMode && cfg.ValidateContract {
+		r.Use(h.ContractMiddleware(r))
+	}
+
 	//user
 	r.POST("/user", h.CreateClient)
+	r.GET("/user/block-suggestions", h.GetBlockSuggestions)
 	r.GET("/user/:id", h.GetClientByID)
 	r.GET("/user", h.GetClientList)
 	r.PUT("user/:id", h.UpdateClient)
 	r.DELETE("/user/:id", h.DeleteClient)
 	r.PATCH("/user/:id", h.UpdatePatchClient)
+	r.POST("/user/:id/block", h.BlockClient)
+	r.POST("/user/:id/unblock", h.UnblockClient)
+	r.GET("/user/:id/block-history", h.GetClientBlockHistory)
 
 	//order
 	r.POST("/order", h.CreateOrder)
+	r.GET("/order/overdue", h.GetOverdueOrders)
 	r.GET("/order/:id", h.GetOrderByID)
 	r.GET("/order", h.GetListOrder)
 	r.PUT("order/:id", h.UpdateOrder)
 	r.DELETE("order/:id", h.DeleteOrder)
 	r.PATCH("order/:id", h.UpdatePatchOrder)
+	r.POST("/order/:id/confirm", h.ConfirmOrder)
+	r.POST("/order/:id/pickup", h.PickupOrder)
+	r.POST("/order/:id/return", h.ReturnOrder)
+	r.POST("/order/:id/overdue", h.MarkOrderOverdue)
+	r.POST("/order/:id/complete", h.CompleteOrder)
+	r.POST("/order/:id/cancel", h.CancelOrder)
+	r.GET("/order/:id/history", h.GetOrderStatusHistory)
+	r.GET("/order/:id/inspections", h.GetOrderInspections)
+	r.GET("/order/:id/charges", h.GetOrderCharges)
+	r.POST("/order/:id/payments", h.CreateOrderPayment)
+	r.GET("/order/:id/payments", h.GetOrderPayments)
+	r.GET("/order/:id/deposit", h.GetOrderDeposit)
+	r.POST("/order/:id/deposit/hold", h.HoldOrderDeposit)
+	r.POST("/order/:id/deposit/capture", h.CaptureOrderDeposit)
+	r.POST("/order/:id/deposit/release", h.ReleaseOrderDeposit)
+
+	//car
+	r.POST("/car", h.CreateCar)
+	r.GET("/car/:id", h.GetCarByID)
+	r.GET("/car", h.GetCarList)
+	r.PUT("/car/:id", h.UpdateCar)
+	r.DELETE("/car/:id", h.DeleteCar)
+	r.PATCH("/car/:id", h.UpdatePatchCar)
+
+	//batch
+	r.POST("/batch", h.Batch(r))
+
+	//graphql
+	r.POST("/graphql", h.GraphQL())
 
 	// otp
 	r.POST("/check", h.CreateUserOTP)
 	r.GET("/check", h.VerifyUserOTP)
 
+	//webhooks
+	r.POST("/webhooks", h.CreateWebhook)
+	r.GET("/webhooks", h.GetWebhookList)
+	r.DELETE("/webhooks/:id", h.DeleteWebhook)
+	r.GET("/webhooks/dead-letters", h.GetWebhookDeadLetters)
+	r.POST("/webhooks/dead-letters/replay", h.ReplayWebhookDeadLetters)
+	r.POST("/webhooks/dead-letters/:id/replay", h.ReplayWebhookDeadLetter)
+
+	//streams
+	r.GET("/ws", h.WebSocket)
+	r.GET("/events", h.Events)
+
+	// rpcs annotated with google.api.http in protos/ that have no route above
+	transcoded := h.TranscodedRoutes(r.Routes())
+	for _, route := range transcoded {
+		r.Handle(route.Method, route.Path, h.Transcode(route))
+	}
+
+	// openapi 3.1 document of all the routes above
+	r.GET("/openapi.json", h.OpenAPI(r))
+	r.GET("/docs", h.OpenAPIPage)
+
+	swag.Register(gatewayDocs, &transcode.Swagger{Base: docs.SwaggerInfo, Routes: transcoded})
 	url := ginSwagger.URL("swagger/doc.json") // The url pointing to API definition
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url, ginSwagger.InstanceName(gatewayDocs)))
 }
@@ -16,9 +16,86 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/batch": {
+            "post": {
+                "description": "Run several API requests in one round trip, in the given order, through the gateway routes with the caller's Authorization.\nA path or body may use the response of an earlier request as $N.field, like $1.id, counting the requests from 1, dependsOn lists more requests that must succeed first.\nA request whose dependency failed is answered with 424 and not run, with atomic=true the batch stops at the first failure, the requests that already ran are not undone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Batch"
+                ],
+                "summary": "Batch",
+                "operationId": "batch",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "stop at the first failed request",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "BatchRequestBody",
+                        "name": "requests",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BatchRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Responses in the order of the requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.BatchResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid batch",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
         "/car": {
             "get": {
-                "description": "Get Car List",
+                "description": "Get Car List, filter as field=value, field=a,b or field[op]=value with op one of eq, ne, in, gt, gte, lt, lte, like, expand embeds the model and tariff as in models.ExpandedCarList",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,15 +116,75 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "limit",
+                        "description": "limit, at most the configured maximum page size",
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous page, replaces offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "search",
                         "name": "search",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "car status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tarif id",
+                        "name": "tarif_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "model id",
+                        "name": "model_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "state number contains",
+                        "name": "state_number[like]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cars added at or after the date",
+                        "name": "created_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cars added at or before the date",
+                        "name": "created_at[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated relations: model, tarif",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, e.g. id,state_number or expanded.model.name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by state_number, created_at e.g. state_number:asc, the newest first when not given",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -70,7 +207,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Rejected filters",
                         "schema": {
                             "allOf": [
                                 {
@@ -80,7 +217,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.QueryRejected"
                                         }
                                     }
                                 }
@@ -168,6 +305,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -191,7 +346,7 @@ const docTemplate = `{
         },
         "/car/{id}": {
             "get": {
-                "description": "Get Car By ID",
+                "description": "Get Car By ID, with expand the model and tariff are embedded under expanded as in models.ExpandedCar",
                 "consumes": [
                     "application/json"
                 ],
@@ -210,6 +365,24 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated relations: model, tarif",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, e.g. id,state_number or expanded.model.name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -231,6 +404,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "400": {
                         "description": "Invalid Argument",
                         "schema": {
@@ -270,7 +461,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update Car",
+                "description": "Update Car, the fields the caller role may not patch must keep their current value",
                 "consumes": [
                     "application/json"
                 ],
@@ -290,6 +481,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "UpdateCarRequestBody",
                         "name": "profile",
@@ -320,7 +517,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Rejected fields",
                         "schema": {
                             "allOf": [
                                 {
@@ -330,15 +527,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.PatchRejected"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -354,34 +551,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete Car",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Car"
-                ],
-                "summary": "Delete Car",
-                "operationId": "delete_car",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Car data",
+                    },
+                    "412": {
+                        "description": "Resource was modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -391,15 +563,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "428": {
+                        "description": "If-Match is required",
                         "schema": {
                             "allOf": [
                                 {
@@ -436,8 +608,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Patch Car",
+            "delete": {
+                "description": "Delete Car",
                 "consumes": [
                     "application/json"
                 ],
@@ -447,8 +619,8 @@ const docTemplate = `{
                 "tags": [
                     "Car"
                 ],
-                "summary": "Patch Car",
-                "operationId": "patch_car",
+                "summary": "Delete Car",
+                "operationId": "delete_car",
                 "parameters": [
                     {
                         "type": "string",
@@ -458,13 +630,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "UpdatePatchRequestBody",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdatePatch"
-                        }
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -479,7 +648,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Car"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -504,8 +673,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -521,43 +690,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/check": {
-            "get": {
-                "description": "Verify OTP",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "OTP"
-                ],
-                "summary": "Verify OTP",
-                "operationId": "verify_otp",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "otp_code",
-                        "name": "otp_code",
-                        "in": "query",
-                        "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "phone_number",
-                        "name": "phone_number",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OTP",
+                    "412": {
+                        "description": "Resource was modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -567,15 +702,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid Argument",
+                    "428": {
+                        "description": "If-Match is required",
                         "schema": {
                             "allOf": [
                                 {
@@ -612,33 +747,47 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create OTP",
+            "patch": {
+                "description": "Patch Car fields allowed for the caller role, the body is either UpdatePatch or a JSON Merge Patch object",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "OTP"
+                    "Car"
                 ],
-                "summary": "Create OTP",
-                "operationId": "create_otp",
+                "summary": "Patch Car",
+                "operationId": "patch_car",
                 "parameters": [
                     {
-                        "description": "CreateOTPRequestBody",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/client_service.CreateOTP"
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpdatePatchRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePatch"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP",
+                        "description": "Car data",
                         "schema": {
                             "allOf": [
                                 {
@@ -648,7 +797,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/order_service.Car"
                                         }
                                     }
                                 }
@@ -656,7 +805,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Rejected fields",
                         "schema": {
                             "allOf": [
                                 {
@@ -666,15 +815,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.PatchRejected"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -690,47 +839,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/client": {
-            "get": {
-                "description": "Get Client List",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Client"
-                ],
-                "summary": "Get Client List",
-                "operationId": "get_client_list",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "GetAllClientResponseBody",
+                    "412": {
+                        "description": "Resource was modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -740,15 +851,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/client_service.GetListClientResponse"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid Argument",
+                    "428": {
+                        "description": "If-Match is required",
                         "schema": {
                             "allOf": [
                                 {
@@ -784,9 +895,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create Client",
+            }
+        },
+        "/check": {
+            "get": {
+                "description": "Verify OTP",
                 "consumes": [
                     "application/json"
                 ],
@@ -794,24 +907,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "OTP"
                 ],
-                "summary": "Create Client",
-                "operationId": "create_client",
+                "summary": "Verify OTP",
+                "operationId": "verify_otp",
                 "parameters": [
                     {
-                        "description": "CreateClient",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/client_service.CreateClient"
-                        }
+                        "type": "string",
+                        "description": "otp_code",
+                        "name": "otp_code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone_number",
+                        "name": "phone_number",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "GetClientBody",
+                        "description": "OTP",
                         "schema": {
                             "allOf": [
                                 {
@@ -821,7 +939,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/client_service.Client"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -865,11 +983,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/client/{id}": {
-            "get": {
-                "description": "Get Client By ID",
+            },
+            "post": {
+                "description": "Create OTP",
                 "consumes": [
                     "application/json"
                 ],
@@ -877,22 +993,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "OTP"
                 ],
-                "summary": "Get Client By ID",
-                "operationId": "get_client_by_id",
+                "summary": "Create OTP",
+                "operationId": "create_otp",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "CreateOTPRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/client_service.CreateOTP"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Client",
+                        "description": "OTP",
                         "schema": {
                             "allOf": [
                                 {
@@ -902,7 +1020,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/client_service.Client"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -946,41 +1064,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update Client",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Server-Sent Events of the topics orders, cars and alerts. A reconnecting EventSource sends Last-Event-ID and first gets the events it missed, a reset event means they are gone and the state has to be loaded again. Browsers pass the token as access_token",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Client"
+                    "Stream"
                 ],
-                "summary": "Update Client",
-                "operationId": "update_client",
+                "summary": "Events",
+                "operationId": "events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "comma separated topics, by default every topic of the role",
+                        "name": "topics",
+                        "in": "query"
                     },
                     {
-                        "description": "UpdateClientRequestBody",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/client_service.UpdateClient"
-                        }
+                        "type": "string",
+                        "description": "JWT for clients that cannot send the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last received event, the ids of an earlier run of the gateway answer a reset",
+                        "name": "Last-Event-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Client data",
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown topic",
                         "schema": {
                             "allOf": [
                                 {
@@ -990,15 +1115,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/client_service.Client"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "allOf": [
                                 {
@@ -1015,8 +1140,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Topic not allowed",
                         "schema": {
                             "allOf": [
                                 {
@@ -1034,9 +1159,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete Client",
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Query orders, clients, cars, models, tariffs, mechanics and discounts with their relations in one round trip.\nThe types mirror the proto messages and keep their field names, an order has client, car, tarif, mechanic and discount fields and a car has model and tarif.\nClients read their own profile and orders only, listing the clients is for operators and admins.\nQueries deeper than GRAPHQL_MAX_DEPTH or resolving more than GRAPHQL_MAX_COMPLEXITY fields, counting list items by their limit, are rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1044,22 +1171,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "GraphQL"
                 ],
-                "summary": "Delete Client",
-                "operationId": "delete_client",
+                "summary": "GraphQL",
+                "operationId": "graphql",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "GraphQLRequestBody",
+                        "name": "query",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GraphQLRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Client data",
+                        "description": "data and the errors of the fields that failed",
                         "schema": {
                             "allOf": [
                                 {
@@ -1077,25 +1206,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/http.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Server Error",
+                        "description": "Invalid query or query over the limits",
                         "schema": {
                             "allOf": [
                                 {
@@ -1105,7 +1216,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -1113,9 +1224,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Patch Client",
+            }
+        },
+        "/order": {
+            "get": {
+                "description": "Get Order List, filter as field=value, field=a,b or field[op]=value with op one of eq, ne, in, gt, gte, lt, lte, like, expand embeds related resources as in models.ExpandedOrderList, clients only get their own orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -1123,41 +1236,145 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Order"
                 ],
-                "summary": "Patch Client",
-                "operationId": "patch_client",
+                "summary": "Get Order List",
+                "operationId": "get_order_list",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit, at most the configured maximum page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor or prev_cursor of a previous page, replaces offset",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "description": "UpdatePatchRequestBody",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdatePatch"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Client data",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/http.Response"
-                                },
+                        "type": "string",
+                        "description": "search, dates like 15 марта, ertaga or с 1 по 5 мая go in start_date",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated statuses: draft, confirmed, active, returned, completed, cancelled, overdue",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "car id",
+                        "name": "car_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client id",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tarif id",
+                        "name": "tarif_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "mechanic id",
+                        "name": "mechanic_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders starting on the day or in the range, e.g. 2024-05-01, 15 марта, ertaga, с 1 по 5 мая, 1-5 may",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders starting at or after the date",
+                        "name": "start_date[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders starting at or before the date",
+                        "name": "start_date[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders created at or after the date",
+                        "name": "created_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders created at or before the date",
+                        "name": "created_at[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimal total price",
+                        "name": "total_price[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "maximal total price",
+                        "name": "total_price[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "fully paid or unpaid orders",
+                        "name": "is_paid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated relations: client, car, tarif, mechanic, discount",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, e.g. id,status,total_price or expanded.client.first_name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by start_date, created_at, total_price, day_count, order_number e.g. created_at:desc,total_price:asc, the newest first when not given",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GetAllOrderResponseBody",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/client_service.Client"
+                                            "$ref": "#/definitions/order_service.GetListOrderResponse"
                                         }
                                     }
                                 }
@@ -1165,7 +1382,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Rejected filters",
                         "schema": {
                             "allOf": [
                                 {
@@ -1175,7 +1392,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.QueryRejected"
                                         }
                                     }
                                 }
@@ -1201,11 +1418,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/discount": {
+            },
             "post": {
-                "description": "Create Discount",
+                "description": "Create Order",
                 "consumes": [
                     "application/json"
                 ],
@@ -1213,24 +1428,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Discount"
+                    "Order"
                 ],
-                "summary": "Create Discount",
-                "operationId": "create_car",
+                "summary": "Create Order",
+                "operationId": "create_order",
                 "parameters": [
                     {
-                        "description": "CreateDiscount",
+                        "description": "CreateOrderRequestBody",
                         "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/order_service.CreateDiscount"
+                            "$ref": "#/definitions/order_service.CreateOrder"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "GetDiscountBody",
+                        "description": "GetOrderBody",
                         "schema": {
                             "allOf": [
                                 {
@@ -1240,7 +1455,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Discount"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
@@ -1265,6 +1480,42 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Client is not eligible",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EligibilityFailed"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -1286,9 +1537,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/discount/{id}": {
+        "/order/overdue": {
             "get": {
-                "description": "Get Discount By ID",
+                "description": "List rented orders whose car was not returned by the due time, the oldest rentals first",
                 "consumes": [
                     "application/json"
                 ],
@@ -1296,22 +1547,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Discount"
+                    "Order"
                 ],
-                "summary": "Get Discount By ID",
-                "operationId": "get_discount_by_id",
+                "summary": "Get Overdue Orders",
+                "operationId": "get_overdue_orders",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit, at most the configured maximum page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor or prev_cursor of a previous page, replaces offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the orders to return, e.g. id,client_id,due_date",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Discount",
+                        "description": "GetAllOrderResponseBody",
                         "schema": {
                             "allOf": [
                                 {
@@ -1321,7 +1589,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Discount"
+                                            "$ref": "#/definitions/order_service.GetListOrderResponse"
                                         }
                                     }
                                 }
@@ -1346,6 +1614,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -1365,9 +1651,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete Mechanic",
+            }
+        },
+        "/order/{id}": {
+            "get": {
+                "description": "Get Order By ID, with expand the related resources are embedded under expanded as in models.ExpandedOrder",
                 "consumes": [
                     "application/json"
                 ],
@@ -1375,10 +1663,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mechanic"
+                    "Order"
                 ],
-                "summary": "Delete Mechanic",
-                "operationId": "delete_discount",
+                "summary": "Get Order By ID",
+                "operationId": "get_order_by_id",
                 "parameters": [
                     {
                         "type": "string",
@@ -1386,11 +1674,29 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated relations: client, car, tarif, mechanic, discount",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, e.g. id,status,total_price or expanded.client.first_name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mechanic data",
+                        "description": "OrderBody",
                         "schema": {
                             "allOf": [
                                 {
@@ -1400,15 +1706,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "304": {
+                        "description": "Not Modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -1425,8 +1731,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "400": {
+                        "description": "Invalid Argument",
                         "schema": {
                             "allOf": [
                                 {
@@ -1442,56 +1748,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/mechanic": {
-            "post": {
-                "description": "Create Mechanic",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Mechanic"
-                ],
-                "summary": "Create Mechanic",
-                "operationId": "create_mechanic",
-                "parameters": [
-                    {
-                        "description": "CreateMechanic",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order_service.CreateMechanic"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "GetMechanicBody",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/http.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/order_service.Mechanic"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
-                    "400": {
-                        "description": "Invalid Argument",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -1527,11 +1786,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/mechanic/{id}": {
-            "get": {
-                "description": "Get Mechanic By ID",
+            },
+            "put": {
+                "description": "Update Order, the fields the caller role may not patch must keep their current value",
                 "consumes": [
                     "application/json"
                 ],
@@ -1539,10 +1796,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Mechanic"
+                    "Order"
                 ],
-                "summary": "Get Mechanic By ID",
-                "operationId": "get_mechanic_by_id",
+                "summary": "Update Order",
+                "operationId": "update_order",
                 "parameters": [
                     {
                         "type": "string",
@@ -1550,11 +1807,26 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpdateOrderRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order_service.UpdateOrder"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Mechanic",
+                        "description": "order data",
                         "schema": {
                             "allOf": [
                                 {
@@ -1564,7 +1836,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Mechanic"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
@@ -1572,7 +1844,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Rejected fields",
                         "schema": {
                             "allOf": [
                                 {
@@ -1582,15 +1854,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.PatchRejected"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -1606,38 +1878,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/model": {
-            "post": {
-                "description": "Create Model",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Model"
-                ],
-                "summary": "Create Model",
-                "operationId": "create_model",
-                "parameters": [
-                    {
-                        "description": "CreateModel",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order_service.CreateModel"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "GetModelBody",
+                    },
+                    "412": {
+                        "description": "Resource was modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -1647,15 +1890,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Model"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid Argument",
+                    "428": {
+                        "description": "If-Match is required",
                         "schema": {
                             "allOf": [
                                 {
@@ -1691,11 +1934,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/model/{id}": {
-            "get": {
-                "description": "Get Model By ID",
+            },
+            "delete": {
+                "description": "Delete Order",
                 "consumes": [
                     "application/json"
                 ],
@@ -1703,10 +1944,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Model"
+                    "Order"
                 ],
-                "summary": "Get Model By ID",
-                "operationId": "get_model_by_id",
+                "summary": "Delete Order",
+                "operationId": "delete_order",
                 "parameters": [
                     {
                         "type": "string",
@@ -1714,11 +1955,17 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Model",
+                        "description": "Order data",
                         "schema": {
                             "allOf": [
                                 {
@@ -1728,7 +1975,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Model"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -1736,7 +1983,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
@@ -1753,8 +2000,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -1770,34 +2017,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete Model",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Model"
-                ],
-                "summary": "Delete Model",
-                "operationId": "delete_model",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Model data",
+                    },
+                    "412": {
+                        "description": "Resource was modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -1807,15 +2029,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "428": {
+                        "description": "If-Match is required",
                         "schema": {
                             "allOf": [
                                 {
@@ -1851,13 +2073,12 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/order": {
-            "get": {
-                "description": "Get Order List",
+            },
+            "patch": {
+                "description": "Patch Order fields allowed for the caller role, the body is either UpdatePatch or a JSON Merge Patch object",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
@@ -1865,31 +2086,35 @@ const docTemplate = `{
                 "tags": [
                     "Order"
                 ],
-                "summary": "Get Order List",
-                "operationId": "get_order_list",
+                "summary": "Patch Order",
+                "operationId": "patch_order",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
+                        "description": "UpdatePatchRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePatch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "GetAllOrderResponseBody",
+                        "description": "Order data",
                         "schema": {
                             "allOf": [
                                 {
@@ -1899,7 +2124,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.GetListOrderResponse"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
@@ -1907,7 +2132,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Rejected fields",
                         "schema": {
                             "allOf": [
                                 {
@@ -1917,15 +2142,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/models.PatchRejected"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -1941,36 +2166,9 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Create Order",
-                "operationId": "create_order",
-                "parameters": [
-                    {
-                        "description": "CreateOrderRequestBody",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order_service.CreateOrder"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "GetOrderBody",
+                    },
+                    "412": {
+                        "description": "Resource was modified",
                         "schema": {
                             "allOf": [
                                 {
@@ -1980,15 +2178,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Order"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid Argument",
+                    "428": {
+                        "description": "If-Match is required",
                         "schema": {
                             "allOf": [
                                 {
@@ -2026,9 +2224,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/order/{id}": {
-            "get": {
-                "description": "Get Order By ID",
+        "/order/{id}/cancel": {
+            "post": {
+                "description": "Cancel a draft or confirmed order",
                 "consumes": [
                     "application/json"
                 ],
@@ -2038,8 +2236,8 @@ const docTemplate = `{
                 "tags": [
                     "Order"
                 ],
-                "summary": "Get Order By ID",
-                "operationId": "get_order_by_id",
+                "summary": "Cancel Order",
+                "operationId": "cancel_order",
                 "parameters": [
                     {
                         "type": "string",
@@ -2047,11 +2245,19 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "ChangeOrderStatusRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeOrderStatus"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OrderBody",
+                        "description": "Order data",
                         "schema": {
                             "allOf": [
                                 {
@@ -2069,7 +2275,43 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed",
                         "schema": {
                             "allOf": [
                                 {
@@ -2105,9 +2347,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update Order",
+            }
+        },
+        "/order/{id}/charges": {
+            "get": {
+                "description": "List extra charges added to the order total",
                 "consumes": [
                     "application/json"
                 ],
@@ -2117,8 +2361,8 @@ const docTemplate = `{
                 "tags": [
                     "Order"
                 ],
-                "summary": "Update Order",
-                "operationId": "update_order",
+                "summary": "Get Order Charges",
+                "operationId": "get_order_charges",
                 "parameters": [
                     {
                         "type": "string",
@@ -2128,18 +2372,15 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "UpdateOrderRequestBody",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order_service.UpdateOrder"
-                        }
+                        "type": "string",
+                        "description": "comma separated fields of the charges items to return, e.g. type,amount",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "order data",
+                        "description": "OrderCharges",
                         "schema": {
                             "allOf": [
                                 {
@@ -2149,7 +2390,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Order"
+                                            "$ref": "#/definitions/order_service.GetOrderChargesResponse"
                                         }
                                     }
                                 }
@@ -2157,7 +2398,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -2193,9 +2452,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete Order",
+            }
+        },
+        "/order/{id}/complete": {
+            "post": {
+                "description": "Close a returned order",
                 "consumes": [
                     "application/json"
                 ],
@@ -2205,8 +2466,8 @@ const docTemplate = `{
                 "tags": [
                     "Order"
                 ],
-                "summary": "Delete Order",
-                "operationId": "delete_order",
+                "summary": "Complete Order",
+                "operationId": "complete_order",
                 "parameters": [
                     {
                         "type": "string",
@@ -2214,6 +2475,14 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "ChangeOrderStatusRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeOrderStatus"
+                        }
                     }
                 ],
                 "responses": {
@@ -2228,7 +2497,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
@@ -2253,8 +2522,8 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "allOf": [
                                 {
@@ -2270,11 +2539,49 @@ const docTemplate = `{
                                 }
                             ]
                         }
-                    }
-                }
-            },
-            "patch": {
-                "description": "Patch Order",
+                    },
+                    "409": {
+                        "description": "Transition not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/confirm": {
+            "post": {
+                "description": "Move a draft order to confirmed",
                 "consumes": [
                     "application/json"
                 ],
@@ -2284,8 +2591,8 @@ const docTemplate = `{
                 "tags": [
                     "Order"
                 ],
-                "summary": "Patch Order",
-                "operationId": "patch_order",
+                "summary": "Confirm Order",
+                "operationId": "confirm_order",
                 "parameters": [
                     {
                         "type": "string",
@@ -2295,12 +2602,11 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "UpdatePatchRequestBody",
+                        "description": "ChangeOrderStatusRequestBody",
                         "name": "profile",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdatePatch"
+                            "$ref": "#/definitions/models.ChangeOrderStatus"
                         }
                     }
                 ],
@@ -2341,6 +2647,42 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -2362,9 +2704,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tarif": {
-            "post": {
-                "description": "Create Tarif",
+        "/order/{id}/deposit": {
+            "get": {
+                "description": "Show the required deposit, its state and the hold, capture and release transactions",
                 "consumes": [
                     "application/json"
                 ],
@@ -2372,24 +2714,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tarif"
+                    "Order"
                 ],
-                "summary": "Create Tarif",
-                "operationId": "create_tarif",
+                "summary": "Get Order Deposit",
+                "operationId": "get_order_deposit",
                 "parameters": [
                     {
-                        "description": "CreateTarif",
-                        "name": "profile",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order_service.CreateTarif"
-                        }
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the transactions items to return, e.g. type,amount",
+                        "name": "fields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "GetTarifBody",
+                        "description": "Deposit",
                         "schema": {
                             "allOf": [
                                 {
@@ -2399,7 +2745,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Tarif"
+                                            "$ref": "#/definitions/order_service.GetDepositTransactionsResponse"
                                         }
                                     }
                                 }
@@ -2424,6 +2770,24 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -2445,9 +2809,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tarif/{id}": {
-            "get": {
-                "description": "Get Model By ID",
+        "/order/{id}/deposit/capture": {
+            "post": {
+                "description": "Deduct damages or fines from the deposit of a returned order, reason is one of damage, fine, other",
                 "consumes": [
                     "application/json"
                 ],
@@ -2455,10 +2819,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tarif"
+                    "Order"
                 ],
-                "summary": "Get Model By ID",
-                "operationId": "get_tarif_by_id",
+                "summary": "Capture Order Deposit",
+                "operationId": "capture_order_deposit",
                 "parameters": [
                     {
                         "type": "string",
@@ -2466,11 +2830,20 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "DepositCaptureRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DepositCapture"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tarif",
+                        "description": "Order data",
                         "schema": {
                             "allOf": [
                                 {
@@ -2480,7 +2853,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/order_service.Tarif"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
@@ -2488,7 +2861,43 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Argument",
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Deposit cannot be captured",
                         "schema": {
                             "allOf": [
                                 {
@@ -2524,9 +2933,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete Tarif",
+            }
+        },
+        "/order/{id}/deposit/hold": {
+            "post": {
+                "description": "Record the deposit taken from the client, it must cover the amount required by the tariff or model",
                 "consumes": [
                     "application/json"
                 ],
@@ -2534,10 +2945,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tarif"
+                    "Order"
                 ],
-                "summary": "Delete Tarif",
-                "operationId": "delete_tarif",
+                "summary": "Hold Order Deposit",
+                "operationId": "hold_order_deposit",
                 "parameters": [
                     {
                         "type": "string",
@@ -2545,11 +2956,20 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "DepositHoldRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DepositHold"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tarif data",
+                        "description": "Order data",
                         "schema": {
                             "allOf": [
                                 {
@@ -2559,7 +2979,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/order_service.Order"
                                         }
                                     }
                                 }
@@ -2584,6 +3004,42 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Deposit cannot be held",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Server Error",
                         "schema": {
@@ -2604,296 +3060,3772 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "client_service.Client": {
-            "type": "object",
-            "properties": {
-                "additional_phone_number": {
-                    "type": "string"
-                },
-                "address": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "driving_license_number": {
-                    "type": "string"
-                },
-                "driving_number_expired": {
-                    "type": "string"
-                },
-                "driving_number_given_date": {
-                    "type": "string"
-                },
-                "driving_number_given_place": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_blocked": {
-                    "type": "boolean"
-                },
-                "last_name": {
-                    "type": "string"
-                },
-                "passport_number": {
-                    "type": "string"
-                },
-                "passport_pinfl": {
-                    "type": "string"
-                },
-                "phone_number": {
-                    "type": "string"
-                },
-                "photo": {
-                    "type": "string"
-                },
-                "propiska": {
-                    "type": "string"
+        },
+        "/order/{id}/deposit/release": {
+            "post": {
+                "description": "Give the remainder of the deposit back to the client",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Release Order Deposit",
+                "operationId": "release_order_deposit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "DepositReleaseRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.DepositRelease"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Deposit cannot be released",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/history": {
+            "get": {
+                "description": "List who changed the order status and when",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order Status History",
+                "operationId": "get_order_status_history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the history items to return, e.g. to_status,created_at",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OrderStatusHistory",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.GetOrderStatusHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/inspections": {
+            "get": {
+                "description": "List pickup and return inspections of the order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order Inspections",
+                "operationId": "get_order_inspections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the inspections items to return, e.g. id,type,mileage",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "VehicleInspections",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.GetVehicleInspectionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/overdue": {
+            "post": {
+                "description": "Move an active order whose due time has passed to overdue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Mark Order Overdue",
+                "operationId": "mark_order_overdue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ChangeOrderStatusRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeOrderStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/payments": {
+            "get": {
+                "description": "List the payment ledger of the order with the paid amount and outstanding balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order Payments",
+                "operationId": "get_order_payments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the payments items to return, e.g. id,amount,method",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payments",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.GetListPaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Record a payment for the order, refunds are recorded with a negative amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Create Order Payment",
+                "operationId": "create_order_payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "CreatePaymentRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePayment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Payment data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.Payment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Balance changed by another payment",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/pickup": {
+            "post": {
+                "description": "Hand the car over to the client recording odometer, fuel level and checklist, the order becomes active",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Pickup Order",
+                "operationId": "pickup_order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "VehicleHandoverRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VehicleHandover"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/return": {
+            "post": {
+                "description": "Take the car back recording odometer, fuel level and checklist, mileage overage, refuelling and late return are charged to the order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Return Order",
+                "operationId": "return_order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "VehicleHandoverRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VehicleHandover"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/order_service.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Transition not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "description": "Get Client List, filter as field=value, field=a,b or field[op]=value with op one of eq, ne, in, gt, gte, lt, lte, like",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Get Client List",
+                "operationId": "get_client_list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit, at most the configured maximum page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a previous page, replaces offset",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "blocked or active clients",
+                        "name": "is_blocked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone number",
+                        "name": "phone_number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "first name contains",
+                        "name": "first_name[like]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last name contains",
+                        "name": "last_name[like]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "clients registered at or after the date",
+                        "name": "created_at[gte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "clients registered at or before the date",
+                        "name": "created_at[lte]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the clients to return, e.g. id,first_name,phone_number",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by first_name, last_name, created_at e.g. created_at:desc, the newest first when not given",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GetAllClientResponseBody",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.GetListClientResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Rejected filters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.QueryRejected"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create Client",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Create Client",
+                "operationId": "create_client",
+                "parameters": [
+                    {
+                        "description": "CreateClient",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/client_service.CreateClient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GetClientBody",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid client fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ValidationFailed"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/block-suggestions": {
+            "get": {
+                "description": "List clients that are not blocked yet but have unpaid balances or damage claims on finished orders, the largest debts first. count is the number of clients with debts, the blocked ones among them are left out of the page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Get Block Suggestions",
+                "operationId": "get_block_suggestions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit, at most the configured maximum page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "BlockSuggestions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BlockSuggestions"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "description": "Get Client By ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Get Client By ID",
+                "operationId": "get_client_by_id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields to return, e.g. id,first_name,phone_number",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Client",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update Client, the fields the caller role may not patch must keep their current value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Update Client",
+                "operationId": "update_client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpdateClientRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/client_service.UpdateClient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Client data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid client fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ValidationFailed"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Resource was modified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete Client",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Delete Client",
+                "operationId": "delete_client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Client data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Resource was modified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Patch Client fields allowed for the caller role, the body is either UpdatePatch or a JSON Merge Patch object",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Patch Client",
+                "operationId": "patch_client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "UpdatePatchRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Client data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid client fields",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ValidationFailed"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "Resource was modified",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match is required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/block": {
+            "post": {
+                "description": "Block the client, reason is one of unpaid_balance, damage, fraud, traffic_violations, other",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Block Client",
+                "operationId": "block_client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "BlockClientRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockClient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Client data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Client is already blocked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/block-history": {
+            "get": {
+                "description": "List who blocked and unblocked the client, when and why",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Get Client Block History",
+                "operationId": "get_client_block_history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated fields of the history items to return, e.g. action,reason",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ClientBlockHistory",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.GetClientBlockHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}/unblock": {
+            "post": {
+                "description": "Unblock the client, reason is one of debt_settled, appeal, mistake, other",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Unblock Client",
+                "operationId": "unblock_client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "UnblockClientRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockClient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Client data",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/client_service.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Client is not blocked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "List the subscriptions without their secrets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get Webhook List",
+                "operationId": "get_webhook_list",
+                "responses": {
+                    "200": {
+                        "description": "Webhooks",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribe an url to events, the deliveries are signed with the secret, see the X-Car24-Signature header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Create Webhook",
+                "operationId": "create_webhook",
+                "parameters": [
+                    {
+                        "description": "CreateWebhookRequestBody",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Argument",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/dead-letters": {
+            "get": {
+                "description": "List the deliveries that failed every attempt, the latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get Webhook Dead Letters",
+                "operationId": "get_webhook_dead_letters",
+                "responses": {
+                    "200": {
+                        "description": "Dead letters",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookDeliveryList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/dead-letters/replay": {
+            "post": {
+                "description": "Send every failed delivery of the existing subscriptions again, as many as the delivery queue has room for",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Replay Webhook Dead Letters",
+                "operationId": "replay_webhook_dead_letters",
+                "responses": {
+                    "202": {
+                        "description": "Replayed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookReplay"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/dead-letters/{id}/replay": {
+            "post": {
+                "description": "Send the failed delivery again, it becomes a dead letter again when every attempt fails",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Replay Webhook Dead Letter",
+                "operationId": "replay_webhook_dead_letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Replayed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookReplay"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription was removed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "The delivery queue is full",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "description": "Remove the subscription, the deliveries still being retried are dropped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Delete Webhook",
+                "operationId": "delete_webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "description": "WebSocket with the events of the topics orders, cars and alerts as JSON messages, besides them the server sends ping messages and a reset message when the events after last_event_id are gone. Browsers pass the token as access_token",
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "WebSocket",
+                "operationId": "websocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated topics, by default every topic of the role",
+                        "name": "topics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT for clients that cannot send the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last received event",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown topic",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Topic not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/http.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "client_service.Client": {
+            "type": "object",
+            "properties": {
+                "additional_phone_number": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "driving_license_number": {
+                    "type": "string"
+                },
+                "driving_number_expired": {
+                    "type": "string"
+                },
+                "driving_number_given_date": {
+                    "type": "string"
+                },
+                "driving_number_given_place": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "passport_number": {
+                    "type": "string"
+                },
+                "passport_pinfl": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "propiska": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "client_service.ClientBlockHistory": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "client_service.CreateClient": {
+            "type": "object",
+            "properties": {
+                "additional_phone_number": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "driving_license_number": {
+                    "type": "string"
+                },
+                "driving_number_expired": {
+                    "type": "string"
+                },
+                "driving_number_given_date": {
+                    "type": "string"
+                },
+                "driving_number_given_place": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "passport_number": {
+                    "type": "string"
+                },
+                "passport_pinfl": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "propiska": {
+                    "type": "string"
+                }
+            }
+        },
+        "client_service.CreateOTP": {
+            "type": "object",
+            "properties": {
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "client_service.GetClientBlockHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/client_service.ClientBlockHistory"
+                    }
+                }
+            }
+        },
+        "client_service.GetListClientResponse": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/client_service.Client"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "client_service.UpdateClient": {
+            "type": "object",
+            "properties": {
+                "additional_phone_number": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "driving_license_number": {
+                    "type": "string"
+                },
+                "driving_number_expired": {
+                    "type": "string"
+                },
+                "driving_number_given_date": {
+                    "type": "string"
+                },
+                "driving_number_given_place": {
+                    "type": "string"
+                },
+                "expected_updated_at": {
+                    "description": "expected_updated_at makes the update conditional, the service changes nothing and answers\nABORTED when the stored updated_at differs, the gateway sets it to the version If-Match names",
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "passport_number": {
+                    "type": "string"
+                },
+                "passport_pinfl": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "photo": {
+                    "type": "string"
+                },
+                "propiska": {
+                    "type": "string"
+                }
+            }
+        },
+        "eligibility.Failure": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "description": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object"
+                },
+                "dependsOn": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BlockClient": {
+            "type": "object",
+            "required": [
+                "comment",
+                "reason"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BlockSuggestion": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "damage_claims": {
+                    "type": "number"
+                },
+                "order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "outstanding_balance": {
+                    "type": "number"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BlockSuggestions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BlockSuggestion"
+                    }
+                }
+            }
+        },
+        "models.ChangeOrderStatus": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChecklistItem": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "ok": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CreatePayment": {
+            "type": "object",
+            "required": [
+                "amount",
+                "method"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateWebhook": {
+            "type": "object",
+            "required": [
+                "events",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "client_service.CreateClient": {
+        "models.DepositCapture": {
             "type": "object",
+            "required": [
+                "amount",
+                "reason"
+            ],
             "properties": {
-                "additional_phone_number": {
-                    "type": "string"
-                },
-                "address": {
-                    "type": "string"
-                },
-                "driving_license_number": {
-                    "type": "string"
-                },
-                "driving_number_expired": {
-                    "type": "string"
+                "amount": {
+                    "type": "number"
                 },
-                "driving_number_given_date": {
+                "comment": {
                     "type": "string"
                 },
-                "driving_number_given_place": {
+                "reason": {
                     "type": "string"
+                }
+            }
+        },
+        "models.DepositHold": {
+            "type": "object",
+            "required": [
+                "amount",
+                "method"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "first_name": {
+                "comment": {
                     "type": "string"
                 },
-                "last_name": {
+                "method": {
                     "type": "string"
-                },
-                "passport_number": {
+                }
+            }
+        },
+        "models.DepositRelease": {
+            "type": "object",
+            "properties": {
+                "comment": {
                     "type": "string"
-                },
-                "passport_pinfl": {
+                }
+            }
+        },
+        "models.EligibilityFailed": {
+            "type": "object",
+            "properties": {
+                "client_id": {
                     "type": "string"
                 },
-                "phone_number": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/eligibility.Failure"
+                    }
+                }
+            }
+        },
+        "models.GraphQLRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
                     "type": "string"
                 },
-                "photo": {
+                "query": {
                     "type": "string"
                 },
-                "propiska": {
-                    "type": "string"
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
-        "client_service.CreateOTP": {
+        "models.PatchRejected": {
             "type": "object",
             "properties": {
-                "phone_number": {
-                    "type": "string"
+                "rejected": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/patch.Rejection"
+                    }
                 }
             }
         },
-        "client_service.GetListClientResponse": {
+        "models.QueryRejected": {
             "type": "object",
             "properties": {
-                "clients": {
+                "rejected": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/client_service.Client"
+                        "$ref": "#/definitions/query.Rejection"
                     }
-                },
-                "count": {
-                    "type": "integer"
                 }
             }
         },
-        "client_service.UpdateClient": {
+        "models.UpdatePatch": {
             "type": "object",
             "properties": {
-                "additional_phone_number": {
-                    "type": "string"
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "address": {
+                "id": {
                     "type": "string"
+                }
+            }
+        },
+        "models.ValidationFailed": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validation.FieldError"
+                    }
+                }
+            }
+        },
+        "models.VehicleHandover": {
+            "type": "object",
+            "required": [
+                "fuel_level",
+                "mileage"
+            ],
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChecklistItem"
+                    }
                 },
-                "driving_license_number": {
+                "comment": {
                     "type": "string"
                 },
-                "driving_number_expired": {
-                    "type": "string"
+                "fuel_level": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
                 },
-                "driving_number_given_date": {
-                    "type": "string"
+                "mileage": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "driving_number_given_place": {
+                "notes": {
                     "type": "string"
+                }
+            }
+        },
+        "models.WebhookDeliveryList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "first_name": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhook.Delivery"
+                    }
+                }
+            }
+        },
+        "models.WebhookList": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhook.Subscription"
+                    }
+                }
+            }
+        },
+        "models.WebhookReplay": {
+            "type": "object",
+            "properties": {
+                "replayed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "order_service.Car": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "last_name": {
+                "model_id": {
                     "type": "string"
                 },
-                "passport_number": {
+                "state_number": {
                     "type": "string"
                 },
-                "passport_pinfl": {
-                    "type": "string"
+                "status": {
+                    "type": "boolean"
                 },
-                "phone_number": {
+                "tarif_id": {
                     "type": "string"
                 },
-                "photo": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "order_service.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "name": {
                     "type": "string"
                 },
-                "propiska": {
+                "note": {
                     "type": "string"
+                },
+                "ok": {
+                    "type": "boolean"
                 }
             }
         },
-        "http.Response": {
+        "order_service.CreateCar": {
             "type": "object",
             "properties": {
-                "data": {},
-                "description": {
+                "model_id": {
                     "type": "string"
                 },
-                "status": {
+                "state_number": {
+                    "type": "string"
+                },
+                "tarif_id": {
                     "type": "string"
                 }
             }
         },
-        "models.UpdatePatch": {
+        "order_service.CreateOrder": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "object",
-                    "additionalProperties": true
+                "car_id": {
+                    "type": "string"
                 },
-                "id": {
+                "client_id": {
+                    "type": "string"
+                },
+                "day_count": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "string"
+                },
+                "mechanic_id": {
+                    "type": "string"
+                },
+                "miliage": {
+                    "type": "integer"
+                },
+                "start_date": {
                     "type": "string"
+                },
+                "tarif_id": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
                 }
             }
         },
-        "order_service.Car": {
+        "order_service.DepositTransaction": {
             "type": "object",
             "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "comment": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "model_id": {
+                "method": {
                     "type": "string"
                 },
-                "state_number": {
+                "operator_id": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "boolean"
+                "order_id": {
+                    "type": "string"
                 },
-                "tarif_id": {
+                "reason": {
                     "type": "string"
                 },
-                "updated_at": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "order_service.CreateCar": {
+        "order_service.GetDepositTransactionsResponse": {
             "type": "object",
             "properties": {
-                "model_id": {
+                "count": {
+                    "type": "integer"
+                },
+                "deposit_captured": {
+                    "type": "number"
+                },
+                "deposit_held": {
+                    "type": "number"
+                },
+                "deposit_released": {
+                    "type": "number"
+                },
+                "deposit_remaining": {
+                    "type": "number"
+                },
+                "deposit_required": {
+                    "type": "number"
+                },
+                "deposit_status": {
                     "type": "string"
                 },
-                "state_number": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.DepositTransaction"
+                    }
+                }
+            }
+        },
+        "order_service.GetListCarResponse": {
+            "type": "object",
+            "properties": {
+                "cars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.Car"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
                     "type": "string"
                 },
-                "tarif_id": {
+                "prev_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "order_service.GetListOrderResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.Order"
+                    }
+                },
+                "prev_cursor": {
                     "type": "string"
                 }
             }
         },
-        "order_service.CreateDiscount": {
+        "order_service.GetListPaymentResponse": {
             "type": "object",
             "properties": {
-                "discount_amount": {
+                "balance": {
                     "type": "number"
                 },
-                "discount_type": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "paid_price": {
+                    "type": "number"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.Payment"
+                    }
                 }
             }
         },
-        "order_service.CreateMechanic": {
+        "order_service.GetOrderChargesResponse": {
             "type": "object",
             "properties": {
-                "fullname": {
-                    "type": "string"
-                },
-                "phone_number": {
-                    "type": "string"
+                "charges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.OrderCharge"
+                    }
                 },
-                "photo": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "order_service.GetOrderStatusHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "price_per_hour": {
-                    "type": "string"
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.OrderStatusHistory"
+                    }
                 }
             }
         },
-        "order_service.CreateModel": {
+        "order_service.GetVehicleInspectionsResponse": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
+                },
+                "inspections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.VehicleInspection"
+                    }
                 }
             }
         },
-        "order_service.CreateOrder": {
+        "order_service.Order": {
             "type": "object",
             "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
                 "car_id": {
                     "type": "string"
                 },
                 "client_id": {
                     "type": "string"
                 },
+                "completed_at": {
+                    "type": "string"
+                },
+                "confirmed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "day_count": {
                     "type": "integer"
                 },
+                "deposit_captured": {
+                    "type": "number"
+                },
+                "deposit_held": {
+                    "type": "number"
+                },
+                "deposit_released": {
+                    "type": "number"
+                },
+                "deposit_required": {
+                    "type": "number"
+                },
+                "deposit_status": {
+                    "type": "string"
+                },
                 "discount": {
                     "type": "string"
                 },
+                "distance_driven": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "extra_charges": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_overdue": {
+                    "type": "boolean"
+                },
                 "is_paid_date": {
                     "type": "string"
                 },
@@ -2903,129 +6835,144 @@ const docTemplate = `{
                 "miliage": {
                     "type": "integer"
                 },
+                "order_number": {
+                    "type": "string"
+                },
                 "paid_price": {
                     "type": "number"
                 },
+                "picked_up_at": {
+                    "type": "string"
+                },
+                "pickup_mileage": {
+                    "type": "integer"
+                },
+                "return_mileage": {
+                    "type": "integer"
+                },
+                "returned_at": {
+                    "type": "string"
+                },
                 "start_date": {
                     "type": "string"
                 },
                 "status": {
-                    "type": "boolean"
+                    "type": "string"
                 },
                 "tarif_id": {
                     "type": "string"
                 },
                 "total_price": {
                     "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "order_service.CreateTarif": {
+        "order_service.OrderCharge": {
             "type": "object",
             "properties": {
-                "model_id": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
                     "type": "string"
                 },
-                "price_per_day": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "order_service.Discount": {
+        "order_service.OrderStatusHistory": {
             "type": "object",
             "properties": {
-                "create_at": {
+                "changed_by": {
                     "type": "string"
                 },
-                "discount_amount": {
-                    "type": "number"
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
                 },
-                "discount_type": {
+                "from_status": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "order_id": {
                     "type": "string"
                 },
-                "update_at": {
+                "to_status": {
                     "type": "string"
                 }
             }
         },
-        "order_service.GetListCarResponse": {
+        "order_service.Payment": {
             "type": "object",
             "properties": {
-                "cars": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/order_service.Car"
-                    }
+                "amount": {
+                    "type": "number"
                 },
-                "count": {
-                    "type": "integer"
-                }
-            }
-        },
-        "order_service.GetListOrderResponse": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
+                "comment": {
+                    "type": "string"
                 },
-                "orders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/order_service.Order"
-                    }
-                }
-            }
-        },
-        "order_service.Mechanic": {
-            "type": "object",
-            "properties": {
-                "fullname": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "phone_number": {
+                "method": {
                     "type": "string"
                 },
-                "photo": {
+                "operator_id": {
                     "type": "string"
                 },
-                "price_per_hour": {
+                "order_id": {
                     "type": "string"
-                },
-                "status": {
-                    "type": "boolean"
                 }
             }
         },
-        "order_service.Model": {
+        "order_service.UpdateCar": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "expected_updated_at": {
+                    "description": "expected_updated_at makes the update conditional, the service changes nothing and answers\nABORTED when the stored updated_at differs, the gateway sets it to the version If-Match names",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "model_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "state_number": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "tarif_id": {
                     "type": "string"
                 }
             }
         },
-        "order_service.Order": {
+        "order_service.UpdateOrder": {
             "type": "object",
             "properties": {
                 "car_id": {
@@ -3034,22 +6981,17 @@ const docTemplate = `{
                 "client_id": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
                 "day_count": {
                     "type": "integer"
                 },
                 "discount": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "is_paid_date": {
+                "expected_updated_at": {
+                    "description": "expected_updated_at makes the update conditional, the service changes nothing and answers\nABORTED when the stored updated_at differs, the gateway sets it to the version If-Match names",
                     "type": "string"
                 },
-                "mechanic_id": {
+                "id": {
                     "type": "string"
                 },
                 "miliage": {
@@ -3058,104 +7000,148 @@ const docTemplate = `{
                 "order_number": {
                     "type": "string"
                 },
-                "paid_price": {
-                    "type": "number"
-                },
                 "start_date": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "boolean"
-                },
                 "tarif_id": {
                     "type": "string"
                 },
                 "total_price": {
                     "type": "number"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "order_service.Tarif": {
+        "order_service.VehicleInspection": {
             "type": "object",
             "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/order_service.ChecklistItem"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "fuel_level": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
-                "model_id": {
+                "mileage": {
+                    "type": "integer"
+                },
+                "notes": {
                     "type": "string"
                 },
-                "name": {
+                "order_id": {
                     "type": "string"
                 },
-                "price_per_day": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "order_service.UpdateCar": {
+        "patch.Rejection": {
             "type": "object",
             "properties": {
-                "id": {
+                "field": {
                     "type": "string"
                 },
-                "model_id": {
+                "reason": {
                     "type": "string"
-                },
-                "state_number": {
+                }
+            }
+        },
+        "query.Rejection": {
+            "type": "object",
+            "properties": {
+                "param": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "boolean"
-                },
-                "tarif_id": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "order_service.UpdateOrder": {
+        "validation.FieldError": {
             "type": "object",
             "properties": {
-                "car_id": {
+                "field": {
                     "type": "string"
                 },
-                "client_id": {
+                "message": {
                     "type": "string"
-                },
-                "day_count": {
+                }
+            }
+        },
+        "webhook.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
                     "type": "integer"
                 },
-                "discount": {
+                "event": {
+                    "$ref": "#/definitions/webhook.Event"
+                },
+                "failed_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_paid_date": {
+                "last_error": {
                     "type": "string"
                 },
-                "miliage": {
+                "last_status": {
                     "type": "integer"
                 },
-                "order_number": {
+                "subscription_id": {
                     "type": "string"
                 },
-                "paid_price": {
-                    "type": "number"
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Event": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "start_date": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "boolean"
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Subscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "tarif_id": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
-                "total_price": {
-                    "type": "number"
+                "url": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	authUserIDKey = "auth_user_id"
+	authRoleKey   = "auth_role"
+)
+
+// publicRoutes answer without a token: the OTP login that issues the tokens and the documentation
+var publicRoutes = map[string]bool{
+	"POST /check":       true,
+	"GET /check":        true,
+	"GET /openapi.json": true,
+	"GET /docs":         true,
+	"GET /swagger/*any": true,
+}
+
+// queryTokenRoutes also take the token from access_token, EventSource and WebSocket in browsers
+// cannot send headers
+var queryTokenRoutes = map[string]bool{
+	"GET /ws":     true,
+	"GET /events": true,
+}
+
+// AuthMiddleware resolves the caller from the bearer token, requests without a token are only
+// let through to the public routes
+func (h *Handler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			token := c.Query("access_token")
+			switch {
+			case token != "" && queryTokenRoutes[route]:
+				if !h.authenticate(c, token) {
+					c.Abort()
+					return
+				}
+			case !publicRoutes[route] && c.FullPath() != "":
+				h.handleResponse(c, http.Unauthorized, "authorization token is required")
+				c.Abort()
+				return
+			}
+
+			c.Next()
+			return
+		}
+
+		token, err := helper.ExtractToken(header)
+		if err != nil {
+			h.handleResponse(c, http.Unauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		if !h.authenticate(c, token) {
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate sets the caller from the claims of the token, it answers 401 when the token is invalid
+func (h *Handler) authenticate(c *gin.Context, token string) bool {
+	claims, err := helper.ExtractClaims(token, h.cfg.SecretKey)
+	if err != nil {
+		h.handleResponse(c, http.Unauthorized, err.Error())
+		return false
+	}
+
+	userId, _ := claims["id"].(string)
+	role, _ := claims["role"].(string)
+	if role == "" {
+		// tokens issued before roles existed were only given to clients
+		role = config.RoleClient
+	}
+
+	c.Set(authUserIDKey, userId)
+	c.Set(authRoleKey, role)
+	return true
+}
+
+// getAuthUserID returns the id of the authenticated caller, or an empty string when the request is anonymous
+func (h *Handler) getAuthUserID(c *gin.Context) string {
+	return c.GetString(authUserIDKey)
+}
+
+// getAuthRole returns the role of the caller, the public routes are called with the client role
+func (h *Handler) getAuthRole(c *gin.Context) string {
+	if role := c.GetString(authRoleKey); role != "" {
+		return role
+	}
+	return config.RoleClient
+}
+
+// isStaff reports whether the caller works in the back office, every other role is treated as a client
+func (h *Handler) isStaff(c *gin.Context) bool {
+	switch h.getAuthRole(c) {
+	case config.RoleOperator, config.RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// ensureStaff answers 403 unless the caller is an operator or an admin, it guards the back office
+// routes that move money, change the order lifecycle or reach the data of every client
+func (h *Handler) ensureStaff(c *gin.Context) bool {
+	if !h.isStaff(c) {
+		h.handleResponse(c, http.Forbidden, "only operators and admins have access")
+		return false
+	}
+	return true
+}
+
+// ensureOwner answers 403 when a client reaches for the data of another client
+func (h *Handler) ensureOwner(c *gin.Context, clientId string) bool {
+	if !h.isStaff(c) && h.getAuthUserID(c) != clientId {
+		h.handleResponse(c, http.Forbidden, "clients can only access their own data")
+		return false
+	}
+	return true
+}
+
+// ensureOrderOwner checks that a client calls for one of their own orders, the order is only loaded
+// for clients
+func (h *Handler) ensureOrderOwner(c *gin.Context, orderId string) bool {
+	if h.isStaff(c) {
+		return true
+	}
+
+	order, err := h.services.OrderService().GetByID(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{
+			Id:        orderId,
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"client_id"}},
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return false
+	}
+
+	return h.ensureOwner(c, order.ClientId)
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/batch"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htp "net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+var batchMethods = map[string]bool{
+	htp.MethodGet:    true,
+	htp.MethodPost:   true,
+	htp.MethodPut:    true,
+	htp.MethodPatch:  true,
+	htp.MethodDelete: true,
+}
+
+// Batch godoc
+// @ID batch
+// @Router /batch [POST]
+// @Summary Batch
+// @Description Run several API requests in one round trip, in the given order, through the gateway routes with the caller's Authorization.
+// @Description A path or body may use the response of an earlier request as $N.field, like $1.id, counting the requests from 1, dependsOn lists more requests that must succeed first.
+// @Description A request whose dependency failed is answered with 424 and not run, with atomic=true the batch stops at the first failure, the requests that already ran are not undone.
+// @Tags Batch
+// @Accept json
+// @Produce json
+// @Param atomic query boolean false "stop at the first failed request"
+// @Param requests body []models.BatchRequest true "BatchRequestBody"
+// @Success 200 {object} http.Response{data=[]models.BatchResponse} "Responses in the order of the requests"
+// @Response 400 {object} http.Response{data=string} "Invalid batch"
+func (h *Handler) Batch(router htp.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requests []models.BatchRequest
+
+		err := c.ShouldBindJSON(&requests)
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, err.Error())
+			return
+		}
+
+		if len(requests) == 0 {
+			h.handleResponse(c, http.InvalidArgument, "batch has no requests")
+			return
+		}
+		if len(requests) > h.cfg.MaxBatchSize {
+			h.handleResponse(c, http.InvalidArgument, fmt.Sprintf("batch has %d requests, at most %d are allowed", len(requests), h.cfg.MaxBatchSize))
+			return
+		}
+
+		dependencies := make([][]int, len(requests))
+		for i, request := range requests {
+			dependencies[i], err = batchDependencies(i, request)
+			if err != nil {
+				h.handleResponse(c, http.InvalidArgument, fmt.Sprintf("request %d: %s", i+1, err))
+				return
+			}
+		}
+
+		var (
+			atomic    = cast.ToBool(c.Query("atomic"))
+			failed    = 0
+			results   = make(batch.Results, len(requests))
+			responses = make([]models.BatchResponse, len(requests))
+		)
+
+		for i, request := range requests {
+			if atomic && failed > 0 {
+				responses[i] = batchSkipped(fmt.Sprintf("not run, request %d failed and the batch is atomic", failed))
+				continue
+			}
+
+			if dependency := failedDependency(dependencies[i], results); dependency > 0 {
+				responses[i] = batchSkipped(fmt.Sprintf("not run, request %d it depends on did not succeed", dependency))
+				if failed == 0 {
+					failed = i + 1
+				}
+				continue
+			}
+
+			responses[i], results[i] = h.runBatchRequest(c, router, request, results)
+			if results[i] == nil && failed == 0 {
+				failed = i + 1
+			}
+		}
+
+		h.handleResponse(c, http.OK, responses)
+	}
+}
+
+// batchDependencies checks the request and returns the earlier requests it depends on
+func batchDependencies(index int, request models.BatchRequest) ([]int, error) {
+	request.Method = strings.ToUpper(request.Method)
+	if !batchMethods[request.Method] {
+		return nil, fmt.Errorf("method %q is not allowed", request.Method)
+	}
+	if !strings.HasPrefix(request.Path, "/") {
+		return nil, fmt.Errorf("path %q must start with /", request.Path)
+	}
+	if request.Path == "/batch" || strings.HasPrefix(request.Path, "/batch?") || strings.HasPrefix(request.Path, "/batch/") {
+		return nil, fmt.Errorf("batches cannot be nested")
+	}
+
+	dependencies := append([]int(nil), request.DependsOn...)
+	dependencies = append(dependencies, batch.References(request.Path, string(request.Body))...)
+
+	for _, dependency := range dependencies {
+		if dependency < 1 || dependency > index {
+			return nil, fmt.Errorf("can only depend on the requests before it, not on %d", dependency)
+		}
+	}
+
+	return dependencies, nil
+}
+
+func failedDependency(dependencies []int, results batch.Results) int {
+	for _, dependency := range dependencies {
+		if results[dependency-1] == nil {
+			return dependency
+		}
+	}
+	return 0
+}
+
+// runBatchRequest sends the request through the router, the decoded body is returned when it succeeded
+func (h *Handler) runBatchRequest(c *gin.Context, router htp.Handler, request models.BatchRequest, results batch.Results) (models.BatchResponse, interface{}) {
+	path, err := results.Path(request.Path)
+	if err != nil {
+		return batchFailed(http.InvalidArgument, err.Error()), nil
+	}
+
+	var body []byte
+	if len(request.Body) > 0 && string(request.Body) != "null" {
+		decoded, err := decodeJSON(request.Body)
+		if err != nil {
+			return batchFailed(http.BadRequest, err.Error()), nil
+		}
+
+		decoded, err = results.Body(decoded)
+		if err != nil {
+			return batchFailed(http.InvalidArgument, err.Error()), nil
+		}
+
+		body, err = json.Marshal(decoded)
+		if err != nil {
+			return batchFailed(http.BadRequest, err.Error()), nil
+		}
+	}
+
+	sub, err := htp.NewRequestWithContext(c.Request.Context(), strings.ToUpper(request.Method), path, bytes.NewReader(body))
+	if err != nil {
+		return batchFailed(http.InvalidArgument, err.Error()), nil
+	}
+
+	sub.Header.Set("Content-Type", "application/json")
+	if authorization := c.GetHeader("Authorization"); authorization != "" {
+		sub.Header.Set("Authorization", authorization)
+	}
+	for key, value := range request.Headers {
+		sub.Header.Set(key, value)
+	}
+	// the answers are embedded in the JSON of the batch
+	sub.Header.Del("Accept")
+	sub.Header.Del("Accept-Encoding")
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, sub)
+
+	response := models.BatchResponse{
+		Status:  recorder.Code,
+		Headers: map[string]string{},
+	}
+	for key, values := range recorder.Header() {
+		response.Headers[key] = strings.Join(values, ", ")
+	}
+
+	if recorder.Body.Len() > 0 {
+		if json.Valid(recorder.Body.Bytes()) {
+			response.Body = recorder.Body.Bytes()
+		} else {
+			response.Body, _ = json.Marshal(recorder.Body.String())
+		}
+	}
+
+	if recorder.Code >= 300 {
+		return response, nil
+	}
+
+	result, err := decodeJSON(response.Body)
+	if err != nil || result == nil {
+		// an empty answer still lets the requests depending on it run
+		result = map[string]interface{}{}
+	}
+
+	return response, result
+}
+
+func batchSkipped(message string) models.BatchResponse {
+	return batchFailed(http.FailedDependency, message)
+}
+
+func batchFailed(status http.Status, message string) models.BatchResponse {
+	body, _ := json.Marshal(message)
+	return models.BatchResponse{Status: status.Code, Body: body}
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	var decoded interface{}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&decoded)
+	return decoded, err
+}
@@ -6,6 +6,7 @@ import (
 	"Projects/Car24/car24_api_gateway/models"
 	"Projects/Car24/car24_api_gateway/pkg/helper"
 	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
 	"context"
 
 	"github.com/gin-gonic/gin"
@@ -22,13 +23,16 @@ import (
 // @Param profile body order_service.CreateCar true "CreateCar"
 // @Success 200 {object} http.Response{data=order_service.Car} "GetCarBody"
 // @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) CreateCar(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
 	var car order_service.CreateCar
 
-	err := c.ShouldBindJSON(&car)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &car) {
 		return
 	}
 
@@ -41,6 +45,7 @@ func (h *Handler) CreateCar(c *gin.Context) {
 		return
 	}
 
+	h.publish(webhook.CarCreated, resp)
 	h.handleResponse(c, http.Created, resp)
 }
 
@@ -48,12 +53,16 @@ func (h *Handler) CreateCar(c *gin.Context) {
 // @ID get_car_by_id
 // @Router /car/{id} [GET]
 // @Summary Get Car By ID
-// @Description Get Car By ID
+// @Description Get Car By ID, with expand the model and tariff are embedded under expanded as in models.ExpandedCar
 // @Tags Car
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param expand query string false "comma separated relations: model, tarif"
+// @Param fields query string false "comma separated fields to return, e.g. id,state_number or expanded.model.name"
+// @Param If-None-Match header string false "ETag of the version the client has"
 // @Success 200 {object} http.Response{data=order_service.Car} "Car"
+// @Response 304 {object} http.Response{data=string} "Not Modified"
 // @Response 400 {object} http.Response{data=string} "Invalid Argument"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) GetCarByID(c *gin.Context) {
@@ -64,10 +73,21 @@ func (h *Handler) GetCarByID(c *gin.Context) {
 		return
 	}
 
+	relations, ok := h.getExpand(c, carExpandRelations)
+	if !ok {
+		return
+	}
+
+	fields, ok := h.getFields(c, carDescriptor, "", carExtensions)
+	if !ok {
+		return
+	}
+
 	resp, err := h.services.CarService().GetByID(
 		context.Background(),
 		&order_service.CarPrimaryKey{
-			Id: carId,
+			Id:        carId,
+			FieldMask: backendMask(fields, carGatewayFields),
 		},
 	)
 	if err != nil {
@@ -75,6 +95,17 @@ func (h *Handler) GetCarByID(c *gin.Context) {
 		return
 	}
 
+	if len(relations) > 0 {
+		expanded, errs := h.expandCars(c.Request.Context(), []*order_service.Car{resp}, relations)
+		expanded[0].ExpandErrors = errs
+		h.setETag(c, resp)
+		h.handleResponse(c, http.OK, expanded[0])
+		return
+	}
+
+	if h.notModified(c, resp) {
+		return
+	}
 	h.handleResponse(c, http.OK, resp)
 }
 
@@ -82,36 +113,61 @@ func (h *Handler) GetCarByID(c *gin.Context) {
 // @ID get_car_list
 // @Router /car [GET]
 // @Summary Get Car List
-// @Description Get Car List
+// @Description Get Car List, filter as field=value, field=a,b or field[op]=value with op one of eq, ne, in, gt, gte, lt, lte, like, expand embeds the model and tariff as in models.ExpandedCarList
 // @Tags Car
 // @Accept json
 // @Produce json
 // @Param offset query integer false "offset"
-// @Param limit query integer false "limit"
+// @Param limit query integer false "limit, at most the configured maximum page size"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, replaces offset"
 // @Param search query string false "search"
+// @Param status query boolean false "car status"
+// @Param tarif_id query string false "tarif id"
+// @Param model_id query string false "model id"
+// @Param state_number[like] query string false "state number contains"
+// @Param created_at[gte] query string false "cars added at or after the date"
+// @Param created_at[lte] query string false "cars added at or before the date"
+// @Param expand query string false "comma separated relations: model, tarif"
+// @Param fields query string false "comma separated fields to return, e.g. id,state_number or expanded.model.name"
+// @Param sort query string false "sort by state_number, created_at e.g. state_number:asc, the newest first when not given"
+// @Header 200 {string} Link "next, prev and first page links"
 // @Success 200 {object} http.Response{data=order_service.GetListCarResponse} "GetAllCarResponseBody"
-// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 400 {object} http.Response{data=models.QueryRejected} "Rejected filters"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) GetCarList(c *gin.Context) {
 
-	offset, err := h.getOffsetParam(c)
-	if err != nil {
-		h.handleResponse(c, http.InvalidArgument, err.Error())
+	current, ok := h.getPage(c)
+	if !ok {
 		return
 	}
 
-	limit, err := h.getLimitParam(c)
-	if err != nil {
-		h.handleResponse(c, http.InvalidArgument, err.Error())
+	listQuery, ok := h.parseListQuery(c, carQuerySchema)
+	if !ok {
+		return
+	}
+
+	relations, ok := h.getExpand(c, carExpandRelations)
+	if !ok {
+		return
+	}
+
+	fields, ok := h.getFields(c, carDescriptor, "cars", carExtensions)
+	if !ok {
 		return
 	}
+	listQuery.Sort = keysetSort(listQuery.Sort)
+	filters, sorts := orderFilters(listQuery)
 
 	resp, err := h.services.CarService().GetList(
 		context.Background(),
 		&order_service.GetListCarRequest{
-			Limit:  int64(limit),
-			Offset: int64(offset),
-			Search: c.Query("search"),
+			Limit:     current.FetchLimit(),
+			Offset:    current.Offset,
+			Keyset:    current.orderKeyset(),
+			Search:    c.Query("search"),
+			Filters:   filters,
+			Sort:      sorts,
+			FieldMask: backendMask(fields, withSortFields(carGatewayFields, listQuery.Sort)),
 		},
 	)
 
@@ -120,22 +176,43 @@ func (h *Handler) GetCarList(c *gin.Context) {
 		return
 	}
 
+	resp.Cars, resp.NextCursor, resp.PrevCursor = pageRows(h, c, current, listQuery.Sort, resp.Cars)
+
+	if len(relations) > 0 {
+		expanded, errs := h.expandCars(c.Request.Context(), resp.Cars, relations)
+		h.handleResponse(c, http.OK, models.ExpandedCarList{
+			Count:        resp.Count,
+			Cars:         expanded,
+			NextCursor:   resp.NextCursor,
+			PrevCursor:   resp.PrevCursor,
+			ExpandErrors: errs,
+		})
+		return
+	}
+
 	h.handleResponse(c, http.OK, resp)
 }
 
 // @ID update_car
 // @Router /car/{id} [PUT]
 // @Summary Update Car
-// @Description Update Car
+// @Description Update Car, the fields the caller role may not patch must keep their current value
 // @Tags Car
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param profile body order_service.UpdateCar true "UpdateCarRequestBody"
 // @Success 200 {object} http.Response{data=order_service.Car} "Car data"
-// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 400 {object} http.Response{data=models.PatchRejected} "Rejected fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpdateCar(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
 
 	var car order_service.UpdateCar
 
@@ -146,22 +223,40 @@ func (h *Handler) UpdateCar(c *gin.Context) {
 		return
 	}
 
-	err := c.ShouldBindJSON(&car)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &car) {
 		return
 	}
 
+	current, ok := h.loadCurrent(c, h.currentCar(c, car.Id))
+	if !ok {
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, current)
+	if !ok {
+		return
+	}
+
+	if !h.checkUpdate(c, carPatchPolicy, current, &car) {
+		return
+	}
+
+	if expected != "" {
+		car.ExpectedUpdatedAt = expected
+	}
+
 	resp, err := h.services.CarService().Update(
 		c.Request.Context(),
 		&car,
 	)
 
 	if err != nil {
-		h.handleResponse(c, http.GRPCError, err.Error())
+		h.handleWriteError(c, err)
 		return
 	}
 
+	h.setETag(c, resp)
+	h.publish(webhook.CarUpdated, resp)
 	h.handleResponse(c, http.OK, resp)
 }
 
@@ -169,25 +264,26 @@ func (h *Handler) UpdateCar(c *gin.Context) {
 // @ID patch_car
 // @Router /car/{id} [PATCH]
 // @Summary Patch Car
-// @Description Patch Car
+// @Description Patch Car fields allowed for the caller role, the body is either UpdatePatch or a JSON Merge Patch object
 // @Tags Car
-// @Accept json
+// @Accept json,application/merge-patch+json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param profile body models.UpdatePatch true "UpdatePatchRequestBody"
 // @Success 200 {object} http.Response{data=order_service.Car} "Car data"
-// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 400 {object} http.Response{data=models.PatchRejected} "Rejected fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpdatePatchCar(c *gin.Context) {
-
-	var updatePatchCar models.UpdatePatch
-
-	err := c.ShouldBindJSON(&updatePatchCar)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.ensureStaff(c) {
 		return
 	}
 
+	var updatePatchCar models.UpdatePatch
+
 	updatePatchCar.ID = c.Param("id")
 
 	if !util.IsValidUUID(updatePatchCar.ID) {
@@ -195,25 +291,39 @@ func (h *Handler) UpdatePatchCar(c *gin.Context) {
 		return
 	}
 
+	data, ok := h.bindPatch(c, carPatchPolicy)
+	if !ok {
+		return
+	}
+	updatePatchCar.Data = data
+
 	structData, err := helper.ConvertMapToStruct(updatePatchCar.Data)
 	if err != nil {
 		h.handleResponse(c, http.InvalidArgument, err.Error())
 		return
 	}
 
+	expected, ok := h.checkIfMatchLoading(c, h.currentCar(c, updatePatchCar.ID))
+	if !ok {
+		return
+	}
+
 	resp, err := h.services.CarService().UpdatePatch(
 		c.Request.Context(),
 		&order_service.UpdatePathCar{
-			Id:     updatePatchCar.ID,
-			Fields: structData,
+			Id:                updatePatchCar.ID,
+			Fields:            structData,
+			ExpectedUpdatedAt: expected,
 		},
 	)
 
 	if err != nil {
-		h.handleResponse(c, http.GRPCError, err.Error())
+		h.handleWriteError(c, err)
 		return
 	}
 
+	h.setETag(c, resp)
+	h.publish(webhook.CarUpdated, resp)
 	h.handleResponse(c, http.OK, resp)
 }
 
@@ -226,10 +336,17 @@ func (h *Handler) UpdatePatchCar(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} http.Response{data=object{}} "Car data"
 // @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) DeleteCar(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
 
 	carId := c.Param("id")
 
@@ -238,6 +355,11 @@ func (h *Handler) DeleteCar(c *gin.Context) {
 		return
 	}
 
+	// deletes are only checked by the gateway, the primary key messages carry no version
+	if _, ok := h.checkIfMatchLoading(c, h.currentCar(c, carId)); !ok {
+		return
+	}
+
 	resp, err := h.services.CarService().Delete(
 		c.Request.Context(),
 		&order_service.CarPrimaryKey{Id: carId},
@@ -248,5 +370,6 @@ func (h *Handler) DeleteCar(c *gin.Context) {
 		return
 	}
 
+	h.publish(webhook.CarDeleted, deletedResource{ID: carId})
 	h.handleResponse(c, http.NoContent, resp)
 }
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/media"
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+	"bytes"
+	"io"
+	htp "net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordingWriter keeps a copy of the response body
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// ContractMiddleware logs the requests and responses of the documented routes that do not match the
+// OpenAPI document, the traffic itself is not changed
+func (h *Handler) ContractMiddleware(router *gin.Engine) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *openapi.Document
+	)
+
+	return func(c *gin.Context) {
+		// the routes are read on the first request, when all of them are registered
+		once.Do(func() {
+			doc = OpenAPIDocument(router.Routes())
+		})
+
+		operation := doc.Operation(c.Request.Method, c.FullPath())
+		// the document describes the JSON bodies only
+		if operation == nil || !exchangesJSON(c) {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		violations := doc.CheckRequest(operation, c.Request.URL.Query(), c.GetHeader, body)
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		if status := writer.Status(); status != htp.StatusNotModified {
+			violations = append(violations, doc.CheckResponse(operation, status, writer.body.Bytes())...)
+		}
+
+		if len(violations) > 0 {
+			h.log.Warn(
+				"contract violation",
+				logger.String("method", c.Request.Method),
+				logger.String("route", c.FullPath()),
+				logger.Int("code", writer.Status()),
+				logger.Any("violations", violations),
+			)
+		}
+	}
+}
+
+// exchangesJSON reports whether the request body and the answer are JSON
+func exchangesJSON(c *gin.Context) bool {
+	mediaType, err := media.Type(c.GetHeader("Content-Type"))
+	return err == nil && mediaType == media.JSON && media.Negotiate(c.GetHeader("Accept")) == media.JSON
+}
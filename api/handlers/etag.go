@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/etag"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// orderDerivedFields are the fields decorateOrders fills, an order changes with them without its
+// updated_at changing, like when it becomes overdue or is paid
+var orderDerivedFields = []protoreflect.Name{"due_date", "is_overdue", "paid_price", "balance", "deposit_status"}
+
+// etagOf is the entity tag of the resource as the gateway answers it. The tag names a version of the
+// resource and the JSON, protobuf and MessagePack answers of the version share it, it is strong so
+// that If-Match can compare it
+func etagOf(resource proto.Message) string {
+	var derived []protoreflect.Name
+	if _, ok := resource.(*order_service.Order); ok {
+		derived = orderDerivedFields
+	}
+	return etag.Of(resource, derived...)
+}
+
+// setETag adds the entity tag of the resource to the response
+func (h *Handler) setETag(c *gin.Context, resource proto.Message) {
+	c.Header("ETag", etagOf(resource))
+}
+
+// notModified sets the entity tag and answers 304 when the client already has this version of the resource
+func (h *Handler) notModified(c *gin.Context, resource proto.Message) bool {
+	tag := etagOf(resource)
+	c.Header("ETag", tag)
+
+	header := c.GetHeader("If-None-Match")
+	if header == "" || !etag.Match(header, tag) {
+		return false
+	}
+
+	c.Status(http.NotModified.Code)
+	return true
+}
+
+// checkIfMatch rejects the write when the resource changed since the client read it. It returns the
+// updated_at of the checked version, the write forwards it as expected_updated_at and the backend
+// refuses the write when the resource changed after the check. Requests without If-Match are let
+// through unless the gateway is configured to require it
+func (h *Handler) checkIfMatch(c *gin.Context, current proto.Message) (string, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		if h.cfg.RequireIfMatch {
+			h.handleResponse(c, http.PreconditionRequired, "If-Match header with the resource ETag is required")
+			return "", false
+		}
+		return "", true
+	}
+
+	tag := etagOf(current)
+	if !etag.MatchStrong(header, tag) {
+		c.Header("ETag", tag)
+		h.handleResponse(c, http.PreconditionFailed, "resource was modified by another request, reload it and retry")
+		return "", false
+	}
+
+	return updatedAt(current), true
+}
+
+// checkIfMatchLoading is checkIfMatch for the writes that need the current resource for the check
+// only, it is loaded when the request has If-Match
+func (h *Handler) checkIfMatchLoading(c *gin.Context, load func() (proto.Message, error)) (string, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return h.checkIfMatch(c, nil)
+	}
+
+	current, ok := h.loadCurrent(c, load)
+	if !ok {
+		return "", false
+	}
+	return h.checkIfMatch(c, current)
+}
+
+// loadCurrent loads the resource a write changes, it answers the error of the backend
+func (h *Handler) loadCurrent(c *gin.Context, load func() (proto.Message, error)) (proto.Message, bool) {
+	current, err := load()
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return nil, false
+	}
+	return current, true
+}
+
+// handleWriteError answers the error of a conditional write, the backend aborts it when the resource
+// changed after checkIfMatch
+func (h *Handler) handleWriteError(c *gin.Context, err error) {
+	if status.Code(err) == codes.Aborted {
+		h.handleResponse(c, http.PreconditionFailed, "resource was modified by another request, reload it and retry")
+		return
+	}
+	h.handleResponse(c, http.GRPCError, err.Error())
+}
+
+// updatedAt is the version of the resource the conditional writes are checked against
+func updatedAt(resource proto.Message) string {
+	fields := resource.ProtoReflect()
+	if fd := fields.Descriptor().Fields().ByName("updated_at"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		return fields.Get(fd).String()
+	}
+	return ""
+}
+
+// currentOrder loads the order as GET /order/:id answers it, its tag covers the derived fields
+func (h *Handler) currentOrder(c *gin.Context, id string) func() (proto.Message, error) {
+	return func() (proto.Message, error) {
+		order, err := h.services.OrderService().GetByID(c.Request.Context(), &order_service.OrderPrimaryKey{Id: id})
+		if err != nil {
+			return nil, err
+		}
+		return order, h.decorateOrders(c.Request.Context(), time.Now(), order)
+	}
+}
+
+func (h *Handler) currentClient(c *gin.Context, id string) func() (proto.Message, error) {
+	return func() (proto.Message, error) {
+		return h.services.UserService().GetByID(c.Request.Context(), &client_service.CLientPrimaryKey{Id: id})
+	}
+}
+
+func (h *Handler) currentCar(c *gin.Context, id string) func() (proto.Message, error) {
+	return func() (proto.Message, error) {
+		return h.services.CarService().GetByID(c.Request.Context(), &order_service.CarPrimaryKey{Id: id})
+	}
+}
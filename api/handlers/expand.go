@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/expand"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// expandConcurrency is the number of backend calls an expansion runs at the same time
+const expandConcurrency = 8
+
+var (
+	orderExpandRelations = []string{"client", "car", "tarif", "mechanic", "discount"}
+	carExpandRelations   = []string{"model", "tarif"}
+)
+
+// getExpand reads the expand parameter, an empty result means the plain resource is returned
+func (h *Handler) getExpand(c *gin.Context, allowed []string) ([]string, bool) {
+	relations, err := expand.Parse(c.Query("expand"), allowed)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return nil, false
+	}
+	return relations, true
+}
+
+// expandOrders embeds the related resources of the orders, ids shared by several orders are loaded once
+func (h *Handler) expandOrders(ctx context.Context, orders []*order_service.Order, relations []string) ([]*models.ExpandedOrder, []expand.Error) {
+	ids := map[string][]string{}
+	for _, relation := range relations {
+		for _, order := range orders {
+			ids[relation] = append(ids[relation], orderRelationID(order, relation))
+		}
+	}
+
+	results, errs := expand.Fetch(ctx, h.expandLoaders(), ids, expandConcurrency)
+
+	expanded := make([]*models.ExpandedOrder, 0, len(orders))
+	for _, order := range orders {
+		relations := &models.OrderRelations{}
+		relations.Client, _ = results.Get("client", order.ClientId).(*client_service.Client)
+		relations.Car, _ = results.Get("car", order.CarId).(*order_service.Car)
+		relations.Tarif, _ = results.Get("tarif", order.TarifId).(*order_service.Tarif)
+		relations.Mechanic, _ = results.Get("mechanic", order.MechanicId).(*order_service.Mechanic)
+		relations.Discount, _ = results.Get("discount", orderRelationID(order, "discount")).(*order_service.Discount)
+
+		expanded = append(expanded, &models.ExpandedOrder{Order: order, Expanded: relations})
+	}
+
+	return expanded, errs
+}
+
+// expandCars embeds the models and tariffs of the cars
+func (h *Handler) expandCars(ctx context.Context, cars []*order_service.Car, relations []string) ([]*models.ExpandedCar, []expand.Error) {
+	ids := map[string][]string{}
+	for _, relation := range relations {
+		for _, car := range cars {
+			switch relation {
+			case "model":
+				ids[relation] = append(ids[relation], car.ModelId)
+			case "tarif":
+				ids[relation] = append(ids[relation], car.TarifId)
+			}
+		}
+	}
+
+	results, errs := expand.Fetch(ctx, h.expandLoaders(), ids, expandConcurrency)
+
+	expanded := make([]*models.ExpandedCar, 0, len(cars))
+	for _, car := range cars {
+		relations := &models.CarRelations{}
+		relations.Model, _ = results.Get("model", car.ModelId).(*order_service.Model)
+		relations.Tarif, _ = results.Get("tarif", car.TarifId).(*order_service.Tarif)
+
+		expanded = append(expanded, &models.ExpandedCar{Car: car, Expanded: relations})
+	}
+
+	return expanded, errs
+}
+
+func (h *Handler) expandLoaders() map[string]expand.Loader {
+	return map[string]expand.Loader{
+		"client": func(ctx context.Context, id string) (interface{}, error) {
+			return h.services.UserService().GetByID(ctx, &client_service.CLientPrimaryKey{Id: id})
+		},
+		"car": func(ctx context.Context, id string) (interface{}, error) {
+			return h.services.CarService().GetByID(ctx, &order_service.CarPrimaryKey{Id: id})
+		},
+		"tarif": func(ctx context.Context, id string) (interface{}, error) {
+			return h.services.TarifService().GetByID(ctx, &order_service.TarifPK{Id: id})
+		},
+		"mechanic": func(ctx context.Context, id string) (interface{}, error) {
+			return h.services.MechanicService().GetByID(ctx, &order_service.MechanicPK{Id: id})
+		},
+		"discount": func(ctx context.Context, id string) (interface{}, error) {
+			return h.services.DiscountService().GetByID(ctx, &order_service.DiscountPK{Id: id})
+		},
+		"model": func(ctx context.Context, id string) (interface{}, error) {
+			return h.services.ModelService().GetByID(ctx, &order_service.ModelPK{Id: id})
+		},
+	}
+}
+
+// orderRelationID returns the id an order refers to, the discount is only expanded when it holds a discount id
+func orderRelationID(order *order_service.Order, relation string) string {
+	switch relation {
+	case "client":
+		return order.ClientId
+	case "car":
+		return order.CarId
+	case "tarif":
+		return order.TarifId
+	case "mechanic":
+		return order.MechanicId
+	case "discount":
+		if util.IsValidUUID(order.Discount) {
+			return order.Discount
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/fieldmask"
+	"bytes"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const fieldsKey = "fields_projection"
+
+// projection is the sparse fieldset of a response, items names the list the fields apply to
+type projection struct {
+	mask  *fieldmask.Mask
+	items string
+}
+
+// keptKeys are always returned next to the projected fields
+var keptKeys = []string{"expand_errors"}
+
+// Descriptors of the resources the fields parameter selects from
+var (
+	orderDescriptor        = (&order_service.Order{}).ProtoReflect().Descriptor()
+	orderHistoryDescriptor = (&order_service.OrderStatusHistory{}).ProtoReflect().Descriptor()
+	inspectionDescriptor   = (&order_service.VehicleInspection{}).ProtoReflect().Descriptor()
+	chargeDescriptor       = (&order_service.OrderCharge{}).ProtoReflect().Descriptor()
+	paymentDescriptor      = (&order_service.Payment{}).ProtoReflect().Descriptor()
+	depositDescriptor      = (&order_service.DepositTransaction{}).ProtoReflect().Descriptor()
+	clientDescriptor       = (&client_service.Client{}).ProtoReflect().Descriptor()
+	blockHistoryDescriptor = (&client_service.ClientBlockHistory{}).ProtoReflect().Descriptor()
+	carDescriptor          = (&order_service.Car{}).ProtoReflect().Descriptor()
+)
+
+var (
+	orderExtensions = map[string]protoreflect.MessageDescriptor{
+		"expanded.client":   (&client_service.Client{}).ProtoReflect().Descriptor(),
+		"expanded.car":      (&order_service.Car{}).ProtoReflect().Descriptor(),
+		"expanded.tarif":    (&order_service.Tarif{}).ProtoReflect().Descriptor(),
+		"expanded.mechanic": (&order_service.Mechanic{}).ProtoReflect().Descriptor(),
+		"expanded.discount": (&order_service.Discount{}).ProtoReflect().Descriptor(),
+	}
+	carExtensions = map[string]protoreflect.MessageDescriptor{
+		"expanded.model": (&order_service.Model{}).ProtoReflect().Descriptor(),
+		"expanded.tarif": (&order_service.Tarif{}).ProtoReflect().Descriptor(),
+	}
+)
+
+// Fields the gateway reads from the backend responses whatever the caller asks for
+var (
+	orderGatewayFields = []string{
+		"id", "updated_at", "status", "start_date", "day_count", "total_price", "paid_price",
+		"deposit_held", "deposit_captured", "deposit_released",
+		"client_id", "car_id", "tarif_id", "mechanic_id", "discount",
+	}
+	clientGatewayFields = []string{"id", "updated_at"}
+	carGatewayFields    = []string{"id", "updated_at", "model_id", "tarif_id"}
+)
+
+// getFields validates the fields parameter against the resource returned by the endpoint, the
+// response is projected to those fields by handleResponse, items names the list of a list response
+func (h *Handler) getFields(c *gin.Context, message protoreflect.MessageDescriptor, items string, extensions map[string]protoreflect.MessageDescriptor) (*fieldmask.Mask, bool) {
+	value, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, true
+	}
+
+	mask, err := fieldmask.Parse(value, message, extensions)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return nil, false
+	}
+
+	c.Set(fieldsKey, projection{mask: mask, items: items})
+	return mask, true
+}
+
+// backendMask is the field mask forwarded to the backend, nil asks for the whole resource
+func backendMask(mask *fieldmask.Mask, required []string) *fieldmaskpb.FieldMask {
+	if mask == nil {
+		return nil
+	}
+	return mask.FieldMask(required...)
+}
+
+// project applies the sparse fieldset of the request to a successful response
+func (h *Handler) project(c *gin.Context, data interface{}) interface{} {
+	value, ok := c.Get(fieldsKey)
+	if !ok {
+		return data
+	}
+	p := value.(projection)
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&decoded) != nil {
+		return data
+	}
+
+	object, ok := decoded.(map[string]interface{})
+	if !ok {
+		return data
+	}
+
+	if p.items != "" {
+		if items, ok := object[p.items]; ok {
+			object[p.items] = p.mask.Project(items)
+		}
+		return object
+	}
+
+	projected := p.mask.Project(object).(map[string]interface{})
+	for _, key := range keptKeys {
+		if kept, ok := object[key]; ok {
+			projected[key] = kept
+		}
+	}
+	return projected
+}
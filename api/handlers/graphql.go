@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/expand"
+	"Projects/Car24/car24_api_gateway/pkg/gql"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/spf13/cast"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// batchKey holds the per request batch the resolvers load related resources with
+type batchKey struct{}
+
+// viewerKey holds the graphQLViewer of the request
+type viewerKey struct{}
+
+// graphQLViewer is the caller of the query, clients only read their own profile and orders
+type graphQLViewer struct {
+	staff    bool
+	clientId string
+}
+
+// GraphQL godoc
+// @ID graphql
+// @Router /graphql [POST]
+// @Summary GraphQL
+// @Description Query orders, clients, cars, models, tariffs, mechanics and discounts with their relations in one round trip.
+// @Description The types mirror the proto messages and keep their field names, an order has client, car, tarif, mechanic and discount fields and a car has model and tarif.
+// @Description Clients read their own profile and orders only, listing the clients is for operators and admins.
+// @Description Queries deeper than GRAPHQL_MAX_DEPTH or resolving more than GRAPHQL_MAX_COMPLEXITY fields, counting list items by their limit, are rejected.
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param query body models.GraphQLRequest true "GraphQLRequestBody"
+// @Success 200 {object} http.Response{data=object} "data and the errors of the fields that failed"
+// @Response 400 {object} http.Response{data=object} "Invalid query or query over the limits"
+func (h *Handler) GraphQL() gin.HandlerFunc {
+	schema, err := h.graphQLSchema()
+	if err != nil {
+		panic(fmt.Sprintf("graphql schema: %v", err))
+	}
+
+	limits := gql.Limits{
+		MaxDepth:      h.cfg.GraphQLMaxDepth,
+		MaxComplexity: h.cfg.GraphQLMaxComplexity,
+		ListSize:      cast.ToInt(h.cfg.DefaultLimit),
+	}
+
+	return func(c *gin.Context) {
+		var body models.GraphQLRequest
+
+		err := c.ShouldBindJSON(&body)
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, err.Error())
+			return
+		}
+
+		document, err := parser.Parse(parser.ParseParams{Source: body.Query})
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+
+		validation := graphql.ValidateDocument(&schema, document, nil)
+		if !validation.IsValid {
+			h.handleResponse(c, http.BadRequest, &graphql.Result{Errors: validation.Errors})
+			return
+		}
+
+		err = limits.Check(schema, document, body.OperationName, body.Variables)
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+
+		batch := expand.NewBatch(c.Request.Context(), h.graphQLLoaders(), expandConcurrency)
+
+		ctx := context.WithValue(c.Request.Context(), batchKey{}, batch)
+		ctx = context.WithValue(ctx, viewerKey{}, graphQLViewer{staff: h.isStaff(c), clientId: h.getAuthUserID(c)})
+
+		result := graphql.Execute(graphql.ExecuteParams{
+			Schema:        schema,
+			AST:           document,
+			OperationName: body.OperationName,
+			Args:          body.Variables,
+			Context:       ctx,
+		})
+
+		h.handleResponse(c, http.OK, result)
+	}
+}
+
+// graphQLSchema builds the object types from the proto messages and links the related resources,
+// the related resources of all items are loaded together by the request batch
+func (h *Handler) graphQLSchema() (graphql.Schema, error) {
+	var (
+		b = gql.NewBuilder()
+
+		order    = orderDescriptor
+		client   = clientDescriptor
+		car      = carDescriptor
+		model    = (&order_service.Model{}).ProtoReflect().Descriptor()
+		tarif    = (&order_service.Tarif{}).ProtoReflect().Descriptor()
+		mechanic = (&order_service.Mechanic{}).ProtoReflect().Descriptor()
+		discount = (&order_service.Discount{}).ProtoReflect().Descriptor()
+	)
+
+	orderRelation := func(relation string, target protoreflect.MessageDescriptor) {
+		b.Relate(order, relation, &graphql.Field{
+			Type: b.Object(target),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				source, _ := p.Source.(*order_service.Order)
+				return loadRelated(p, relation, orderRelationID(source, relation))
+			},
+		})
+	}
+	orderRelation("client", client)
+	orderRelation("car", car)
+	orderRelation("tarif", tarif)
+	orderRelation("mechanic", mechanic)
+	orderRelation("discount", discount)
+
+	b.Relate(car, "model", &graphql.Field{
+		Type: b.Object(model),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			source, _ := p.Source.(*order_service.Car)
+			return loadRelated(p, "model", source.GetModelId())
+		},
+	})
+	b.Relate(car, "tarif", &graphql.Field{
+		Type: b.Object(tarif),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			source, _ := p.Source.(*order_service.Car)
+			return loadRelated(p, "tarif", source.GetTarifId())
+		},
+	})
+
+	byID := func(relation string, target protoreflect.MessageDescriptor) *graphql.Field {
+		return &graphql.Field{
+			Type: b.Object(target),
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				id, _ := p.Args["id"].(string)
+				if !util.IsValidUUID(id) {
+					return nil, fmt.Errorf("%s id is an invalid uuid", relation)
+				}
+
+				viewer := viewerOf(p)
+				if relation == "client" {
+					if err := viewer.owns(id); err != nil {
+						return nil, err
+					}
+				}
+
+				resolved, err := loadRelated(p, relation, id)
+				if err != nil || relation != "order" || viewer.staff {
+					return resolved, err
+				}
+
+				// the owner of an order is only known once it is loaded
+				load, _ := resolved.(func() (interface{}, error))
+				return func() (interface{}, error) {
+					loaded, err := load()
+					if err != nil {
+						return nil, err
+					}
+					if order, ok := loaded.(*order_service.Order); ok {
+						if err := viewer.owns(order.ClientId); err != nil {
+							return nil, err
+						}
+					}
+					return loaded, nil
+				}, nil
+			},
+		}
+	}
+
+	listArgs := graphql.FieldConfigArgument{
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int},
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
+		"search": &graphql.ArgumentConfig{Type: graphql.String},
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"order": byID("order", order),
+			"orders": &graphql.Field{
+				Type: b.Object((&order_service.GetListOrderResponse{}).ProtoReflect().Descriptor()),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					offset, limit, err := h.graphQLPage(p)
+					if err != nil {
+						return nil, err
+					}
+
+					request := &order_service.GetListOrderRequest{
+						Offset: offset,
+						Limit:  limit,
+						Search: cast.ToString(p.Args["search"]),
+					}
+					if viewer := viewerOf(p); !viewer.staff {
+						request.Filters = []*order_service.Filter{{Field: "client_id", Op: query.OpEq, Values: []string{viewer.clientId}}}
+					}
+
+					resp, err := h.services.OrderService().GetList(p.Context, request)
+					if err != nil {
+						return nil, err
+					}
+
+					err = h.decorateOrders(p.Context, time.Now(), resp.Orders...)
+					if err != nil {
+						return nil, err
+					}
+					return resp, nil
+				},
+			},
+			"client": byID("client", client),
+			"clients": &graphql.Field{
+				Type: b.Object((&client_service.GetListClientResponse{}).ProtoReflect().Descriptor()),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if !viewerOf(p).staff {
+						return nil, errors.New("only operators and admins list clients")
+					}
+
+					offset, limit, err := h.graphQLPage(p)
+					if err != nil {
+						return nil, err
+					}
+
+					return h.services.UserService().GetList(p.Context, &client_service.GetListClientRequest{
+						Offset: offset,
+						Limit:  limit,
+						Search: cast.ToString(p.Args["search"]),
+					})
+				},
+			},
+			"car": byID("car", car),
+			"cars": &graphql.Field{
+				Type: b.Object((&order_service.GetListCarResponse{}).ProtoReflect().Descriptor()),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					offset, limit, err := h.graphQLPage(p)
+					if err != nil {
+						return nil, err
+					}
+
+					return h.services.CarService().GetList(p.Context, &order_service.GetListCarRequest{
+						Offset: offset,
+						Limit:  limit,
+						Search: cast.ToString(p.Args["search"]),
+					})
+				},
+			},
+			"model":    byID("model", model),
+			"tarif":    byID("tarif", tarif),
+			"mechanic": byID("mechanic", mechanic),
+			"discount": byID("discount", discount),
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// graphQLLoaders are the expand loaders and a loader of orders that fills the fields the gateway derives
+func (h *Handler) graphQLLoaders() map[string]expand.Loader {
+	loaders := h.expandLoaders()
+	loaders["order"] = func(ctx context.Context, id string) (interface{}, error) {
+		order, err := h.services.OrderService().GetByID(ctx, &order_service.OrderPrimaryKey{Id: id})
+		if err != nil {
+			return nil, err
+		}
+		err = h.decorateOrders(ctx, time.Now(), order)
+		if err != nil {
+			return nil, err
+		}
+		return order, nil
+	}
+	return loaders
+}
+
+// graphQLPage reads the offset and limit arguments with the same bounds as the REST lists
+func (h *Handler) graphQLPage(p graphql.ResolveParams) (offset, limit int64, err error) {
+	offset = int64(cast.ToInt(h.cfg.DefaultOffset))
+	limit = int64(cast.ToInt(h.cfg.DefaultLimit))
+
+	if value, ok := p.Args["offset"].(int); ok {
+		offset = int64(value)
+	}
+	if value, ok := p.Args["limit"].(int); ok {
+		limit = int64(value)
+	}
+
+	if offset < 0 {
+		return 0, 0, fmt.Errorf("offset must not be negative")
+	}
+	if limit < 1 || limit > int64(h.cfg.MaxPageSize) {
+		return 0, 0, fmt.Errorf("limit must be between 1 and %d", h.cfg.MaxPageSize)
+	}
+
+	return offset, limit, nil
+}
+
+// viewerOf returns the caller of the query, a query run without one reads as a client with no data
+func viewerOf(p graphql.ResolveParams) graphQLViewer {
+	viewer, _ := p.Context.Value(viewerKey{}).(graphQLViewer)
+	return viewer
+}
+
+// owns returns an error when a client reads the data of another client
+func (v graphQLViewer) owns(clientId string) error {
+	if !v.staff && v.clientId != clientId {
+		return errors.New("clients can only access their own data")
+	}
+	return nil
+}
+
+// loadRelated queues the id in the request batch, an empty id resolves to null
+func loadRelated(p graphql.ResolveParams, relation, id string) (interface{}, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	batch, ok := p.Context.Value(batchKey{}).(*expand.Batch)
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be loaded outside a request", relation)
+	}
+
+	return batch.Load(relation, id), nil
+}
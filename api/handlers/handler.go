@@ -4,12 +4,17 @@ import (
 	"Projects/Car24/car24_api_gateway/api/http"
 	"Projects/Car24/car24_api_gateway/config"
 	"Projects/Car24/car24_api_gateway/grpc/client"
+	"Projects/Car24/car24_api_gateway/pkg/cache"
 	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/stream"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
 	"bufio"
 	"encoding/json"
+	"fmt"
 	htp "net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gogo/protobuf/jsonpb"
@@ -20,16 +25,43 @@ type Handler struct {
 	cfg      config.Config
 	log      logger.LoggerI
 	services client.ServiceManagerI
+	webhooks *webhook.Dispatcher
+	events   *stream.Hub
+	// overdueAlerts are the orders whose overdue alert was sent
+	overdueAlerts *cache.Cache[bool]
 }
 
 func NewHandler(cfg config.Config, log logger.LoggerI, svcs client.ServiceManagerI) Handler {
+	// the tools that only build the routes have no backend
+	var webhooks webhook.Store = webhook.NewMemoryStore()
+	if svcs != nil {
+		webhooks = client.NewWebhookStore(svcs)
+	}
+
 	return Handler{
 		cfg:      cfg,
 		log:      log,
 		services: svcs,
+		webhooks: webhook.NewDispatcher(webhook.Options{
+			MaxAttempts:    cfg.WebhookMaxAttempts,
+			Backoff:        cfg.WebhookBackoff,
+			MaxBackoff:     cfg.WebhookMaxBackoff,
+			Timeout:        cfg.WebhookTimeout,
+			MaxDeadLetters: cfg.WebhookMaxDeadLetters,
+			Concurrency:    cfg.WebhookConcurrency,
+			QueueSize:      cfg.WebhookQueueSize,
+			Refresh:        cfg.WebhookRefresh,
+		}, webhooks, log),
+		events:        stream.NewHub(cfg.StreamBufferSize),
+		overdueAlerts: cache.New[bool](overdueAlertTTL, overdueAlertSize, time.Second),
 	}
 }
 
+// Close stops the background work of the handler, the server calls it on shutdown
+func (h *Handler) Close() {
+	h.webhooks.Close()
+}
+
 func (h *Handler) handleResponse(c *gin.Context, status http.Status, data interface{}) {
 	switch code := status.Code; {
 	case code < 300:
@@ -58,7 +90,11 @@ func (h *Handler) handleResponse(c *gin.Context, status http.Status, data interf
 		)
 	}
 
-	c.JSON(status.Code, data)
+	if status.Code < 300 {
+		data = h.project(c, data)
+	}
+
+	h.render(c, status.Code, data)
 }
 
 func ProtoToStruct(s interface{}, p protoiface.MessageV1) error {
@@ -78,27 +114,40 @@ func ProtoToStruct(s interface{}, p protoiface.MessageV1) error {
 }
 
 func (h *Handler) getOffsetParam(c *gin.Context) (offset int, err error) {
+	offsetStr := c.DefaultQuery("offset", "0")
 	if h.cfg.DefaultOffset != "" {
-		offsetStr := c.DefaultQuery("offset", h.cfg.DefaultOffset)
-		return strconv.Atoi(offsetStr)
+		offsetStr = c.DefaultQuery("offset", h.cfg.DefaultOffset)
 	}
 
-	offsetStr := c.DefaultQuery("offset", "0")
-	return strconv.Atoi(offsetStr)
+	offset, err = strconv.Atoi(offsetStr)
+	if err != nil {
+		return 0, fmt.Errorf("offset must be an integer")
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("offset must not be negative")
+	}
+	return offset, nil
 }
 
-func (h *Handler) getLimitParam(c *gin.Context) (offset int, err error) {
+func (h *Handler) getLimitParam(c *gin.Context) (limit int, err error) {
+	limitStr := c.DefaultQuery("limit", "10")
 	if h.cfg.DefaultLimit != "" {
-		limitStr := c.DefaultQuery("limit", h.cfg.DefaultLimit)
-		return strconv.Atoi(limitStr)
+		limitStr = c.DefaultQuery("limit", h.cfg.DefaultLimit)
 	}
-	limitStr := c.DefaultQuery("limit", "10")
-	return strconv.Atoi(limitStr)
+
+	limit, err = strconv.Atoi(limitStr)
+	if err != nil {
+		return 0, fmt.Errorf("limit must be an integer")
+	}
+	if limit < 1 || limit > h.cfg.MaxPageSize {
+		return 0, fmt.Errorf("limit must be between 1 and %d", h.cfg.MaxPageSize)
+	}
+	return limit, nil
 }
 
 func (h *Handler) handleErrorResponse(c *gin.Context, code int, message string, err interface{}) {
 	h.log.Error(message, logger.Int("code", code), logger.Any("error", err))
-	c.JSON(code, ResponseModel{
+	h.render(c, code, ResponseModel{
 		Code:    code,
 		Message: message,
 		Error:   err,
@@ -106,7 +155,7 @@ func (h *Handler) handleErrorResponse(c *gin.Context, code int, message string,
 }
 
 func (h *Handler) handleSuccessResponse(c *gin.Context, code int, message string, data interface{}) {
-	c.JSON(code, ResponseModel{
+	h.render(c, code, ResponseModel{
 		Code:    code,
 		Message: message,
 		Data:    data,
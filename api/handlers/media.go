@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/pkg/compress"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/media"
+	"errors"
+	"io"
+	htp "net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// render writes the data in the media type the Accept header prefers. Protobuf only has the protobuf
+// messages and the error messages, the gateway models are answered as JSON
+func (h *Handler) render(c *gin.Context, code int, data interface{}) {
+	c.Writer.Header().Add("Vary", "Accept")
+
+	mediaType := media.Negotiate(c.GetHeader("Accept"))
+	if mediaType != media.JSON {
+		body, err := media.Marshal(mediaType, data)
+		if err == nil {
+			c.Data(code, mediaType, body)
+			return
+		}
+		if !errors.Is(err, media.ErrUnsupported) {
+			h.log.Error("encode response", logger.String("media_type", mediaType), logger.Error(err))
+		}
+	}
+
+	c.JSON(code, data)
+}
+
+// bind decodes the body by its Content-Type into obj and validates it like ShouldBindJSON, it answers
+// 415 for the formats the endpoint does not read and 400 for an invalid body
+func (h *Handler) bind(c *gin.Context, obj interface{}) bool {
+	mediaType, err := media.Type(c.GetHeader("Content-Type"))
+	if err != nil {
+		h.handleResponse(c, http.UnsupportedMediaType, "the body must be application/json, application/x-protobuf or application/msgpack")
+		return false
+	}
+
+	if mediaType == media.JSON {
+		err = c.ShouldBindJSON(obj)
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, err.Error())
+			return false
+		}
+		return true
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return false
+	}
+
+	err = media.Unmarshal(mediaType, body, obj)
+	if errors.Is(err, media.ErrUnsupported) {
+		h.handleResponse(c, http.UnsupportedMediaType, "the body of this endpoint is not a protobuf message, send it as application/json or application/msgpack")
+		return false
+	}
+	if err == nil {
+		err = binding.Validator.ValidateStruct(obj)
+	}
+	if err != nil {
+		h.handleResponse(c, http.BadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+// CompressionMiddleware compresses the responses of at least CompressionMinSize bytes with brotli or
+// gzip, whichever Accept-Encoding prefers
+func (h *Handler) CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		coding := compress.Negotiate(c.GetHeader("Accept-Encoding"))
+		// the WebSocket takes over the connection
+		if coding == "" || c.Request.Method == htp.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := compress.NewWriter(original, coding, h.cfg.CompressionMinSize)
+		c.Writer = writer
+
+		c.Next()
+
+		err := writer.Close()
+		if err != nil {
+			h.log.Error("compress response", logger.String("coding", coding), logger.Error(err))
+		}
+		// the router writes the answer of unknown routes after the handlers
+		c.Writer = original
+	}
+}
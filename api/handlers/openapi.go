@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/docs"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+	"Projects/Car24/car24_api_gateway/pkg/transcode"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"fmt"
+	htp "net/http"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPIInfo describes the gateway in the generated document
+var openAPIInfo = openapi.Info{
+	Title:       "Car24 API Gateway",
+	Version:     "1.0",
+	Description: "Orders, clients and cars of Car24. Errors answer with the message, or an object like the rejected parameters.",
+}
+
+// redocPage shows the document at /openapi.json
+const redocPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Car24 API Gateway</title>
+	<meta charset="utf-8"/>
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+</head>
+<body>
+	<redoc spec-url="openapi.json"></redoc>
+	<script src="https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"></script>
+</body>
+</html>
+`
+
+// OpenAPI serves the OpenAPI 3.1 document of the routes of the router
+func (h *Handler) OpenAPI(router *gin.Engine) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *openapi.Document
+	)
+
+	return func(c *gin.Context) {
+		// the routes are read on the first request, when all of them are registered
+		once.Do(func() {
+			doc = OpenAPIDocument(router.Routes())
+		})
+
+		c.JSON(htp.StatusOK, doc)
+	}
+}
+
+// OpenAPIPage serves the Redoc page of the document
+func (h *Handler) OpenAPIPage(c *gin.Context) {
+	c.Data(htp.StatusOK, "text/html; charset=utf-8", []byte(redocPage))
+}
+
+// OpenAPIDocument documents the routes with the endpoints of the gateway
+func OpenAPIDocument(routes gin.RoutesInfo) *openapi.Document {
+	return openapi.Build(openAPIInfo, routes, Endpoints())
+}
+
+// Endpoints documents every route of the gateway, keyed by openapi.Key with the path the route is
+// registered with. The entries here only have what swag cannot say in OpenAPI 3.1, the rest comes from
+// the handler annotations. A route without an entry or annotation is reported by cmd/openapi
+func Endpoints() map[string]openapi.Endpoint {
+	var (
+		ifNoneMatch = openapi.HeaderParam("If-None-Match", "ETag of the version the client has, answered with 304 when it is current")
+		ifMatch     = openapi.HeaderParam("If-Match", "ETag of the version being changed")
+		etag        = map[string]string{"ETag": "entity tag of the returned version"}
+		statusBody  = openapi.Endpoint{Body: models.ChangeOrderStatus{}, BodyOptional: true, Result: &order_service.Order{}}
+	)
+
+	endpoints := map[string]openapi.Endpoint{
+		// user
+		"POST /user": {
+			Body: &client_service.CreateClient{}, Status: htp.StatusCreated, Result: &client_service.Client{},
+		},
+		"GET /user/block-suggestions": {
+			Params: offsetParams(),
+			Result: models.BlockSuggestions{},
+		},
+		"GET /user/:id": {
+			Params:  []openapi.Parameter{fieldsParam("id,first_name,phone_number"), ifNoneMatch},
+			Result:  &client_service.Client{},
+			Headers: etag,
+		},
+		"GET /user": {
+			Params: append(append(pageParams(), openapi.Filters(clientQuerySchema)...),
+				fieldsParam("id,first_name,phone_number")),
+			Result: &client_service.GetListClientResponse{},
+		},
+		"PUT /user/:id": {
+			Params: []openapi.Parameter{ifMatch},
+			Body:   &client_service.UpdateClient{}, Result: &client_service.Client{}, Headers: etag,
+		},
+		"PATCH /user/:id": {
+			Params: []openapi.Parameter{ifMatch},
+			Body:   models.UpdatePatch{}, Result: &client_service.Client{}, Headers: etag,
+		},
+		"DELETE /user/:id": {
+			Params: []openapi.Parameter{ifMatch}, Status: htp.StatusNoContent,
+		},
+		"POST /user/:id/block": {
+			Body: models.BlockClient{}, Result: &client_service.Client{},
+		},
+		"POST /user/:id/unblock": {
+			Body: models.BlockClient{}, Result: &client_service.Client{},
+		},
+		"GET /user/:id/block-history": {
+			Params: []openapi.Parameter{fieldsParam("action,reason")},
+			Result: &client_service.GetClientBlockHistoryResponse{},
+		},
+
+		// order
+		"POST /order": {
+			Body: &order_service.CreateOrder{}, Status: htp.StatusCreated, Result: &order_service.Order{},
+		},
+		"GET /order/overdue": {
+			Params: append(offsetParams(), cursorParam(), fieldsParam("id,client_id,due_date")),
+			Result: &order_service.GetListOrderResponse{},
+		},
+		"GET /order/:id": {
+			Params: []openapi.Parameter{
+				expandParam(orderExpandRelations), fieldsParam("id,status,total_price or expanded.client.first_name"), ifNoneMatch,
+			},
+			Result:  &order_service.Order{},
+			Headers: etag,
+		},
+		"GET /order": {
+			Params: append(append(pageParams(), openapi.Filters(orderQuerySchema)...),
+				expandParam(orderExpandRelations), fieldsParam("id,status,total_price or expanded.client.first_name")),
+			Result: &order_service.GetListOrderResponse{},
+		},
+		"PUT /order/:id": {
+			Params: []openapi.Parameter{ifMatch},
+			Body:   &order_service.UpdateOrder{}, Result: &order_service.Order{}, Headers: etag,
+		},
+		"PATCH /order/:id": {
+			Params: []openapi.Parameter{ifMatch},
+			Body:   models.UpdatePatch{}, Result: &order_service.Order{}, Headers: etag,
+		},
+		"DELETE /order/:id": {
+			Params: []openapi.Parameter{ifMatch}, Status: htp.StatusNoContent,
+		},
+		"POST /order/:id/confirm":  statusBody,
+		"POST /order/:id/complete": statusBody,
+		"POST /order/:id/cancel":   statusBody,
+		"POST /order/:id/overdue":  statusBody,
+		"POST /order/:id/pickup": {
+			Body: models.VehicleHandover{}, Result: &order_service.Order{},
+		},
+		"POST /order/:id/return": {
+			Body: models.VehicleHandover{}, Result: &order_service.Order{},
+		},
+		"GET /order/:id/history": {
+			Params: []openapi.Parameter{fieldsParam("to_status,created_at")},
+			Result: &order_service.GetOrderStatusHistoryResponse{},
+		},
+		"GET /order/:id/inspections": {
+			Params: []openapi.Parameter{fieldsParam("id,type,mileage")},
+			Result: &order_service.GetVehicleInspectionsResponse{},
+		},
+		"GET /order/:id/charges": {
+			Params: []openapi.Parameter{fieldsParam("type,amount")},
+			Result: &order_service.GetOrderChargesResponse{},
+		},
+		"POST /order/:id/payments": {
+			Body: models.CreatePayment{}, Status: htp.StatusCreated, Result: &order_service.Payment{},
+		},
+		"GET /order/:id/payments": {
+			Params: []openapi.Parameter{fieldsParam("id,amount,method")},
+			Result: &order_service.GetListPaymentResponse{},
+		},
+		"GET /order/:id/deposit": {
+			Params: []openapi.Parameter{fieldsParam("type,amount")},
+			Result: &order_service.GetDepositTransactionsResponse{},
+		},
+		"POST /order/:id/deposit/hold": {
+			Body: models.DepositHold{}, Result: &order_service.Order{},
+		},
+		"POST /order/:id/deposit/capture": {
+			Body: models.DepositCapture{}, Result: &order_service.Order{},
+		},
+		"POST /order/:id/deposit/release": {
+			Body: models.DepositRelease{}, BodyOptional: true, Result: &order_service.Order{},
+		},
+
+		// car
+		"POST /car": {
+			Body: &order_service.CreateCar{}, Status: htp.StatusCreated, Result: &order_service.Car{},
+		},
+		"GET /car/:id": {
+			Params: []openapi.Parameter{
+				expandParam(carExpandRelations), fieldsParam("id,state_number or expanded.model.name"), ifNoneMatch,
+			},
+			Result:  &order_service.Car{},
+			Headers: etag,
+		},
+		"GET /car": {
+			Params: append(append(pageParams(), openapi.Filters(carQuerySchema)...),
+				expandParam(carExpandRelations), fieldsParam("id,state_number or expanded.model.name")),
+			Result: &order_service.GetListCarResponse{},
+		},
+		"PUT /car/:id": {
+			Params: []openapi.Parameter{ifMatch},
+			Body:   &order_service.UpdateCar{}, Result: &order_service.Car{}, Headers: etag,
+		},
+		"PATCH /car/:id": {
+			Params: []openapi.Parameter{ifMatch},
+			Body:   models.UpdatePatch{}, Result: &order_service.Car{}, Headers: etag,
+		},
+		"DELETE /car/:id": {
+			Params: []openapi.Parameter{ifMatch}, Status: htp.StatusNoContent,
+		},
+
+		// batch and graphql
+		"POST /batch": {
+			Params: []openapi.Parameter{openapi.Query("atomic", openapi.Boolean(), "stop at the first failed request")},
+			Body:   []models.BatchRequest{}, Result: []models.BatchResponse{},
+		},
+		"POST /graphql": {
+			Body: models.GraphQLRequest{}, Result: &openapi.Schema{Type: "object"},
+		},
+
+		// otp
+		"POST /check": {
+			Body: &client_service.CreateOTP{}, Status: htp.StatusCreated, Result: &openapi.Schema{Type: "object"},
+		},
+		"GET /check": {
+			Params: []openapi.Parameter{
+				required(openapi.Query("otp_code", openapi.String(), "code sent to the phone")),
+				required(openapi.Query("phone_number", openapi.String(), "phone number")),
+			},
+			Result: &openapi.Schema{Type: "string", Description: "JWT"},
+		},
+
+		// webhooks
+		"POST /webhooks": {
+			Body: models.CreateWebhook{}, Status: htp.StatusCreated, Result: &webhook.Subscription{},
+		},
+		"GET /webhooks": {
+			Result: models.WebhookList{},
+		},
+		"DELETE /webhooks/:id": {
+			Status: htp.StatusNoContent,
+		},
+		"GET /webhooks/dead-letters": {
+			Result: models.WebhookDeliveryList{},
+		},
+		"POST /webhooks/dead-letters/replay": {
+			Status: htp.StatusAccepted, Result: models.WebhookReplay{},
+		},
+		"POST /webhooks/dead-letters/:id/replay": {
+			Status: htp.StatusAccepted, Result: models.WebhookReplay{},
+		},
+
+		// streams are not request and response, they are described in swagger
+		"GET /ws":     {Hidden: true},
+		"GET /events": {Hidden: true},
+
+		// documentation
+		"GET /openapi.json": {Hidden: true},
+		"GET /docs":         {Hidden: true},
+		"GET /swagger/*any": {Hidden: true},
+	}
+
+	// the operation ids, summaries, descriptions and tags of the handlers are their swag annotations
+	err := openapi.Describe(endpoints, []byte(docs.SwaggerInfo.ReadDoc()))
+	if err != nil {
+		panic(err)
+	}
+
+	// every annotated rpc, the ones with a hand written route above are only used when it is removed
+	for _, route := range annotatedRoutes() {
+		key := openapi.Key(route.Method, route.Path)
+		if _, ok := endpoints[key]; !ok {
+			endpoints[key] = transcodedEndpoint(route)
+		}
+	}
+
+	return endpoints
+}
+
+// transcodedEndpoint documents a route generated from a google.api.http annotation
+func transcodedEndpoint(route transcode.Route) openapi.Endpoint {
+	var (
+		service = route.RPC.Parent().(protoreflect.ServiceDescriptor)
+		tag     = strings.TrimSuffix(string(service.Name()), "Service")
+		input   = route.RPC.Input()
+	)
+
+	endpoint := openapi.Endpoint{
+		ID:          strings.ToLower(tag) + "_" + snakeCase(string(route.RPC.Name())),
+		Summary:     fmt.Sprintf("%s %s", route.RPC.Name(), tag),
+		Description: fmt.Sprintf("Calls %s", route.RPC.FullName()),
+		Tag:         tag,
+		Status:      route.SuccessCode(),
+	}
+
+	if route.SuccessCode() != htp.StatusNoContent {
+		endpoint.Result = route.RPC.Output()
+	}
+
+	switch route.Body {
+	case "":
+		bound := map[string]bool{}
+		for _, name := range route.Variables {
+			bound[name] = true
+		}
+
+		fields := input.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if bound[string(field.Name())] || field.Kind() == protoreflect.MessageKind {
+				continue
+			}
+			endpoint.Params = append(endpoint.Params, openapi.Query(string(field.Name()), openapi.Field(field), ""))
+		}
+	case "*":
+		endpoint.Body = input
+	default:
+		endpoint.Body = input.Fields().ByName(protoreflect.Name(route.Body)).Message()
+	}
+
+	return endpoint
+}
+
+// snakeCase turns an rpc name into the operation id style, GetByID becomes get_by_id
+func snakeCase(name string) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		upper := unicode.IsUpper(r)
+		if upper && i > 0 {
+			previous := rune(name[i-1])
+			next := i+1 < len(name) && unicode.IsLower(rune(name[i+1]))
+			if unicode.IsLower(previous) || (unicode.IsUpper(previous) && next) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+func required(parameter openapi.Parameter) openapi.Parameter {
+	parameter.Required = true
+	return parameter
+}
+
+func pageParams() []openapi.Parameter {
+	return append(offsetParams(), cursorParam(), openapi.Query("search", openapi.String(), "search"))
+}
+
+func cursorParam() openapi.Parameter {
+	return openapi.Query("cursor", openapi.String(), "next_cursor or prev_cursor of a previous page, replaces offset")
+}
+
+func offsetParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.Query("offset", openapi.Integer(), "offset"),
+		openapi.Query("limit", openapi.Integer(), "limit, at most the configured maximum page size"),
+	}
+}
+
+func fieldsParam(example string) openapi.Parameter {
+	return openapi.Query("fields", openapi.String(), "comma separated fields to return, e.g. "+example)
+}
+
+func expandParam(relations []string) openapi.Parameter {
+	return openapi.Query("expand", openapi.String(), "comma separated relations: "+strings.Join(relations, ", "))
+}
@@ -0,0 +1,36 @@
+package handlers_test
+
+import (
+	"Projects/Car24/car24_api_gateway/api"
+	"Projects/Car24/car24_api_gateway/api/handlers"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestEveryRouteIsDocumented registers the routes like cmd/openapi
+func TestEveryRouteIsDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Load()
+	r := gin.New()
+	api.SetUpAPI(r, handlers.NewHandler(cfg, logger.NewLogger("test", logger.LevelError), nil), cfg)
+
+	doc := handlers.OpenAPIDocument(r.Routes())
+	if missing := openapi.Missing(doc, r.Routes(), handlers.Endpoints()); len(missing) > 0 {
+		t.Fatalf("routes missing from the OpenAPI document: %v", missing)
+	}
+
+	ids := map[string]string{}
+	for path, operations := range doc.Paths {
+		for method, operation := range operations {
+			if other, ok := ids[operation.OperationID]; ok {
+				t.Errorf("operationId %s of %s %s is also used by %s", operation.OperationID, method, path, other)
+			}
+			ids[operation.OperationID] = method + " " + path
+		}
+	}
+}
@@ -2,11 +2,18 @@ package handlers
 
 import (
 	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
 	"Projects/Car24/car24_api_gateway/genproto/order_service"
 	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/billing"
+	"Projects/Car24/car24_api_gateway/pkg/eligibility"
 	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/query"
 	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,14 +29,23 @@ import (
 // @Param profile body order_service.CreateOrder true "CreateOrderRequestBody"
 // @Success 200 {object} http.Response{data=order_service.Order} "GetOrderBody"
 // @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 422 {object} http.Response{data=models.EligibilityFailed} "Client is not eligible"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) CreateOrder(c *gin.Context) {
 	var order order_service.CreateOrder
-	err := c.ShouldBindJSON(&order)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &order) {
+		return
+	}
+
+	if !h.ensureOwner(c, order.ClientId) {
 		return
 	}
+
+	if !h.checkClientEligibility(c, order.ClientId, order.StartDate, order.DayCount) {
+		return
+	}
+
 	resp, err := h.services.OrderService().Create(
 		c.Request.Context(),
 		&order,
@@ -38,6 +54,7 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		h.handleResponse(c, http.GRPCError, err.Error())
 		return
 	}
+	h.publish(webhook.OrderCreated, resp)
 	h.handleResponse(c, http.Created, resp)
 }
 
@@ -45,13 +62,18 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 // @ID get_order_by_id
 // @Router /order/{id} [GET]
 // @Summary Get Order By ID
-// @Description Get Order By ID
+// @Description Get Order By ID, with expand the related resources are embedded under expanded as in models.ExpandedOrder
 // @Tags Order
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param expand query string false "comma separated relations: client, car, tarif, mechanic, discount"
+// @Param fields query string false "comma separated fields to return, e.g. id,status,total_price or expanded.client.first_name"
+// @Param If-None-Match header string false "ETag of the version the client has"
 // @Success 200 {object} http.Response{data=order_service.Order} "OrderBody"
+// @Response 304 {object} http.Response{data=string} "Not Modified"
 // @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) GetOrderByID(c *gin.Context) {
 	orderId := c.Param("id")
@@ -59,16 +81,50 @@ func (h *Handler) GetOrderByID(c *gin.Context) {
 		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
 		return
 	}
+
+	relations, ok := h.getExpand(c, orderExpandRelations)
+	if !ok {
+		return
+	}
+
+	fields, ok := h.getFields(c, orderDescriptor, "", orderExtensions)
+	if !ok {
+		return
+	}
+
 	resp, err := h.services.OrderService().GetByID(
 		context.Background(),
 		&order_service.OrderPrimaryKey{
-			Id: orderId,
+			Id:        orderId,
+			FieldMask: backendMask(fields, orderGatewayFields),
 		},
 	)
 	if err != nil {
 		h.handleResponse(c, http.GRPCError, err.Error())
 		return
 	}
+
+	if !h.ensureOwner(c, resp.ClientId) {
+		return
+	}
+
+	err = h.decorateOrders(c.Request.Context(), time.Now(), resp)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	if h.notModified(c, resp) {
+		return
+	}
+
+	if len(relations) > 0 {
+		expanded, errs := h.expandOrders(c.Request.Context(), []*order_service.Order{resp}, relations)
+		expanded[0].ExpandErrors = errs
+		h.handleResponse(c, http.OK, expanded[0])
+		return
+	}
+
 	h.handleResponse(c, http.OK, resp)
 }
 
@@ -76,35 +132,72 @@ func (h *Handler) GetOrderByID(c *gin.Context) {
 // @ID get_order_list
 // @Router /order [GET]
 // @Summary Get Order List
-// @Description Get Order List
+// @Description Get Order List, filter as field=value, field=a,b or field[op]=value with op one of eq, ne, in, gt, gte, lt, lte, like, expand embeds related resources as in models.ExpandedOrderList, clients only get their own orders
 // @Tags Order
 // @Accept json
 // @Produce json
 // @Param offset query integer false "offset"
-// @Param limit query integer false "limit"
-// @Param search query string false "search"
+// @Param limit query integer false "limit, at most the configured maximum page size"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, replaces offset"
+// @Param search query string false "search, dates like 15 марта, ertaga or с 1 по 5 мая go in start_date"
+// @Param status query string false "comma separated statuses: draft, confirmed, active, returned, completed, cancelled, overdue"
+// @Param car_id query string false "car id"
+// @Param client_id query string false "client id"
+// @Param tarif_id query string false "tarif id"
+// @Param mechanic_id query string false "mechanic id"
+// @Param start_date query string false "orders starting on the day or in the range, e.g. 2024-05-01, 15 марта, ertaga, с 1 по 5 мая, 1-5 may"
+// @Param start_date[gte] query string false "orders starting at or after the date"
+// @Param start_date[lte] query string false "orders starting at or before the date"
+// @Param created_at[gte] query string false "orders created at or after the date"
+// @Param created_at[lte] query string false "orders created at or before the date"
+// @Param total_price[gte] query number false "minimal total price"
+// @Param total_price[lte] query number false "maximal total price"
+// @Param is_paid query boolean false "fully paid or unpaid orders"
+// @Param expand query string false "comma separated relations: client, car, tarif, mechanic, discount"
+// @Param fields query string false "comma separated fields to return, e.g. id,status,total_price or expanded.client.first_name"
+// @Param sort query string false "sort by start_date, created_at, total_price, day_count, order_number e.g. created_at:desc,total_price:asc, the newest first when not given"
+// @Header 200 {string} Link "next, prev and first page links"
 // @Success 200 {object} http.Response{data=order_service.GetListOrderResponse} "GetAllOrderResponseBody"
-// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 400 {object} http.Response{data=models.QueryRejected} "Rejected filters"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) GetListOrder(c *gin.Context) {
-	offset, err := h.getOffsetParam(c)
-	if err != nil {
-		h.handleResponse(c, http.InvalidArgument, err.Error())
+	current, ok := h.getPage(c)
+	if !ok {
 		return
 	}
 
-	limit, err := h.getLimitParam(c)
-	if err != nil {
-		h.handleResponse(c, http.InvalidArgument, err.Error())
+	listQuery, ok := h.parseListQuery(c, orderQuerySchema)
+	if !ok {
+		return
+	}
+
+	relations, ok := h.getExpand(c, orderExpandRelations)
+	if !ok {
+		return
+	}
+
+	fields, ok := h.getFields(c, orderDescriptor, "orders", orderExtensions)
+	if !ok {
 		return
 	}
 
+	listQuery.Sort = keysetSort(listQuery.Sort)
+	filters, sorts := orderFilters(listQuery)
+	if !h.isStaff(c) {
+		// clients list their own orders only
+		filters = append(filters, &order_service.Filter{Field: "client_id", Op: query.OpEq, Values: []string{h.getAuthUserID(c)}})
+	}
+
 	resp, err := h.services.OrderService().GetList(
 		context.Background(),
 		&order_service.GetListOrderRequest{
-			Limit:  int64(limit),
-			Offset: int64(offset),
-			Search: c.Query("search"),
+			Limit:     current.FetchLimit(),
+			Offset:    current.Offset,
+			Keyset:    current.orderKeyset(),
+			Search:    c.Query("search"),
+			Filters:   filters,
+			Sort:      sorts,
+			FieldMask: backendMask(fields, withSortFields(orderGatewayFields, listQuery.Sort)),
 		},
 	)
 
@@ -113,22 +206,49 @@ func (h *Handler) GetListOrder(c *gin.Context) {
 		return
 	}
 
+	resp.Orders, resp.NextCursor, resp.PrevCursor = pageRows(h, c, current, listQuery.Sort, resp.Orders)
+
+	err = h.decorateOrders(c.Request.Context(), time.Now(), resp.Orders...)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	if len(relations) > 0 {
+		expanded, errs := h.expandOrders(c.Request.Context(), resp.Orders, relations)
+		h.handleResponse(c, http.OK, models.ExpandedOrderList{
+			Count:        resp.Count,
+			Orders:       expanded,
+			NextCursor:   resp.NextCursor,
+			PrevCursor:   resp.PrevCursor,
+			ExpandErrors: errs,
+		})
+		return
+	}
+
 	h.handleResponse(c, http.OK, resp)
 }
 
 // @ID update_order
 // @Router /order/{id} [PUT]
 // @Summary Update Order
-// @Description Update Order
+// @Description Update Order, the fields the caller role may not patch must keep their current value
 // @Tags Order
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param profile body order_service.UpdateOrder true "UpdateOrderRequestBody"
 // @Success 200 {object} http.Response{data=order_service.Order} "order data"
-// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 400 {object} http.Response{data=models.PatchRejected} "Rejected fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpdateOrder(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
 
 	var order order_service.UpdateOrder
 
@@ -139,22 +259,45 @@ func (h *Handler) UpdateOrder(c *gin.Context) {
 		return
 	}
 
-	err := c.ShouldBindJSON(&order)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &order) {
+		return
+	}
+
+	if order.ClientId != "" && !h.ensureClientNotBlocked(c, order.ClientId) {
+		return
+	}
+
+	current, ok := h.loadCurrent(c, h.currentOrder(c, order.Id))
+	if !ok {
 		return
 	}
 
+	expected, ok := h.checkIfMatch(c, current)
+	if !ok {
+		return
+	}
+
+	if !h.checkUpdate(c, orderPatchPolicy, current, &order) {
+		return
+	}
+
+	if expected != "" {
+		order.ExpectedUpdatedAt = expected
+	}
+
 	resp, err := h.services.OrderService().Update(
 		c.Request.Context(),
 		&order,
 	)
 
 	if err != nil {
-		h.handleResponse(c, http.GRPCError, err.Error())
+		h.handleWriteError(c, err)
 		return
 	}
 
+	h.decorateChangedOrder(c, resp)
+	h.setETag(c, resp)
+	h.publish(webhook.OrderUpdated, resp)
 	h.handleResponse(c, http.OK, resp)
 }
 
@@ -162,31 +305,44 @@ func (h *Handler) UpdateOrder(c *gin.Context) {
 // @ID patch_order
 // @Router /order/{id} [PATCH]
 // @Summary Patch Order
-// @Description Patch Order
+// @Description Patch Order fields allowed for the caller role, the body is either UpdatePatch or a JSON Merge Patch object
 // @Tags Order
-// @Accept json
+// @Accept json,application/merge-patch+json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param profile body models.UpdatePatch true "UpdatePatchRequestBody"
 // @Success 200 {object} http.Response{data=order_service.Order} "Order data"
-// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 400 {object} http.Response{data=models.PatchRejected} "Rejected fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpdatePatchOrder(c *gin.Context) {
-
-	var updatePatchOrder models.UpdatePatch
-
-	err := c.ShouldBindJSON(&updatePatchOrder)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.ensureStaff(c) {
 		return
 	}
 
+	var updatePatchOrder models.UpdatePatch
+
 	updatePatchOrder.ID = c.Param("id")
 
 	if !util.IsValidUUID(updatePatchOrder.ID) {
-		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	data, ok := h.bindPatch(c, orderPatchPolicy)
+	if !ok {
 		return
 	}
+	updatePatchOrder.Data = data
+
+	if clientId, ok := updatePatchOrder.Data["client_id"].(string); ok {
+		if !h.ensureClientNotBlocked(c, clientId) {
+			return
+		}
+	}
 
 	structData, err := helper.ConvertMapToStruct(updatePatchOrder.Data)
 	if err != nil {
@@ -194,19 +350,28 @@ func (h *Handler) UpdatePatchOrder(c *gin.Context) {
 		return
 	}
 
+	expected, ok := h.checkIfMatchLoading(c, h.currentOrder(c, updatePatchOrder.ID))
+	if !ok {
+		return
+	}
+
 	resp, err := h.services.OrderService().UpdatePatch(
 		c.Request.Context(),
 		&order_service.UpdatePatchOrder{
-			Id:     updatePatchOrder.ID,
-			Fields: structData,
+			Id:                updatePatchOrder.ID,
+			Fields:            structData,
+			ExpectedUpdatedAt: expected,
 		},
 	)
 
 	if err != nil {
-		h.handleResponse(c, http.GRPCError, err.Error())
+		h.handleWriteError(c, err)
 		return
 	}
 
+	h.decorateChangedOrder(c, resp)
+	h.setETag(c, resp)
+	h.publish(webhook.OrderUpdated, resp)
 	h.handleResponse(c, http.OK, resp)
 }
 
@@ -219,10 +384,17 @@ func (h *Handler) UpdatePatchOrder(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} http.Response{data=object{}} "Order data"
 // @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) DeleteOrder(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
 
 	userId := c.Param("id")
 
@@ -231,6 +403,11 @@ func (h *Handler) DeleteOrder(c *gin.Context) {
 		return
 	}
 
+	// deletes are only checked by the gateway, the primary key messages carry no version
+	if _, ok := h.checkIfMatchLoading(c, h.currentOrder(c, userId)); !ok {
+		return
+	}
+
 	resp, err := h.services.OrderService().Delete(
 		c.Request.Context(),
 		&order_service.OrderPrimaryKey{Id: userId},
@@ -241,5 +418,83 @@ func (h *Handler) DeleteOrder(c *gin.Context) {
 		return
 	}
 
+	h.publish(webhook.OrderDeleted, deletedResource{ID: userId})
 	h.handleResponse(c, http.NoContent, resp)
 }
+
+// decorateOrders fills the fields the gateway derives from the stored orders. The paid price and
+// balance are the sums of the payment ledgers, like GET /order/:id/payments, when they cannot be
+// loaded the orders keep the paid price of the backend and the error is returned
+func (h *Handler) decorateOrders(ctx context.Context, now time.Time, orders ...*order_service.Order) error {
+	var (
+		ids    = make([]string, 0, len(orders))
+		totals *order_service.GetPaymentTotalsResponse
+		err    error
+	)
+	for _, order := range orders {
+		ids = append(ids, order.Id)
+	}
+	if len(ids) > 0 {
+		totals, err = h.services.OrderService().GetPaymentTotals(ctx, &order_service.GetPaymentTotalsRequest{OrderIds: ids})
+	}
+
+	for _, order := range orders {
+		h.setDueDate(order, now)
+		if totals != nil {
+			order.PaidPrice = billing.RoundMoney(totals.PaidPrice[order.Id])
+		}
+		order.Balance = billing.Balance(order.TotalPrice, order.PaidPrice)
+		order.DepositStatus = billing.DepositStatus(order.DepositHeld, order.DepositCaptured, order.DepositReleased)
+		h.alertOverdue(ctx, order)
+	}
+
+	return err
+}
+
+// decorateChangedOrder decorates the order a change answers with, the change is done so a ledger
+// that cannot be loaded is only logged
+func (h *Handler) decorateChangedOrder(c *gin.Context, order *order_service.Order) {
+	err := h.decorateOrders(c.Request.Context(), time.Now(), order)
+	if err != nil {
+		h.log.Error("load payment totals", logger.String("order_id", order.Id), logger.Error(err))
+	}
+}
+
+// checkClientEligibility loads the client and rejects the order when the client may not rent a car for the period
+func (h *Handler) checkClientEligibility(c *gin.Context, clientId, startDate string, dayCount int32) bool {
+	if !util.IsValidUUID(clientId) {
+		h.handleResponse(c, http.InvalidArgument, "client id is an invalid uuid")
+		return false
+	}
+
+	start, err := helper.ParseDateTime(startDate)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, "start_date: "+err.Error())
+		return false
+	}
+
+	if dayCount < 1 {
+		h.handleResponse(c, http.InvalidArgument, "day_count must be positive")
+		return false
+	}
+
+	client, err := h.services.UserService().GetByID(
+		c.Request.Context(),
+		&client_service.CLientPrimaryKey{Id: clientId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return false
+	}
+
+	failures := eligibility.CheckClient(client, start, billing.DueTime(start, dayCount))
+	if len(failures) > 0 {
+		h.handleResponse(c, http.UnprocessableEntity, models.EligibilityFailed{
+			ClientID: clientId,
+			Failures: failures,
+		})
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/billing"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// HoldOrderDeposit godoc
+// @ID hold_order_deposit
+// @Router /order/{id}/deposit/hold [POST]
+// @Summary Hold Order Deposit
+// @Description Record the deposit taken from the client, it must cover the amount required by the tariff or model
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.DepositHold true "DepositHoldRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Deposit cannot be held"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) HoldOrderDeposit(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var hold models.DepositHold
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.bind(c, &hold) {
+		return
+	}
+
+	if !billing.IsValidPaymentMethod(hold.Method) {
+		h.handleResponse(c, http.InvalidArgument, "payment method must be one of cash, card, transfer")
+		return
+	}
+
+	order, ok := h.getOrderForDeposit(c, orderId, lifecycle.StatusDraft, lifecycle.StatusConfirmed, lifecycle.StatusActive)
+	if !ok {
+		return
+	}
+
+	required, err := h.requiredDeposit(c.Request.Context(), order)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	err = billing.ValidateDepositHold(hold.Amount, required, order.DepositHeld)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	h.addDepositTransaction(c, &order_service.CreateDepositTransaction{
+		OrderId:         orderId,
+		Type:            billing.DepositHold,
+		Amount:          hold.Amount,
+		Method:          hold.Method,
+		Comment:         hold.Comment,
+		DepositRequired: required,
+	})
+}
+
+// CaptureOrderDeposit godoc
+// @ID capture_order_deposit
+// @Router /order/{id}/deposit/capture [POST]
+// @Summary Capture Order Deposit
+// @Description Deduct damages or fines from the deposit of a returned order, reason is one of damage, fine, other
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.DepositCapture true "DepositCaptureRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Deposit cannot be captured"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) CaptureOrderDeposit(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var capture models.DepositCapture
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.bind(c, &capture) {
+		return
+	}
+
+	if !billing.IsValidCaptureReason(capture.Reason) {
+		h.handleResponse(c, http.InvalidArgument, "reason must be one of damage, fine, other")
+		return
+	}
+
+	order, ok := h.getOrderForDeposit(c, orderId, lifecycle.StatusReturned, lifecycle.StatusCompleted)
+	if !ok {
+		return
+	}
+
+	remaining := billing.DepositRemaining(order.DepositHeld, order.DepositCaptured, order.DepositReleased)
+	err := billing.ValidateDepositCapture(capture.Amount, remaining)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	h.addDepositTransaction(c, &order_service.CreateDepositTransaction{
+		OrderId: orderId,
+		Type:    billing.DepositCapture,
+		Amount:  capture.Amount,
+		Reason:  capture.Reason,
+		Comment: capture.Comment,
+	})
+}
+
+// ReleaseOrderDeposit godoc
+// @ID release_order_deposit
+// @Router /order/{id}/deposit/release [POST]
+// @Summary Release Order Deposit
+// @Description Give the remainder of the deposit back to the client
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.DepositRelease false "DepositReleaseRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Deposit cannot be released"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ReleaseOrderDeposit(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var release models.DepositRelease
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if c.Request.ContentLength > 0 && !h.bind(c, &release) {
+		return
+	}
+
+	order, ok := h.getOrderForDeposit(c, orderId, lifecycle.StatusReturned, lifecycle.StatusCompleted, lifecycle.StatusCancelled)
+	if !ok {
+		return
+	}
+
+	remaining := billing.DepositRemaining(order.DepositHeld, order.DepositCaptured, order.DepositReleased)
+	if remaining <= 0 {
+		h.handleResponse(c, http.Conflict, "order has no deposit left to release")
+		return
+	}
+
+	h.addDepositTransaction(c, &order_service.CreateDepositTransaction{
+		OrderId: orderId,
+		Type:    billing.DepositRelease,
+		Amount:  remaining,
+		Comment: release.Comment,
+	})
+}
+
+// GetOrderDeposit godoc
+// @ID get_order_deposit
+// @Router /order/{id}/deposit [GET]
+// @Summary Get Order Deposit
+// @Description Show the required deposit, its state and the hold, capture and release transactions
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param fields query string false "comma separated fields of the transactions items to return, e.g. type,amount"
+// @Success 200 {object} http.Response{data=order_service.GetDepositTransactionsResponse} "Deposit"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetOrderDeposit(c *gin.Context) {
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if _, ok := h.getFields(c, depositDescriptor, "transactions", nil); !ok {
+		return
+	}
+
+	order, err := h.services.OrderService().GetByID(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	if !h.ensureOwner(c, order.ClientId) {
+		return
+	}
+
+	resp, err := h.services.OrderService().GetDepositTransactions(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	resp.DepositRequired = order.DepositRequired
+	if resp.DepositRequired == 0 {
+		resp.DepositRequired, err = h.requiredDeposit(c.Request.Context(), order)
+		if err != nil {
+			h.handleResponse(c, http.GRPCError, err.Error())
+			return
+		}
+	}
+
+	resp.DepositHeld, resp.DepositCaptured, resp.DepositReleased = 0, 0, 0
+	for _, transaction := range resp.Transactions {
+		switch transaction.Type {
+		case billing.DepositHold:
+			resp.DepositHeld += transaction.Amount
+		case billing.DepositCapture:
+			resp.DepositCaptured += transaction.Amount
+		case billing.DepositRelease:
+			resp.DepositReleased += transaction.Amount
+		}
+	}
+	resp.DepositRemaining = billing.DepositRemaining(resp.DepositHeld, resp.DepositCaptured, resp.DepositReleased)
+	resp.DepositStatus = billing.DepositStatus(resp.DepositHeld, resp.DepositCaptured, resp.DepositReleased)
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// getOrderForDeposit loads the order and checks its status allows the deposit operation
+func (h *Handler) getOrderForDeposit(c *gin.Context, orderId string, statuses ...string) (*order_service.Order, bool) {
+	order, err := h.services.OrderService().GetByID(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return nil, false
+	}
+
+	status := lifecycle.Normalize(order.Status)
+	for _, allowed := range statuses {
+		if status == allowed {
+			return order, true
+		}
+	}
+
+	h.handleResponse(c, http.Conflict, "deposit operation is not allowed for "+status+" orders")
+	return nil, false
+}
+
+// requiredDeposit resolves the deposit from the order tariff, then the car model, then the configured default
+func (h *Handler) requiredDeposit(ctx context.Context, order *order_service.Order) (float64, error) {
+	if order.TarifId != "" {
+		tarif, err := h.services.TarifService().GetByID(ctx, &order_service.TarifPK{Id: order.TarifId})
+		if err != nil {
+			return 0, err
+		}
+		if deposit := cast.ToFloat64(tarif.Deposit); deposit > 0 {
+			return deposit, nil
+		}
+	}
+
+	if order.CarId != "" {
+		car, err := h.services.CarService().GetByID(ctx, &order_service.CarPrimaryKey{Id: order.CarId})
+		if err != nil {
+			return 0, err
+		}
+
+		model, err := h.services.ModelService().GetByID(ctx, &order_service.ModelPK{Id: car.ModelId})
+		if err != nil {
+			return 0, err
+		}
+		if deposit := cast.ToFloat64(model.Deposit); deposit > 0 {
+			return deposit, nil
+		}
+	}
+
+	return h.cfg.DefaultDeposit, nil
+}
+
+func (h *Handler) addDepositTransaction(c *gin.Context, transaction *order_service.CreateDepositTransaction) {
+	transaction.OperatorId = h.getAuthUserID(c)
+
+	resp, err := h.services.OrderService().AddDepositTransaction(c.Request.Context(), transaction)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+	h.decorateChangedOrder(c, resp)
+	h.publish(depositEvents[transaction.Type], resp)
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/billing"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Inspection types
+const (
+	inspectionPickup = "pickup"
+	inspectionReturn = "return"
+)
+
+// PickupOrder godoc
+// @ID pickup_order
+// @Router /order/{id}/pickup [POST]
+// @Summary Pickup Order
+// @Description Hand the car over to the client recording odometer, fuel level and checklist, the order becomes active
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.VehicleHandover true "VehicleHandoverRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Transition not allowed"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) PickupOrder(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var handover models.VehicleHandover
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.bind(c, &handover) {
+		return
+	}
+
+	_, ok := h.getOrderForTransition(c, orderId, lifecycle.StatusActive)
+	if !ok {
+		return
+	}
+
+	// the inspection and the status change are one call, a failed pickup leaves no inspection behind
+	// that a retry would record twice
+	resp, err := h.services.OrderService().Pickup(
+		c.Request.Context(),
+		&order_service.PickupOrder{
+			Id:         orderId,
+			Inspection: h.newInspection(c, orderId, inspectionPickup, handover),
+			ChangedBy:  h.getAuthUserID(c),
+			Comment:    handover.Comment,
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+	h.decorateChangedOrder(c, resp)
+	h.publishOrderStatus(resp, lifecycle.StatusActive)
+	h.publishCar(c, resp.CarId)
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// ReturnOrder godoc
+// @ID return_order
+// @Router /order/{id}/return [POST]
+// @Summary Return Order
+// @Description Take the car back recording odometer, fuel level and checklist, mileage overage, refuelling and late return are charged to the order
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.VehicleHandover true "VehicleHandoverRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Transition not allowed"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ReturnOrder(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var handover models.VehicleHandover
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.bind(c, &handover) {
+		return
+	}
+
+	order, ok := h.getOrderForTransition(c, orderId, lifecycle.StatusReturned)
+	if !ok {
+		return
+	}
+
+	inspections, err := h.services.OrderService().GetInspections(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	var pickup *order_service.VehicleInspection
+	for _, inspection := range inspections.Inspections {
+		if inspection.Type == inspectionPickup {
+			pickup = inspection
+		}
+	}
+	if pickup == nil {
+		h.handleResponse(c, http.Conflict, "order has no pickup inspection")
+		return
+	}
+
+	distance, err := billing.Distance(pickup.Mileage, *handover.Mileage)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	charges := h.handoverPolicy().ReturnCharges(order.DayCount, distance, pickup.FuelLevel, *handover.FuelLevel)
+
+	penalty, ok := h.latePenalty(c, order, time.Now())
+	if !ok {
+		return
+	}
+	if penalty != nil {
+		charges = append(charges, *penalty)
+	}
+
+	// the inspection, the charges and the status change are one call, a retry after a failure cannot
+	// leave the order charged but not returned and charge it again
+	returnOrder := &order_service.ReturnOrder{
+		Id:         orderId,
+		Inspection: h.newInspection(c, orderId, inspectionReturn, handover),
+		ChangedBy:  h.getAuthUserID(c),
+		Comment:    handover.Comment,
+	}
+	for _, charge := range charges {
+		returnOrder.Charges = append(returnOrder.Charges, &order_service.CreateOrderCharge{
+			OrderId:     orderId,
+			Type:        charge.Type,
+			Amount:      charge.Amount,
+			Description: charge.Description,
+			CreatedBy:   h.getAuthUserID(c),
+		})
+	}
+
+	resp, err := h.services.OrderService().Return(c.Request.Context(), returnOrder)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+	h.decorateChangedOrder(c, resp)
+	h.publishOrderStatus(resp, lifecycle.StatusReturned)
+	h.publishCar(c, resp.CarId)
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// publishCar sends the car whose availability a handover changed, the handover is done so a car that
+// cannot be loaded is only logged
+func (h *Handler) publishCar(c *gin.Context, carId string) {
+	car, err := h.services.CarService().GetByID(c.Request.Context(), &order_service.CarPrimaryKey{Id: carId})
+	if err != nil {
+		h.log.Error("load the car of the handover", logger.String("car_id", carId), logger.Error(err))
+		return
+	}
+
+	h.publish(webhook.CarUpdated, car)
+}
+
+// GetOrderInspections godoc
+// @ID get_order_inspections
+// @Router /order/{id}/inspections [GET]
+// @Summary Get Order Inspections
+// @Description List pickup and return inspections of the order
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param fields query string false "comma separated fields of the inspections items to return, e.g. id,type,mileage"
+// @Success 200 {object} http.Response{data=order_service.GetVehicleInspectionsResponse} "VehicleInspections"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetOrderInspections(c *gin.Context) {
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.ensureOrderOwner(c, orderId) {
+		return
+	}
+
+	if _, ok := h.getFields(c, inspectionDescriptor, "inspections", nil); !ok {
+		return
+	}
+
+	resp, err := h.services.OrderService().GetInspections(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// GetOrderCharges godoc
+// @ID get_order_charges
+// @Router /order/{id}/charges [GET]
+// @Summary Get Order Charges
+// @Description List extra charges added to the order total
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param fields query string false "comma separated fields of the charges items to return, e.g. type,amount"
+// @Success 200 {object} http.Response{data=order_service.GetOrderChargesResponse} "OrderCharges"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetOrderCharges(c *gin.Context) {
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.ensureOrderOwner(c, orderId) {
+		return
+	}
+
+	if _, ok := h.getFields(c, chargeDescriptor, "charges", nil); !ok {
+		return
+	}
+
+	resp, err := h.services.OrderService().GetCharges(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+func (h *Handler) newInspection(c *gin.Context, orderId, inspectionType string, handover models.VehicleHandover) *order_service.CreateVehicleInspection {
+	checklist := make([]*order_service.ChecklistItem, 0, len(handover.Checklist))
+	for _, item := range handover.Checklist {
+		checklist = append(checklist, &order_service.ChecklistItem{
+			Name: item.Name,
+			Ok:   item.Ok,
+			Note: item.Note,
+		})
+	}
+
+	return &order_service.CreateVehicleInspection{
+		OrderId:   orderId,
+		Type:      inspectionType,
+		Mileage:   *handover.Mileage,
+		FuelLevel: *handover.FuelLevel,
+		Checklist: checklist,
+		Notes:     handover.Notes,
+		CreatedBy: h.getAuthUserID(c),
+	}
+}
+
+func (h *Handler) handoverPolicy() billing.HandoverPolicy {
+	return billing.HandoverPolicy{
+		AllowancePerDay:     h.cfg.MileageAllowancePerDay,
+		OverageRatePerKm:    h.cfg.OverageRatePerKm,
+		RefuelFeePerPercent: h.cfg.RefuelFeePerPercent,
+		RefuelServiceFee:    h.cfg.RefuelServiceFee,
+	}
+}
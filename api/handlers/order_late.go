@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/billing"
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// overdueSort lists the oldest rentals first
+var overdueSort = []query.Sort{{Field: "start_date"}}
+
+// The orders found overdue that are alerted, an order still rented a day later is alerted again
+const (
+	overdueAlertTTL  = 24 * time.Hour
+	overdueAlertSize = 10000
+)
+
+// GetOverdueOrders godoc
+// @ID get_overdue_orders
+// @Router /order/overdue [GET]
+// @Summary Get Overdue Orders
+// @Description List rented orders whose car was not returned by the due time, the oldest rentals first
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit, at most the configured maximum page size"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, replaces offset"
+// @Param fields query string false "comma separated fields of the orders to return, e.g. id,client_id,due_date"
+// @Header 200 {string} Link "next, prev and first page links"
+// @Success 200 {object} http.Response{data=order_service.GetListOrderResponse} "GetAllOrderResponseBody"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetOverdueOrders(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	current, ok := h.getPage(c)
+	if !ok {
+		return
+	}
+
+	fields, ok := h.getFields(c, orderDescriptor, "orders", nil)
+	if !ok {
+		return
+	}
+
+	var (
+		now       = time.Now()
+		listQuery = &query.Query{Sort: keysetSort(overdueSort)}
+		_, sorts  = orderFilters(listQuery)
+	)
+
+	// the order service selects the orders due before the grace period, the page is as stable as the other lists
+	resp, err := h.services.OrderService().GetList(
+		c.Request.Context(),
+		&order_service.GetListOrderRequest{
+			Limit:     current.FetchLimit(),
+			Offset:    current.Offset,
+			Keyset:    current.orderKeyset(),
+			Statuses:  []string{lifecycle.StatusActive, lifecycle.StatusOverdue},
+			DueBefore: now.Add(-h.latePolicy().GracePeriod).Format(helper.DateTimeLayout),
+			Sort:      sorts,
+			FieldMask: backendMask(fields, withSortFields(orderGatewayFields, listQuery.Sort)),
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	resp.Orders, resp.NextCursor, resp.PrevCursor = pageRows(h, c, current, listQuery.Sort, resp.Orders)
+
+	err = h.decorateOrders(c.Request.Context(), now, resp.Orders...)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// MarkOrderOverdue godoc
+// @ID mark_order_overdue
+// @Router /order/{id}/overdue [POST]
+// @Summary Mark Order Overdue
+// @Description Move an active order whose due time has passed to overdue
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.ChangeOrderStatus false "ChangeOrderStatusRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Transition not allowed"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) MarkOrderOverdue(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var body models.ChangeOrderStatus
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if c.Request.ContentLength > 0 && !h.bind(c, &body) {
+		return
+	}
+
+	order, ok := h.getOrderForTransition(c, orderId, lifecycle.StatusOverdue)
+	if !ok {
+		return
+	}
+
+	h.setDueDate(order, time.Now())
+	if !order.IsOverdue {
+		h.handleResponse(c, http.Conflict, "order is not overdue yet, due date: "+order.DueDate)
+		return
+	}
+
+	h.applyOrderStatus(c, orderId, lifecycle.StatusOverdue, body.Comment)
+}
+
+// setDueDate fills the due date of the order and flags it when the car is rented past it
+func (h *Handler) setDueDate(order *order_service.Order, now time.Time) {
+	start, err := helper.ParseDateTime(order.StartDate)
+	if err != nil {
+		return
+	}
+
+	due := billing.DueTime(start, order.DayCount)
+	order.DueDate = due.Format(helper.DateTimeLayout)
+
+	status := lifecycle.Normalize(order.Status)
+	order.IsOverdue = (status == lifecycle.StatusActive || status == lifecycle.StatusOverdue) &&
+		h.latePolicy().IsLate(due, now)
+}
+
+// alertOverdue sends the overdue event of an active order found past its due time, once per
+// overdueAlertTTL. The order keeps its status until it is marked overdue
+func (h *Handler) alertOverdue(ctx context.Context, order *order_service.Order) {
+	if !order.IsOverdue || lifecycle.Normalize(order.Status) != lifecycle.StatusActive || h.overdueAlerts == nil {
+		return
+	}
+
+	_, _ = h.overdueAlerts.Load(ctx, order.Id, func(context.Context) (bool, error) {
+		h.publish(webhook.OrderOverdue, order)
+		return true, nil
+	})
+}
+
+// latePenalty computes the late return charge of an order returned at the given time, the tariff is
+// only loaded for the orders returned late
+func (h *Handler) latePenalty(c *gin.Context, order *order_service.Order, returnedAt time.Time) (*billing.Charge, bool) {
+	start, err := helper.ParseDateTime(order.StartDate)
+	if err != nil {
+		h.handleResponse(c, http.InternalServerError, err.Error())
+		return nil, false
+	}
+
+	policy := h.latePolicy()
+	due := billing.DueTime(start, order.DayCount)
+	if !policy.IsLate(due, returnedAt) {
+		return nil, true
+	}
+
+	tarif, err := h.services.TarifService().GetByID(c.Request.Context(), &order_service.TarifPK{Id: order.TarifId})
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return nil, false
+	}
+
+	pricePerDay, err := cast.ToFloat64E(tarif.PricePerDay)
+	if err != nil {
+		h.handleResponse(c, http.InternalServerError, fmt.Sprintf("tarif %s has invalid price per day: %v", tarif.Id, err))
+		return nil, false
+	}
+
+	return policy.LatePenalty(due, returnedAt, pricePerDay), true
+}
+
+func (h *Handler) latePolicy() billing.LatePolicy {
+	return billing.LatePolicy{
+		GracePeriod:      h.cfg.LateGracePeriod,
+		HourlyRateFactor: h.cfg.LateHourlyRateFactor,
+		DailyCapFactor:   h.cfg.LateDailyCapFactor,
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/billing"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateOrderPayment godoc
+// @ID create_order_payment
+// @Router /order/{id}/payments [POST]
+// @Summary Create Order Payment
+// @Description Record a payment for the order, refunds are recorded with a negative amount
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.CreatePayment true "CreatePaymentRequestBody"
+// @Success 201 {object} http.Response{data=order_service.Payment} "Payment data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Balance changed by another payment"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) CreateOrderPayment(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	var payment models.CreatePayment
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.bind(c, &payment) {
+		return
+	}
+
+	if !billing.IsValidPaymentMethod(payment.Method) {
+		h.handleResponse(c, http.InvalidArgument, "payment method must be one of cash, card, transfer")
+		return
+	}
+
+	ledger, ok := h.getPaymentLedger(c, orderId)
+	if !ok {
+		return
+	}
+
+	err := billing.ValidatePayment(payment.Amount, ledger.PaidPrice+ledger.Balance, ledger.PaidPrice)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	resp, err := h.services.OrderService().CreatePayment(
+		c.Request.Context(),
+		&order_service.CreateOrderPayment{
+			OrderId:    orderId,
+			Amount:     payment.Amount,
+			Method:     payment.Method,
+			OperatorId: h.getAuthUserID(c),
+			Comment:    payment.Comment,
+		},
+	)
+	if status.Code(err) == codes.FailedPrecondition {
+		// a payment recorded since the ledger was loaded left no room for this one
+		h.handleResponse(c, http.Conflict, status.Convert(err).Message())
+		return
+	}
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.publish(webhook.OrderPaid, resp)
+	h.handleResponse(c, http.Created, resp)
+}
+
+// GetOrderPayments godoc
+// @ID get_order_payments
+// @Router /order/{id}/payments [GET]
+// @Summary Get Order Payments
+// @Description List the payment ledger of the order with the paid amount and outstanding balance
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param fields query string false "comma separated fields of the payments items to return, e.g. id,amount,method"
+// @Success 200 {object} http.Response{data=order_service.GetListPaymentResponse} "Payments"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetOrderPayments(c *gin.Context) {
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if _, ok := h.getFields(c, paymentDescriptor, "payments", nil); !ok {
+		return
+	}
+
+	resp, ok := h.getPaymentLedger(c, orderId)
+	if !ok {
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// getPaymentLedger loads the payments of the order and derives the paid amount and balance from them
+func (h *Handler) getPaymentLedger(c *gin.Context, orderId string) (*order_service.GetListPaymentResponse, bool) {
+	order, err := h.services.OrderService().GetByID(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return nil, false
+	}
+
+	if !h.ensureOwner(c, order.ClientId) {
+		return nil, false
+	}
+
+	ledger, err := h.services.OrderService().GetPayments(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return nil, false
+	}
+
+	ledger.PaidPrice = 0
+	for _, payment := range ledger.Payments {
+		ledger.PaidPrice += payment.Amount
+	}
+	ledger.PaidPrice = billing.RoundMoney(ledger.PaidPrice)
+	ledger.Balance = billing.Balance(order.TotalPrice, ledger.PaidPrice)
+
+	return ledger, true
+}
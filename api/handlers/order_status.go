@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfirmOrder godoc
+// @ID confirm_order
+// @Router /order/{id}/confirm [POST]
+// @Summary Confirm Order
+// @Description Move a draft order to confirmed
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.ChangeOrderStatus false "ChangeOrderStatusRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Transition not allowed"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ConfirmOrder(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+	h.changeOrderStatus(c, lifecycle.StatusConfirmed)
+}
+
+// CompleteOrder godoc
+// @ID complete_order
+// @Router /order/{id}/complete [POST]
+// @Summary Complete Order
+// @Description Close a returned order
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.ChangeOrderStatus false "ChangeOrderStatusRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Transition not allowed"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) CompleteOrder(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+	h.changeOrderStatus(c, lifecycle.StatusCompleted)
+}
+
+// CancelOrder godoc
+// @ID cancel_order
+// @Router /order/{id}/cancel [POST]
+// @Summary Cancel Order
+// @Description Cancel a draft or confirmed order
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.ChangeOrderStatus false "ChangeOrderStatusRequestBody"
+// @Success 200 {object} http.Response{data=order_service.Order} "Order data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Transition not allowed"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) CancelOrder(c *gin.Context) {
+	h.changeOrderStatus(c, lifecycle.StatusCancelled)
+}
+
+// GetOrderStatusHistory godoc
+// @ID get_order_status_history
+// @Router /order/{id}/history [GET]
+// @Summary Get Order Status History
+// @Description List who changed the order status and when
+// @Tags Order
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param fields query string false "comma separated fields of the history items to return, e.g. to_status,created_at"
+// @Success 200 {object} http.Response{data=order_service.GetOrderStatusHistoryResponse} "OrderStatusHistory"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetOrderStatusHistory(c *gin.Context) {
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if !h.ensureOrderOwner(c, orderId) {
+		return
+	}
+
+	if _, ok := h.getFields(c, orderHistoryDescriptor, "history", nil); !ok {
+		return
+	}
+
+	resp, err := h.services.OrderService().GetStatusHistory(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+func (h *Handler) changeOrderStatus(c *gin.Context, status string) {
+	var body models.ChangeOrderStatus
+
+	orderId := c.Param("id")
+	if !util.IsValidUUID(orderId) {
+		h.handleResponse(c, http.InvalidArgument, "order id is an invalid uuid")
+		return
+	}
+
+	if c.Request.ContentLength > 0 && !h.bind(c, &body) {
+		return
+	}
+
+	_, ok := h.getOrderForTransition(c, orderId, status)
+	if !ok {
+		return
+	}
+
+	h.applyOrderStatus(c, orderId, status, body.Comment)
+}
+
+// getOrderForTransition loads the order and checks that it may move to the given status
+func (h *Handler) getOrderForTransition(c *gin.Context, orderId, status string) (*order_service.Order, bool) {
+	order, err := h.services.OrderService().GetByID(
+		c.Request.Context(),
+		&order_service.OrderPrimaryKey{Id: orderId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return nil, false
+	}
+
+	if !h.ensureOwner(c, order.ClientId) {
+		return nil, false
+	}
+
+	err = lifecycle.ValidateTransition(order.Status, status)
+	if err != nil {
+		h.handleResponse(c, http.Conflict, err.Error())
+		return nil, false
+	}
+
+	if status == lifecycle.StatusConfirmed || status == lifecycle.StatusActive {
+		if !h.ensureClientNotBlocked(c, order.ClientId) {
+			return nil, false
+		}
+	}
+
+	return order, true
+}
+
+func (h *Handler) applyOrderStatus(c *gin.Context, orderId, status, comment string) {
+	resp, err := h.services.OrderService().ChangeStatus(
+		c.Request.Context(),
+		&order_service.ChangeOrderStatus{
+			Id:        orderId,
+			Status:    status,
+			ChangedBy: h.getAuthUserID(c),
+			Comment:   comment,
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+	h.decorateChangedOrder(c, resp)
+	h.publishOrderStatus(resp, status)
+
+	h.handleResponse(c, http.OK, resp)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/config"
 	"Projects/Car24/car24_api_gateway/genproto/client_service"
 	"Projects/Car24/car24_api_gateway/pkg/helper"
 	"errors"
@@ -25,9 +26,7 @@ import (
 func (h *Handler) CreateUserOTP(c *gin.Context) {
 	var request *client_service.CreateOTP
 
-	err := c.ShouldBindJSON(&request)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &request) {
 		return
 	}
 
@@ -95,7 +94,8 @@ func (h *Handler) VerifyUserOTP(c *gin.Context) {
 
 	// exists
 	data := map[string]interface{}{
-		"id": user.Id,
+		"id":   user.Id,
+		"role": config.RoleClient,
 	}
 	token, err := helper.GenerateJWT(data, time.Minute*10, h.cfg.SecretKey)
 	if err != nil {
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/cursor"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultSort orders the lists that ask for no sort, the newest first
+var defaultSort = query.Sort{Field: "created_at", Desc: true}
+
+// page is the slice of a list a request asks for
+type page struct {
+	Offset int64
+	Limit  int64
+	// position is the row a cursor continues from, the first page without a cursor
+	position cursor.Position
+	query    string
+}
+
+// getPage reads the page either from a cursor or from offset and limit, a limit given together
+// with a cursor changes the size of the following pages
+func (h *Handler) getPage(c *gin.Context) (page, bool) {
+	var (
+		values = c.Request.URL.Query()
+		result = page{query: cursor.Fingerprint(values)}
+	)
+
+	token := c.Query("cursor")
+	if token != "" {
+		if _, ok := values["offset"]; ok {
+			h.handleResponse(c, http.InvalidArgument, "cursor and offset cannot be used together")
+			return result, false
+		}
+
+		position, err := cursor.Decode(h.cfg.SecretKey, token)
+		if err == nil && position.Query != result.query {
+			err = cursor.ErrQueryChanged
+		}
+		if err != nil {
+			h.handleResponse(c, http.InvalidArgument, err.Error())
+			return result, false
+		}
+		result.position, result.Limit = position, position.Limit
+	} else {
+		offset, err := h.getOffsetParam(c)
+		if err != nil {
+			h.handleResponse(c, http.InvalidArgument, err.Error())
+			return result, false
+		}
+		result.Offset = int64(offset)
+	}
+
+	if _, ok := values["limit"]; ok || token == "" {
+		limit, err := h.getLimitParam(c)
+		if err != nil {
+			h.handleResponse(c, http.InvalidArgument, err.Error())
+			return result, false
+		}
+		result.Limit = int64(limit)
+	}
+
+	return result, true
+}
+
+// FetchLimit is the number of rows to ask the backend for, the row after the page tells whether
+// there is a next page
+func (p page) FetchLimit() int64 {
+	return p.Limit + 1
+}
+
+// orderKeyset is the condition of the order service lists that continues from the cursor
+func (p page) orderKeyset() *order_service.Keyset {
+	if p.position.First() {
+		return nil
+	}
+	return &order_service.Keyset{Values: p.position.Values, Id: p.position.ID, Before: p.position.Before}
+}
+
+// clientKeyset is orderKeyset for the client service lists
+func (p page) clientKeyset() *client_service.Keyset {
+	if p.position.First() {
+		return nil
+	}
+	return &client_service.Keyset{Values: p.position.Values, Id: p.position.ID, Before: p.position.Before}
+}
+
+// keysetSort is the sort of a list with the id last, so that every row has its own place the cursors
+// can point to. The id follows the direction of the last field, the backend compares the keys as one row
+func keysetSort(sorts []query.Sort) []query.Sort {
+	if len(sorts) == 0 {
+		sorts = []query.Sort{defaultSort}
+	}
+	return append(sorts, query.Sort{Field: "id", Desc: sorts[len(sorts)-1].Desc})
+}
+
+// sortFields are the fields of the sort without the id, the ones the keyset has values of
+func sortFields(sorts []query.Sort) []string {
+	fields := make([]string, 0, len(sorts))
+	for _, sort := range sorts {
+		if sort.Field != "id" {
+			fields = append(fields, sort.Field)
+		}
+	}
+	return fields
+}
+
+// withSortFields adds the sort fields to the fields the gateway reads, the cursors need their values
+func withSortFields(required []string, sorts []query.Sort) []string {
+	return append(append([]string(nil), required...), sortFields(sorts)...)
+}
+
+// pageRows drops the row fetched past the page and returns the cursors of the pages around it, it sets
+// the RFC 8288 Link header
+func pageRows[T proto.Message](h *Handler, c *gin.Context, current page, sorts []query.Sort, rows []T) ([]T, string, string) {
+	var (
+		next, prev string
+		position   = current.position
+		more       = int64(len(rows)) > current.Limit
+		links      []string
+	)
+
+	// a previous page is fetched backwards from its last row, the extra row is its first one
+	switch {
+	case more && position.Before:
+		rows = rows[len(rows)-int(current.Limit):]
+	case more:
+		rows = rows[:current.Limit]
+	}
+
+	cursorOf := func(row T, before bool) string {
+		values, id := cursor.Keyset(row, sortFields(sorts))
+		return cursor.Encode(h.cfg.SecretKey, cursor.Position{Values: values, ID: id, Before: before, Limit: current.Limit, Query: current.query})
+	}
+
+	if len(rows) > 0 {
+		if more || position.Before {
+			next = cursorOf(rows[len(rows)-1], false)
+			links = append(links, h.pageLink(c, next, "next"))
+		}
+
+		if (more && position.Before) || (!position.First() && !position.Before) || current.Offset > 0 {
+			prev = cursorOf(rows[0], true)
+			links = append(links, h.pageLink(c, prev, "prev"))
+		}
+	}
+
+	if !position.First() || current.Offset > 0 {
+		first := cursor.Encode(h.cfg.SecretKey, cursor.Position{Limit: current.Limit, Query: current.query})
+		links = append(links, h.pageLink(c, first, "first"))
+	}
+
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+
+	return rows, next, prev
+}
+
+func (h *Handler) pageLink(c *gin.Context, token, rel string) string {
+	link := *c.Request.URL
+	values := link.Query()
+	values.Del("offset")
+	values.Del("limit")
+	values.Set("cursor", token)
+	link.RawQuery = values.Encode()
+
+	return fmt.Sprintf("<%s>; rel=%q", link.RequestURI(), rel)
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/cursor"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func orders(ids ...string) []*order_service.Order {
+	var result []*order_service.Order
+	for _, id := range ids {
+		result = append(result, &order_service.Order{Id: id, CreatedAt: "2026-05-01 " + id})
+	}
+	return result
+}
+
+func TestPageRows(t *testing.T) {
+	h := &Handler{cfg: config.Config{SecretKey: "secret"}}
+	sorts := keysetSort(nil)
+	after := func(id string, before bool) cursor.Position {
+		return cursor.Position{Values: []string{"2026-05-01 " + id}, ID: id, Before: before, Limit: 2}
+	}
+
+	tests := []struct {
+		name     string
+		current  page
+		rows     []*order_service.Order
+		ids      []string
+		next     *cursor.Position
+		prev     *cursor.Position
+		hasFirst bool
+	}{
+		{
+			name:    "first page with more",
+			current: page{Limit: 2},
+			rows:    orders("a", "b", "c"),
+			ids:     []string{"a", "b"},
+			next:    &cursor.Position{Values: []string{"2026-05-01 b"}, ID: "b", Limit: 2},
+		},
+		{
+			name:    "only page",
+			current: page{Limit: 2},
+			rows:    orders("a"),
+			ids:     []string{"a"},
+		},
+		{
+			name:     "offset page",
+			current:  page{Offset: 4, Limit: 2},
+			rows:     orders("e", "f"),
+			ids:      []string{"e", "f"},
+			prev:     &cursor.Position{Values: []string{"2026-05-01 e"}, ID: "e", Before: true, Limit: 2},
+			hasFirst: true,
+		},
+		{
+			name:     "next page with more",
+			current:  page{Limit: 2, position: after("b", false)},
+			rows:     orders("c", "d", "e"),
+			ids:      []string{"c", "d"},
+			next:     &cursor.Position{Values: []string{"2026-05-01 d"}, ID: "d", Limit: 2},
+			prev:     &cursor.Position{Values: []string{"2026-05-01 c"}, ID: "c", Before: true, Limit: 2},
+			hasFirst: true,
+		},
+		{
+			name:     "previous page with more",
+			current:  page{Limit: 2, position: after("e", true)},
+			rows:     orders("b", "c", "d"),
+			ids:      []string{"c", "d"},
+			next:     &cursor.Position{Values: []string{"2026-05-01 d"}, ID: "d", Limit: 2},
+			prev:     &cursor.Position{Values: []string{"2026-05-01 c"}, ID: "c", Before: true, Limit: 2},
+			hasFirst: true,
+		},
+		{
+			name:     "previous page at the start",
+			current:  page{Limit: 2, position: after("c", true)},
+			rows:     orders("a", "b"),
+			ids:      []string{"a", "b"},
+			next:     &cursor.Position{Values: []string{"2026-05-01 b"}, ID: "b", Limit: 2},
+			hasFirst: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/order", nil)
+
+			rows, next, prev := pageRows(h, c, tt.current, sorts, tt.rows)
+
+			var ids []string
+			for _, row := range rows {
+				ids = append(ids, row.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.ids) {
+				t.Fatalf("rows %v, want %v", ids, tt.ids)
+			}
+
+			checkCursor(t, "next", next, tt.next)
+			checkCursor(t, "prev", prev, tt.prev)
+
+			link := c.Writer.Header().Get("Link")
+			if hasFirst := strings.Contains(link, `rel="first"`); hasFirst != tt.hasFirst {
+				t.Fatalf("first link = %v, want %v in %q", hasFirst, tt.hasFirst, link)
+			}
+		})
+	}
+}
+
+func checkCursor(t *testing.T, name, token string, want *cursor.Position) {
+	t.Helper()
+
+	if want == nil {
+		if token != "" {
+			t.Fatalf("%s cursor %q, want none", name, token)
+		}
+		return
+	}
+
+	got, err := cursor.Decode("secret", token)
+	if err != nil {
+		t.Fatalf("%s cursor: %v", name, err)
+	}
+	if got.ID != want.ID || got.Before != want.Before || got.Limit != want.Limit || len(got.Values) != 1 || got.Values[0] != want.Values[0] {
+		t.Fatalf("%s cursor %+v, want %+v", name, got, *want)
+	}
+}
+
+func TestKeysetSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		sorts []query.Sort
+		want  []query.Sort
+	}{
+		{name: "default", want: []query.Sort{{Field: "created_at", Desc: true}, {Field: "id", Desc: true}}},
+		{name: "ascending", sorts: []query.Sort{{Field: "total_price"}}, want: []query.Sort{{Field: "total_price"}, {Field: "id"}}},
+		{name: "last field decides", sorts: []query.Sort{{Field: "start_date"}, {Field: "total_price", Desc: true}},
+			want: []query.Sort{{Field: "start_date"}, {Field: "total_price", Desc: true}, {Field: "id", Desc: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keysetSort(tt.sorts); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
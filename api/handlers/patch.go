@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/patch"
+	"encoding/json"
+	"io"
+	"mime"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// mergePatchContentType is the media type of JSON Merge Patch (RFC 7396) bodies
+const mergePatchContentType = "application/merge-patch+json"
+
+var clientPatchPolicy = patch.Policy{
+	Message: (&client_service.Client{}).ProtoReflect().Descriptor(),
+	Fields: map[string][]string{
+		config.RoleClient: {
+			"first_name", "last_name", "address", "photo", "additional_phone_number",
+		},
+		config.RoleOperator: {
+			"first_name", "last_name", "address", "photo", "additional_phone_number",
+			"phone_number", "propiska", "passport_number", "passport_pinfl",
+			"driving_license_number", "driving_number_given_place", "driving_number_given_date", "driving_number_expired",
+		},
+		config.RoleAdmin: {
+			"first_name", "last_name", "address", "photo", "additional_phone_number",
+			"phone_number", "propiska", "passport_number", "passport_pinfl",
+			"driving_license_number", "driving_number_given_place", "driving_number_given_date", "driving_number_expired",
+		},
+	},
+	Hints: map[string]string{
+		"is_blocked": "use the block and unblock endpoints",
+	},
+}
+
+var orderPatchPolicy = patch.Policy{
+	Message: (&order_service.Order{}).ProtoReflect().Descriptor(),
+	Fields: map[string][]string{
+		config.RoleOperator: {
+			"car_id", "client_id", "tarif_id", "day_count", "start_date", "discount", "miliage", "mechanic_id",
+		},
+		config.RoleAdmin: {
+			"car_id", "client_id", "tarif_id", "day_count", "start_date", "discount", "miliage", "mechanic_id",
+			"total_price",
+		},
+	},
+	Hints: map[string]string{
+		"status":           "use the order status endpoints",
+		"paid_price":       "use the order payments endpoint",
+		"is_paid_date":     "use the order payments endpoint",
+		"deposit_required": "use the order deposit endpoints",
+		"deposit_status":   "use the order deposit endpoints",
+		"deposit_held":     "use the order deposit endpoints",
+		"deposit_captured": "use the order deposit endpoints",
+		"deposit_released": "use the order deposit endpoints",
+	},
+}
+
+var carPatchPolicy = patch.Policy{
+	Message: (&order_service.Car{}).ProtoReflect().Descriptor(),
+	Fields: map[string][]string{
+		config.RoleOperator: {"tarif_id", "status"},
+		config.RoleAdmin:    {"state_number", "tarif_id", "model_id", "status"},
+	},
+}
+
+// bindPatch reads the patched fields either from a JSON Merge Patch body or from models.UpdatePatch
+// and rejects the fields the caller is not allowed to change
+func (h *Handler) bindPatch(c *gin.Context, policy patch.Policy) (map[string]interface{}, bool) {
+	var data map[string]interface{}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType == mergePatchContentType {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, err.Error())
+			return nil, false
+		}
+
+		err = json.Unmarshal(body, &data)
+		if err != nil {
+			h.handleResponse(c, http.BadRequest, "merge patch body must be a JSON object: "+err.Error())
+			return nil, false
+		}
+	} else {
+		var updatePatch models.UpdatePatch
+
+		if !h.bind(c, &updatePatch) {
+			return nil, false
+		}
+		data = updatePatch.Data
+	}
+
+	if len(data) == 0 {
+		h.handleResponse(c, http.InvalidArgument, "nothing to patch")
+		return nil, false
+	}
+
+	rejected := policy.Check(h.getAuthRole(c), data)
+	if len(rejected) > 0 {
+		h.handleResponse(c, http.BadRequest, models.PatchRejected{Rejected: rejected})
+		return nil, false
+	}
+
+	return data, true
+}
+
+// checkUpdate holds a full update to the fields the caller may patch, the other fields must keep their
+// current value
+func (h *Handler) checkUpdate(c *gin.Context, policy patch.Policy, current, update proto.Message) bool {
+	rejected := policy.CheckUpdate(h.getAuthRole(c), current, update)
+	if len(rejected) > 0 {
+		h.handleResponse(c, http.BadRequest, models.PatchRejected{Rejected: rejected})
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+
+	"github.com/gin-gonic/gin"
+)
+
+var orderQuerySchema = query.Schema{
+	"status": {Type: query.TypeEnum, Values: []string{
+		lifecycle.StatusDraft, lifecycle.StatusConfirmed, lifecycle.StatusActive, lifecycle.StatusReturned,
+		lifecycle.StatusCompleted, lifecycle.StatusCancelled, lifecycle.StatusOverdue,
+	}},
+	"car_id":       {Type: query.TypeUUID},
+	"client_id":    {Type: query.TypeUUID},
+	"tarif_id":     {Type: query.TypeUUID},
+	"mechanic_id":  {Type: query.TypeUUID},
+	"start_date":   {Type: query.TypeDate, Sortable: true},
+	"created_at":   {Type: query.TypeDate, Sortable: true},
+	"total_price":  {Type: query.TypeNumber, Sortable: true},
+	"day_count":    {Type: query.TypeNumber, Sortable: true},
+	"is_paid":      {Type: query.TypeBool},
+	"order_number": {Type: query.TypeString, Sortable: true},
+}
+
+var clientQuerySchema = query.Schema{
+	"is_blocked":   {Type: query.TypeBool},
+	"phone_number": {Type: query.TypeString},
+	"first_name":   {Type: query.TypeString, Sortable: true},
+	"last_name":    {Type: query.TypeString, Sortable: true},
+	"created_at":   {Type: query.TypeDate, Sortable: true},
+}
+
+var carQuerySchema = query.Schema{
+	"status":       {Type: query.TypeBool},
+	"tarif_id":     {Type: query.TypeUUID},
+	"model_id":     {Type: query.TypeUUID},
+	"state_number": {Type: query.TypeString, Sortable: true},
+	"created_at":   {Type: query.TypeDate, Sortable: true},
+}
+
+// parseListQuery validates the filter and sort parameters of a list request against the resource schema
+func (h *Handler) parseListQuery(c *gin.Context, schema query.Schema) (*query.Query, bool) {
+	parsed, rejected := schema.Parse(c.Request.URL.Query())
+	if len(rejected) > 0 {
+		h.handleResponse(c, http.InvalidArgument, models.QueryRejected{Rejected: rejected})
+		return nil, false
+	}
+	return parsed, true
+}
+
+func orderFilters(q *query.Query) ([]*order_service.Filter, []*order_service.Sort) {
+	filters := make([]*order_service.Filter, 0, len(q.Filters))
+	for _, filter := range q.Filters {
+		filters = append(filters, &order_service.Filter{Field: filter.Field, Op: filter.Op, Values: filter.Values})
+	}
+
+	sorts := make([]*order_service.Sort, 0, len(q.Sort))
+	for _, sort := range q.Sort {
+		sorts = append(sorts, &order_service.Sort{Field: sort.Field, Desc: sort.Desc})
+	}
+
+	return filters, sorts
+}
+
+func clientFilters(q *query.Query) ([]*client_service.Filter, []*client_service.Sort) {
+	filters := make([]*client_service.Filter, 0, len(q.Filters))
+	for _, filter := range q.Filters {
+		filters = append(filters, &client_service.Filter{Field: filter.Field, Op: filter.Op, Values: filter.Values})
+	}
+
+	sorts := make([]*client_service.Sort, 0, len(q.Sort))
+	for _, sort := range q.Sort {
+		sorts = append(sorts, &client_service.Sort{Field: sort.Field, Desc: sort.Desc})
+	}
+
+	return filters, sorts
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/stream"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"encoding/json"
+	"fmt"
+	htp "net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// streamTopics are the topics the events of the handlers are pushed to, the other events are only
+// sent to the webhooks
+var streamTopics = map[string][]string{
+	webhook.OrderCreated:   {stream.TopicOrders},
+	webhook.OrderConfirmed: {stream.TopicOrders},
+	webhook.OrderPickedUp:  {stream.TopicOrders},
+	webhook.OrderReturned:  {stream.TopicOrders},
+	webhook.OrderCompleted: {stream.TopicOrders},
+	webhook.OrderCancelled: {stream.TopicOrders},
+	webhook.OrderOverdue:   {stream.TopicOrders, stream.TopicAlerts},
+	webhook.CarCreated:     {stream.TopicCars},
+	webhook.CarUpdated:     {stream.TopicCars},
+	webhook.CarDeleted:     {stream.TopicCars},
+}
+
+// streamRoleTopics are the topics each role may subscribe to, clients only receive their own orders
+var streamRoleTopics = map[string][]string{
+	config.RoleAdmin:    {stream.TopicOrders, stream.TopicCars, stream.TopicAlerts},
+	config.RoleOperator: {stream.TopicOrders, stream.TopicCars, stream.TopicAlerts},
+	config.RoleClient:   {stream.TopicOrders, stream.TopicCars},
+}
+
+// streamMessage is a message of the WebSocket that is not an event: ping and reset
+type streamMessage struct {
+	ID   string    `json:"id,omitempty"`
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+}
+
+// Events godoc
+// @ID events
+// @Router /events [GET]
+// @Summary Events
+// @Description Server-Sent Events of the topics orders, cars and alerts. A reconnecting EventSource sends Last-Event-ID and first gets the events it missed, a reset event means they are gone and the state has to be loaded again. Browsers pass the token as access_token
+// @Tags Stream
+// @Produce text/event-stream
+// @Param topics query string false "comma separated topics, by default every topic of the role"
+// @Param access_token query string false "JWT for clients that cannot send the Authorization header"
+// @Param Last-Event-ID header string false "id of the last received event, the ids of an earlier run of the gateway answer a reset"
+// @Success 200 {string} string "event stream"
+// @Response 400 {object} http.Response{data=string} "Unknown topic"
+// @Response 401 {object} http.Response{data=string} "Unauthorized"
+// @Response 403 {object} http.Response{data=string} "Topic not allowed"
+func (h *Handler) Events(c *gin.Context) {
+	filter, ok := h.streamFilter(c)
+	if !ok {
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	subscription, missed, reset := h.events.Subscribe(lastEventID, filter)
+	defer subscription.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(htp.StatusOK)
+
+	if reset {
+		fmt.Fprintf(c.Writer, "id: %s\nevent: reset\ndata: {}\n\n", subscription.Start)
+	}
+	for _, event := range missed {
+		writeServerSentEvent(c.Writer, event)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.cfg.StreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		case event, open := <-subscription.C:
+			if !open {
+				// the client fell behind, it reconnects with Last-Event-ID
+				return
+			}
+			writeServerSentEvent(c.Writer, event)
+		}
+		c.Writer.Flush()
+	}
+}
+
+// WebSocket godoc
+// @ID websocket
+// @Router /ws [GET]
+// @Summary WebSocket
+// @Description WebSocket with the events of the topics orders, cars and alerts as JSON messages, besides them the server sends ping messages and a reset message when the events after last_event_id are gone. Browsers pass the token as access_token
+// @Tags Stream
+// @Param topics query string false "comma separated topics, by default every topic of the role"
+// @Param access_token query string false "JWT for clients that cannot send the Authorization header"
+// @Param last_event_id query string false "id of the last received event"
+// @Success 101 {string} string "Switching Protocols"
+// @Response 400 {object} http.Response{data=string} "Unknown topic"
+// @Response 401 {object} http.Response{data=string} "Unauthorized"
+// @Response 403 {object} http.Response{data=string} "Topic not allowed"
+func (h *Handler) WebSocket(c *gin.Context) {
+	filter, ok := h.streamFilter(c)
+	if !ok {
+		return
+	}
+
+	server := websocket.Server{
+		// the caller is authenticated with the token, not with cookies, so any origin may connect
+		Handshake: func(*websocket.Config, *htp.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			h.serveWebSocket(conn, c.Query("last_event_id"), filter)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func (h *Handler) serveWebSocket(conn *websocket.Conn, lastEventID string, filter stream.Filter) {
+	subscription, missed, reset := h.events.Subscribe(lastEventID, filter)
+	defer subscription.Close()
+
+	// the messages of the client are not used, reading them notices when it goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var message string
+		for websocket.Message.Receive(conn, &message) == nil {
+		}
+	}()
+
+	send := func(message interface{}) bool {
+		err := websocket.JSON.Send(conn, message)
+		if err != nil {
+			h.log.Debug("websocket", logger.Error(err))
+		}
+		return err == nil
+	}
+
+	if reset && !send(streamMessage{ID: subscription.Start, Type: "reset", Time: time.Now().UTC()}) {
+		return
+	}
+	for _, event := range missed {
+		if !send(event) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.cfg.StreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case now := <-heartbeat.C:
+			if !send(streamMessage{Type: "ping", Time: now.UTC()}) {
+				return
+			}
+		case event, open := <-subscription.C:
+			if !open || !send(event) {
+				return
+			}
+		}
+	}
+}
+
+// streamFilter selects the events of the topics the caller asked for and may see, AuthMiddleware
+// already required its token
+func (h *Handler) streamFilter(c *gin.Context) (stream.Filter, bool) {
+	var (
+		role    = h.getAuthRole(c)
+		userId  = h.getAuthUserID(c)
+		allowed = streamRoleTopics[role]
+		topics  = allowed
+	)
+
+	if requested := c.Query("topics"); requested != "" {
+		topics = strings.Split(requested, ",")
+	}
+
+	selected := map[string]bool{}
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		switch {
+		case topic != stream.TopicOrders && topic != stream.TopicCars && topic != stream.TopicAlerts:
+			h.handleResponse(c, http.InvalidArgument, fmt.Sprintf("unknown topic %q, topics are orders, cars, alerts", topic))
+			return nil, false
+		case !containsString(allowed, topic):
+			h.handleResponse(c, http.Forbidden, fmt.Sprintf("role %s may not subscribe to %s", role, topic))
+			return nil, false
+		}
+		selected[topic] = true
+	}
+
+	return func(event stream.Event) bool {
+		if !selected[event.Topic] {
+			return false
+		}
+		// clients see the cars but only their own orders
+		return role != config.RoleClient || event.Topic == stream.TopicCars || (userId != "" && event.Owner == userId)
+	}, true
+}
+
+// publishStream pushes the event to the topics it belongs to
+func (h *Handler) publishStream(eventType string, data interface{}) {
+	var owner string
+	if order, ok := data.(*order_service.Order); ok {
+		owner = order.ClientId
+	}
+
+	for _, topic := range streamTopics[eventType] {
+		err := h.events.Publish(topic, eventType, owner, data)
+		if err != nil {
+			h.log.Error("stream event", logger.String("event", eventType), logger.Error(err))
+		}
+	}
+}
+
+func writeServerSentEvent(w gin.ResponseWriter, event stream.Event) {
+	data, _ := json.Marshal(event)
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/media"
+	"Projects/Car24/car24_api_gateway/pkg/transcode"
+	"context"
+	"errors"
+	"fmt"
+	htp "net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// referenceServices are the services of the reference data, the gateway caches them and lets the
+// clients cache them for as long
+var referenceServices = map[protoreflect.FullName]bool{
+	"order_service.DiscountService": true,
+	"order_service.ModelService":    true,
+	"order_service.TarifService":    true,
+}
+
+// TranscodedRoutes returns the rpcs annotated with google.api.http that have no hand written route,
+// the hand written handlers cover the flows with gateway logic like OTP, ETags or order eligibility
+func (h *Handler) TranscodedRoutes(registered gin.RoutesInfo) []transcode.Route {
+	return transcode.Missing(annotatedRoutes(), registered)
+}
+
+// annotatedRoutes reads the google.api.http annotations of the services the gateway has clients of
+func annotatedRoutes() []transcode.Route {
+	routes, err := transcode.Routes(
+		client_service.File_client_service_proto,
+		order_service.File_order_service_proto,
+		order_service.File_car_service_proto,
+		order_service.File_discount_service_proto,
+		order_service.File_mechanic_service_proto,
+		order_service.File_model_service_proto,
+		order_service.File_tarif_service_proto,
+	)
+	if err != nil {
+		panic(fmt.Sprintf("google.api.http annotations: %v", err))
+	}
+
+	return routes
+}
+
+// Transcode handles a route generated from the annotation: binds the request, calls the rpc and returns its result
+func (h *Handler) Transcode(route transcode.Route) gin.HandlerFunc {
+	success := http.OK
+	switch route.SuccessCode() {
+	case http.Created.Code:
+		success = http.Created
+	case http.NoContent.Code:
+		success = http.NoContent
+	}
+
+	cached := route.Method == htp.MethodGet && referenceServices[route.RPC.Parent().FullName()]
+
+	return func(c *gin.Context) {
+		// the reference data is read by everyone and changed in the back office
+		if route.Method != htp.MethodGet && !h.ensureStaff(c) {
+			return
+		}
+
+		request, err := route.Bind(c)
+		switch {
+		case errors.Is(err, media.ErrUnsupported):
+			h.handleResponse(c, http.UnsupportedMediaType, "the body must be application/json, application/x-protobuf or application/msgpack")
+			return
+		case errors.Is(err, transcode.ErrInvalidBody):
+			h.handleResponse(c, http.BadRequest, err.Error())
+			return
+		case err != nil:
+			h.handleResponse(c, http.InvalidArgument, err.Error())
+			return
+		}
+
+		resp, err := h.invoke(c.Request.Context(), route.RPC, request)
+		if err != nil {
+			h.handleResponse(c, http.GRPCError, err.Error())
+			return
+		}
+
+		if cached && h.cfg.ReferenceCacheTTL > 0 {
+			c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.cfg.ReferenceCacheTTL.Seconds())))
+			if h.notModified(c, resp) {
+				return
+			}
+		}
+
+		h.handleResponse(c, success, resp)
+	}
+}
+
+// invoke calls the rpc on the client of its service
+func (h *Handler) invoke(ctx context.Context, rpc protoreflect.MethodDescriptor, request proto.Message) (proto.Message, error) {
+	clients := map[protoreflect.FullName]interface{}{
+		"client_service.ClientService":  h.services.UserService(),
+		"order_service.OrderService":    h.services.OrderService(),
+		"order_service.CarService":      h.services.CarService(),
+		"order_service.DiscountService": h.services.DiscountService(),
+		"order_service.MechanicService": h.services.MechanicService(),
+		"order_service.ModelService":    h.services.ModelService(),
+		"order_service.TarifService":    h.services.TarifService(),
+	}
+
+	client, ok := clients[rpc.Parent().FullName()]
+	if !ok {
+		return nil, fmt.Errorf("no client for %s", rpc.Parent().FullName())
+	}
+
+	method := reflect.ValueOf(client).MethodByName(string(rpc.Name()))
+	if !method.IsValid() {
+		return nil, fmt.Errorf("%s has no method %s", rpc.Parent().FullName(), rpc.Name())
+	}
+
+	results := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(request)})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	resp, _ := results[0].Interface().(proto.Message)
+	return resp, nil
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/config"
 	"Projects/Car24/car24_api_gateway/genproto/client_service"
 	"Projects/Car24/car24_api_gateway/models"
 	"Projects/Car24/car24_api_gateway/pkg/helper"
 	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/validation"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
 	"context"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +16,7 @@ import (
 
 // CreateClient godoc
 // @ID create_client
-// @Router /client [POST]
+// @Router /user [POST]
 // @Summary Create Client
 // @Description  Create Client
 // @Tags Client
@@ -21,14 +24,21 @@ import (
 // @Produce json
 // @Param profile body client_service.CreateClient true "CreateClient"
 // @Success 200 {object} http.Response{data=client_service.Client} "GetClientBody"
-// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 400 {object} http.Response{data=models.ValidationFailed} "Invalid client fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) CreateClient(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
 	var user client_service.CreateClient
 
-	err := c.ShouldBindJSON(&user)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &user) {
+		return
+	}
+
+	if !h.validateClient(c, createClientValues(&user), validation.FieldPhoneNumber) {
 		return
 	}
 
@@ -41,20 +51,25 @@ func (h *Handler) CreateClient(c *gin.Context) {
 		return
 	}
 
+	h.publish(webhook.ClientCreated, resp)
 	h.handleResponse(c, http.Created, resp)
 }
 
 // GetClientByID godoc
 // @ID get_client_by_id
-// @Router /client/{id} [GET]
+// @Router /user/{id} [GET]
 // @Summary Get Client By ID
 // @Description Get Client By ID
 // @Tags Client
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param fields query string false "comma separated fields to return, e.g. id,first_name,phone_number"
+// @Param If-None-Match header string false "ETag of the version the client has"
 // @Success 200 {object} http.Response{data=client_service.Client} "Client"
+// @Response 304 {object} http.Response{data=string} "Not Modified"
 // @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) GetClientByID(c *gin.Context) {
 	userId := c.Param("id")
@@ -64,10 +79,20 @@ func (h *Handler) GetClientByID(c *gin.Context) {
 		return
 	}
 
+	if !h.ensureOwner(c, userId) {
+		return
+	}
+
+	fields, ok := h.getFields(c, clientDescriptor, "", nil)
+	if !ok {
+		return
+	}
+
 	resp, err := h.services.UserService().GetByID(
 		context.Background(),
 		&client_service.CLientPrimaryKey{
-			Id: userId,
+			Id:        userId,
+			FieldMask: backendMask(fields, clientGatewayFields),
 		},
 	)
 	if err != nil {
@@ -75,43 +100,69 @@ func (h *Handler) GetClientByID(c *gin.Context) {
 		return
 	}
 
+	if h.notModified(c, resp) {
+		return
+	}
 	h.handleResponse(c, http.OK, resp)
 }
 
 // GetClientList godoc
 // @ID get_client_list
-// @Router /client [GET]
+// @Router /user [GET]
 // @Summary Get Client List
-// @Description Get Client List
+// @Description Get Client List, filter as field=value, field=a,b or field[op]=value with op one of eq, ne, in, gt, gte, lt, lte, like
 // @Tags Client
 // @Accept json
 // @Produce json
 // @Param offset query integer false "offset"
-// @Param limit query integer false "limit"
+// @Param limit query integer false "limit, at most the configured maximum page size"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, replaces offset"
 // @Param search query string false "search"
+// @Param is_blocked query boolean false "blocked or active clients"
+// @Param phone_number query string false "phone number"
+// @Param first_name[like] query string false "first name contains"
+// @Param last_name[like] query string false "last name contains"
+// @Param created_at[gte] query string false "clients registered at or after the date"
+// @Param created_at[lte] query string false "clients registered at or before the date"
+// @Param fields query string false "comma separated fields of the clients to return, e.g. id,first_name,phone_number"
+// @Param sort query string false "sort by first_name, last_name, created_at e.g. created_at:desc, the newest first when not given"
+// @Header 200 {string} Link "next, prev and first page links"
 // @Success 200 {object} http.Response{data=client_service.GetListClientResponse} "GetAllClientResponseBody"
-// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 400 {object} http.Response{data=models.QueryRejected} "Rejected filters"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) GetClientList(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
 
-	offset, err := h.getOffsetParam(c)
-	if err != nil {
-		h.handleResponse(c, http.InvalidArgument, err.Error())
+	current, ok := h.getPage(c)
+	if !ok {
 		return
 	}
 
-	limit, err := h.getLimitParam(c)
-	if err != nil {
-		h.handleResponse(c, http.InvalidArgument, err.Error())
+	listQuery, ok := h.parseListQuery(c, clientQuerySchema)
+	if !ok {
 		return
 	}
 
+	fields, ok := h.getFields(c, clientDescriptor, "clients", nil)
+	if !ok {
+		return
+	}
+	listQuery.Sort = keysetSort(listQuery.Sort)
+	filters, sorts := clientFilters(listQuery)
+
 	resp, err := h.services.UserService().GetList(
 		context.Background(),
 		&client_service.GetListClientRequest{
-			Limit:  int64(limit),
-			Offset: int64(offset),
-			Search: c.Query("search"),
+			Limit:     current.FetchLimit(),
+			Offset:    current.Offset,
+			Keyset:    current.clientKeyset(),
+			Search:    c.Query("search"),
+			Filters:   filters,
+			Sort:      sorts,
+			FieldMask: backendMask(fields, withSortFields(clientGatewayFields, listQuery.Sort)),
 		},
 	)
 
@@ -120,20 +171,26 @@ func (h *Handler) GetClientList(c *gin.Context) {
 		return
 	}
 
+	resp.Clients, resp.NextCursor, resp.PrevCursor = pageRows(h, c, current, listQuery.Sort, resp.Clients)
+
 	h.handleResponse(c, http.OK, resp)
 }
 
 // @ID update_client
-// @Router /client/{id} [PUT]
+// @Router /user/{id} [PUT]
 // @Summary Update Client
-// @Description Update Client
+// @Description Update Client, the fields the caller role may not patch must keep their current value
 // @Tags Client
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param profile body client_service.UpdateClient true "UpdateClientRequestBody"
 // @Success 200 {object} http.Response{data=client_service.Client} "Client data"
-// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 400 {object} http.Response{data=models.ValidationFailed} "Invalid client fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpdateClient(c *gin.Context) {
 
@@ -146,48 +203,73 @@ func (h *Handler) UpdateClient(c *gin.Context) {
 		return
 	}
 
-	err := c.ShouldBindJSON(&user)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
+	if !h.bind(c, &user) {
+		return
+	}
+
+	if h.getAuthRole(c) == config.RoleClient && h.getAuthUserID(c) != user.Id {
+		h.handleResponse(c, http.Forbidden, "clients can only update their own profile")
+		return
+	}
+
+	if !h.validateClient(c, updateClientValues(&user), validation.FieldPhoneNumber) {
+		return
+	}
+
+	current, ok := h.loadCurrent(c, h.currentClient(c, user.Id))
+	if !ok {
+		return
+	}
+
+	expected, ok := h.checkIfMatch(c, current)
+	if !ok {
+		return
+	}
+
+	if !h.checkUpdate(c, clientPatchPolicy, current, &user) {
 		return
 	}
 
+	if expected != "" {
+		user.ExpectedUpdatedAt = expected
+	}
+
 	resp, err := h.services.UserService().Update(
 		c.Request.Context(),
 		&user,
 	)
 
 	if err != nil {
-		h.handleResponse(c, http.GRPCError, err.Error())
+		h.handleWriteError(c, err)
 		return
 	}
 
+	h.setETag(c, resp)
+	h.publish(webhook.ClientUpdated, resp)
 	h.handleResponse(c, http.OK, resp)
 }
 
 // PatchUser godoc
 // @ID patch_client
-// @Router /client/{id} [PATCH]
+// @Router /user/{id} [PATCH]
 // @Summary Patch Client
-// @Description Patch Client
+// @Description Patch Client fields allowed for the caller role, the body is either UpdatePatch or a JSON Merge Patch object
 // @Tags Client
-// @Accept json
+// @Accept json,application/merge-patch+json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Param profile body models.UpdatePatch true "UpdatePatchRequestBody"
 // @Success 200 {object} http.Response{data=client_service.Client} "Client data"
-// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 400 {object} http.Response{data=models.ValidationFailed} "Invalid client fields"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) UpdatePatchClient(c *gin.Context) {
 
 	var updatePatchUser models.UpdatePatch
 
-	err := c.ShouldBindJSON(&updatePatchUser)
-	if err != nil {
-		h.handleResponse(c, http.BadRequest, err.Error())
-		return
-	}
-
 	updatePatchUser.ID = c.Param("id")
 
 	if !util.IsValidUUID(updatePatchUser.ID) {
@@ -195,41 +277,76 @@ func (h *Handler) UpdatePatchClient(c *gin.Context) {
 		return
 	}
 
+	if h.getAuthRole(c) == config.RoleClient && h.getAuthUserID(c) != updatePatchUser.ID {
+		h.handleResponse(c, http.Forbidden, "clients can only patch their own profile")
+		return
+	}
+
+	data, ok := h.bindPatch(c, clientPatchPolicy)
+	if !ok {
+		return
+	}
+	updatePatchUser.Data = data
+
+	current, ok := h.loadCurrent(c, h.currentClient(c, updatePatchUser.ID))
+	if !ok {
+		return
+	}
+
+	if !h.validatePatchedClient(c, current.(*client_service.Client), updatePatchUser.Data) {
+		return
+	}
+
 	structData, err := helper.ConvertMapToStruct(updatePatchUser.Data)
 	if err != nil {
 		h.handleResponse(c, http.InvalidArgument, err.Error())
 		return
 	}
 
+	expected, ok := h.checkIfMatch(c, current)
+	if !ok {
+		return
+	}
+
 	resp, err := h.services.UserService().UpdatePatch(
 		c.Request.Context(),
 		&client_service.UpdatePatchClient{
-			Id:     updatePatchUser.ID,
-			Fields: structData,
+			Id:                updatePatchUser.ID,
+			Fields:            structData,
+			ExpectedUpdatedAt: expected,
 		},
 	)
 
 	if err != nil {
-		h.handleResponse(c, http.GRPCError, err.Error())
+		h.handleWriteError(c, err)
 		return
 	}
 
+	h.setETag(c, resp)
+	h.publish(webhook.ClientUpdated, resp)
 	h.handleResponse(c, http.OK, resp)
 }
 
 // DeleteClient godoc
 // @ID delete_client
-// @Router /client/{id} [DELETE]
+// @Router /user/{id} [DELETE]
 // @Summary Delete Client
 // @Description Delete Client
 // @Tags Client
 // @Accept json
 // @Produce json
 // @Param id path string true "id"
+// @Param If-Match header string false "ETag of the version being changed"
 // @Success 200 {object} http.Response{data=object{}} "Client data"
 // @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 412 {object} http.Response{data=string} "Resource was modified"
+// @Response 428 {object} http.Response{data=string} "If-Match is required"
 // @Failure 500 {object} http.Response{data=string} "Server Error"
 func (h *Handler) DeleteClient(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
 
 	userId := c.Param("id")
 
@@ -238,6 +355,11 @@ func (h *Handler) DeleteClient(c *gin.Context) {
 		return
 	}
 
+	// deletes are only checked by the gateway, the primary key messages carry no version
+	if _, ok := h.checkIfMatchLoading(c, h.currentClient(c, userId)); !ok {
+		return
+	}
+
 	resp, err := h.services.UserService().Delete(
 		c.Request.Context(),
 		&client_service.CLientPrimaryKey{Id: userId},
@@ -248,5 +370,6 @@ func (h *Handler) DeleteClient(c *gin.Context) {
 		return
 	}
 
+	h.publish(webhook.ClientDeleted, deletedResource{ID: userId})
 	h.handleResponse(c, http.NoContent, resp)
 }
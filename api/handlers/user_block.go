@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/eligibility"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"math"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BlockClient godoc
+// @ID block_client
+// @Router /user/{id}/block [POST]
+// @Summary Block Client
+// @Description Block the client, reason is one of unpaid_balance, damage, fraud, traffic_violations, other
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.BlockClient true "BlockClientRequestBody"
+// @Success 200 {object} http.Response{data=client_service.Client} "Client data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Client is already blocked"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) BlockClient(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+	h.changeClientBlock(c, true)
+}
+
+// UnblockClient godoc
+// @ID unblock_client
+// @Router /user/{id}/unblock [POST]
+// @Summary Unblock Client
+// @Description Unblock the client, reason is one of debt_settled, appeal, mistake, other
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param profile body models.BlockClient true "UnblockClientRequestBody"
+// @Success 200 {object} http.Response{data=client_service.Client} "Client data"
+// @Response 400 {object} http.Response{data=string} "Bad Request"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 409 {object} http.Response{data=string} "Client is not blocked"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) UnblockClient(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+	h.changeClientBlock(c, false)
+}
+
+// GetClientBlockHistory godoc
+// @ID get_client_block_history
+// @Router /user/{id}/block-history [GET]
+// @Summary Get Client Block History
+// @Description List who blocked and unblocked the client, when and why
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Param fields query string false "comma separated fields of the history items to return, e.g. action,reason"
+// @Success 200 {object} http.Response{data=client_service.GetClientBlockHistoryResponse} "ClientBlockHistory"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetClientBlockHistory(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	userId := c.Param("id")
+
+	if !util.IsValidUUID(userId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	if _, ok := h.getFields(c, blockHistoryDescriptor, "history", nil); !ok {
+		return
+	}
+
+	resp, err := h.services.UserService().GetBlockHistory(
+		c.Request.Context(),
+		&client_service.CLientPrimaryKey{Id: userId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, resp)
+}
+
+// GetBlockSuggestions godoc
+// @ID get_block_suggestions
+// @Router /user/block-suggestions [GET]
+// @Summary Get Block Suggestions
+// @Description List clients that are not blocked yet but have unpaid balances or damage claims on finished orders, the largest debts first. count is the number of clients with debts, the blocked ones among them are left out of the page
+// @Tags Client
+// @Accept json
+// @Produce json
+// @Param offset query integer false "offset"
+// @Param limit query integer false "limit, at most the configured maximum page size"
+// @Success 200 {object} http.Response{data=models.BlockSuggestions} "BlockSuggestions"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetBlockSuggestions(c *gin.Context) {
+	if !h.ensureStaff(c) {
+		return
+	}
+
+	offset, err := h.getOffsetParam(c)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	limit, err := h.getLimitParam(c)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	// the order service sums the ledgers and damage captures of the finished orders per client
+	debts, err := h.services.OrderService().GetClientDebts(
+		c.Request.Context(),
+		&order_service.GetClientDebtsRequest{
+			Statuses: []string{lifecycle.StatusReturned, lifecycle.StatusCompleted, lifecycle.StatusOverdue},
+			Offset:   int64(offset),
+			Limit:    int64(limit),
+		},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	blocked, err := h.blockedClients(c, debts.Debts)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	suggestions := []*models.BlockSuggestion{}
+	for _, debt := range debts.Debts {
+		if blocked[debt.ClientId] || (debt.OutstandingBalance <= 0 && debt.DamageClaims <= 0) {
+			continue
+		}
+
+		suggestion := &models.BlockSuggestion{
+			ClientID:           debt.ClientId,
+			OutstandingBalance: math.Max(debt.OutstandingBalance, 0),
+			DamageClaims:       debt.DamageClaims,
+			OrderIDs:           debt.OrderIds,
+		}
+		if suggestion.OutstandingBalance > 0 {
+			suggestion.Reasons = append(suggestion.Reasons, eligibility.BlockUnpaidBalance)
+		}
+		if suggestion.DamageClaims > 0 {
+			suggestion.Reasons = append(suggestion.Reasons, eligibility.BlockDamage)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	h.handleResponse(c, http.OK, models.BlockSuggestions{
+		Count:       int(debts.Count),
+		Suggestions: suggestions,
+	})
+}
+
+// blockedClients returns which clients of the debts are already blocked, with one list call
+func (h *Handler) blockedClients(c *gin.Context, debts []*order_service.ClientDebt) (map[string]bool, error) {
+	blocked := map[string]bool{}
+	if len(debts) == 0 {
+		return blocked, nil
+	}
+
+	ids := make([]string, 0, len(debts))
+	for _, debt := range debts {
+		ids = append(ids, debt.ClientId)
+	}
+
+	clients, err := h.services.UserService().GetList(
+		c.Request.Context(),
+		&client_service.GetListClientRequest{
+			Limit:   int64(len(ids)),
+			Filters: []*client_service.Filter{{Field: "id", Op: query.OpIn, Values: ids}},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, client := range clients.Clients {
+		blocked[client.Id] = client.IsBlocked
+	}
+
+	return blocked, nil
+}
+
+func (h *Handler) changeClientBlock(c *gin.Context, block bool) {
+	var body models.BlockClient
+
+	userId := c.Param("id")
+	if !util.IsValidUUID(userId) {
+		h.handleResponse(c, http.InvalidArgument, "user id is an invalid uuid")
+		return
+	}
+
+	if !h.bind(c, &body) {
+		return
+	}
+
+	reasons := eligibility.BlockReasons()
+	if !block {
+		reasons = eligibility.UnblockReasons()
+	}
+	if !eligibility.IsValidReason(body.Reason, reasons) {
+		h.handleResponse(c, http.InvalidArgument, "reason must be one of "+strings.Join(reasons, ", "))
+		return
+	}
+
+	client, err := h.services.UserService().GetByID(
+		c.Request.Context(),
+		&client_service.CLientPrimaryKey{Id: userId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	if block && client.IsBlocked {
+		h.handleResponse(c, http.Conflict, "client is already blocked")
+		return
+	}
+	if !block && !client.IsBlocked {
+		h.handleResponse(c, http.Conflict, "client is not blocked")
+		return
+	}
+
+	request := &client_service.BlockClient{
+		Id:        userId,
+		Reason:    body.Reason,
+		Comment:   body.Comment,
+		ChangedBy: h.getAuthUserID(c),
+	}
+
+	if block {
+		client, err = h.services.UserService().Block(c.Request.Context(), request)
+	} else {
+		client, err = h.services.UserService().Unblock(c.Request.Context(), request)
+	}
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	if block {
+		h.publish(webhook.ClientBlocked, client)
+	} else {
+		h.publish(webhook.ClientUnblocked, client)
+	}
+	h.handleResponse(c, http.OK, client)
+}
+
+// ensureClientNotBlocked rejects the request when the client of the order is blocked
+func (h *Handler) ensureClientNotBlocked(c *gin.Context, clientId string) bool {
+	client, err := h.services.UserService().GetByID(
+		c.Request.Context(),
+		&client_service.CLientPrimaryKey{Id: clientId},
+	)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return false
+	}
+
+	if client.IsBlocked {
+		h.handleResponse(c, http.Forbidden, "client "+clientId+" is blocked")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validateClient responds with every invalid field and returns false when the client data is rejected
+func (h *Handler) validateClient(c *gin.Context, values map[string]string, required ...string) bool {
+	errs := validation.Client(values, required...)
+	if len(errs) > 0 {
+		h.handleResponse(c, http.InvalidArgument, models.ValidationFailed{Errors: errs})
+		return false
+	}
+	return true
+}
+
+func createClientValues(client *client_service.CreateClient) map[string]string {
+	return map[string]string{
+		validation.FieldPhoneNumber:            client.PhoneNumber,
+		validation.FieldAdditionalPhoneNumber:  client.AdditionalPhoneNumber,
+		validation.FieldPassportPinfl:          client.PassportPinfl,
+		validation.FieldPassportNumber:         client.PassportNumber,
+		validation.FieldDrivingLicenseNumber:   client.DrivingLicenseNumber,
+		validation.FieldDrivingNumberGivenDate: client.DrivingNumberGivenDate,
+		validation.FieldDrivingNumberExpired:   client.DrivingNumberExpired,
+	}
+}
+
+func updateClientValues(client *client_service.UpdateClient) map[string]string {
+	return map[string]string{
+		validation.FieldPhoneNumber:            client.PhoneNumber,
+		validation.FieldAdditionalPhoneNumber:  client.AdditionalPhoneNumber,
+		validation.FieldPassportPinfl:          client.PassportPinfl,
+		validation.FieldPassportNumber:         client.PassportNumber,
+		validation.FieldDrivingLicenseNumber:   client.DrivingLicenseNumber,
+		validation.FieldDrivingNumberGivenDate: client.DrivingNumberGivenDate,
+		validation.FieldDrivingNumberExpired:   client.DrivingNumberExpired,
+	}
+}
+
+func storedClientValues(client *client_service.Client) map[string]string {
+	return map[string]string{
+		validation.FieldPhoneNumber:            client.PhoneNumber,
+		validation.FieldAdditionalPhoneNumber:  client.AdditionalPhoneNumber,
+		validation.FieldPassportPinfl:          client.PassportPinfl,
+		validation.FieldPassportNumber:         client.PassportNumber,
+		validation.FieldDrivingLicenseNumber:   client.DrivingLicenseNumber,
+		validation.FieldDrivingNumberGivenDate: client.DrivingNumberGivenDate,
+		validation.FieldDrivingNumberExpired:   client.DrivingNumberExpired,
+	}
+}
+
+// validatePatchedClient checks the patched client fields, a null removes the field like an empty string.
+// The required fields are checked on the stored client merged with the patch, the formats only on the
+// patched fields and a patch of one driving license date against the stored other one
+func (h *Handler) validatePatchedClient(c *gin.Context, current *client_service.Client, data map[string]interface{}) bool {
+	var (
+		values = map[string]string{}
+		errs   []validation.FieldError
+	)
+
+	for field, value := range data {
+		switch field {
+		case validation.FieldPhoneNumber,
+			validation.FieldAdditionalPhoneNumber,
+			validation.FieldPassportPinfl,
+			validation.FieldPassportNumber,
+			validation.FieldDrivingLicenseNumber,
+			validation.FieldDrivingNumberGivenDate,
+			validation.FieldDrivingNumberExpired:
+			if value == nil {
+				values[field] = ""
+				continue
+			}
+			str, ok := value.(string)
+			if !ok {
+				errs = append(errs, validation.FieldError{Field: field, Message: "must be a string"})
+				continue
+			}
+			values[field] = str
+		}
+	}
+
+	if len(errs) > 0 {
+		h.handleResponse(c, http.InvalidArgument, models.ValidationFailed{Errors: errs})
+		return false
+	}
+
+	merged := storedClientValues(current)
+	for field, value := range values {
+		merged[field] = value
+	}
+
+	_, hasGiven := values[validation.FieldDrivingNumberGivenDate]
+	_, hasExpired := values[validation.FieldDrivingNumberExpired]
+	if hasGiven != hasExpired {
+		values[validation.FieldDrivingNumberGivenDate] = merged[validation.FieldDrivingNumberGivenDate]
+		values[validation.FieldDrivingNumberExpired] = merged[validation.FieldDrivingNumberExpired]
+	}
+
+	errs = append(validation.Required(merged, validation.FieldPhoneNumber), validation.Client(values)...)
+	if len(errs) > 0 {
+		h.handleResponse(c, http.InvalidArgument, models.ValidationFailed{Errors: errs})
+		return false
+	}
+	return true
+}
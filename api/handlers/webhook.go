@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/billing"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// orderStatusEvents are the events sent when an order moves to the status
+var orderStatusEvents = map[string]string{
+	lifecycle.StatusConfirmed: webhook.OrderConfirmed,
+	lifecycle.StatusActive:    webhook.OrderPickedUp,
+	lifecycle.StatusReturned:  webhook.OrderReturned,
+	lifecycle.StatusOverdue:   webhook.OrderOverdue,
+	lifecycle.StatusCompleted: webhook.OrderCompleted,
+	lifecycle.StatusCancelled: webhook.OrderCancelled,
+}
+
+// depositEvents are the events sent for the deposit transactions
+var depositEvents = map[string]string{
+	billing.DepositHold:    webhook.OrderDepositHeld,
+	billing.DepositCapture: webhook.OrderDepositCaptured,
+	billing.DepositRelease: webhook.OrderDepositReleased,
+}
+
+// deletedResource is the data of the events of deleted resources
+type deletedResource struct {
+	ID string `json:"id"`
+}
+
+// CreateWebhook godoc
+// @ID create_webhook
+// @Router /webhooks [POST]
+// @Summary Create Webhook
+// @Description Subscribe an url to events, the deliveries are signed with the secret, see the X-Car24-Signature header
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param profile body models.CreateWebhook true "CreateWebhookRequestBody"
+// @Success 201 {object} http.Response{data=webhook.Subscription} "Webhook"
+// @Response 400 {object} http.Response{data=string} "Invalid Argument"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	if !h.ensureAdmin(c) {
+		return
+	}
+
+	var body models.CreateWebhook
+	if !h.bind(c, &body) {
+		return
+	}
+
+	err := webhook.Validate(body.URL, body.Events, body.Secret)
+	if err != nil {
+		h.handleResponse(c, http.InvalidArgument, err.Error())
+		return
+	}
+
+	subscription, err := h.webhooks.Subscribe(c.Request.Context(), body.URL, body.Events, body.Secret)
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Created, subscription)
+}
+
+// GetWebhookList godoc
+// @ID get_webhook_list
+// @Router /webhooks [GET]
+// @Summary Get Webhook List
+// @Description List the subscriptions without their secrets
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Success 200 {object} http.Response{data=models.WebhookList} "Webhooks"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetWebhookList(c *gin.Context) {
+	if !h.ensureAdmin(c) {
+		return
+	}
+
+	subscriptions, err := h.webhooks.Subscriptions(c.Request.Context())
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, models.WebhookList{Count: len(subscriptions), Webhooks: subscriptions})
+}
+
+// DeleteWebhook godoc
+// @ID delete_webhook
+// @Router /webhooks/{id} [DELETE]
+// @Summary Delete Webhook
+// @Description Remove the subscription, the deliveries still being retried are dropped
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 204 "No Content"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 404 {object} http.Response{data=string} "Not Found"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	if !h.ensureAdmin(c) {
+		return
+	}
+
+	err := h.webhooks.Unsubscribe(c.Request.Context(), c.Param("id"))
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		h.handleResponse(c, http.NotFound, "webhook not found")
+		return
+	case err != nil:
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.NoContent, nil)
+}
+
+// GetWebhookDeadLetters godoc
+// @ID get_webhook_dead_letters
+// @Router /webhooks/dead-letters [GET]
+// @Summary Get Webhook Dead Letters
+// @Description List the deliveries that failed every attempt, the latest first
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Success 200 {object} http.Response{data=models.WebhookDeliveryList} "Dead letters"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) GetWebhookDeadLetters(c *gin.Context) {
+	if !h.ensureAdmin(c) {
+		return
+	}
+
+	deliveries, err := h.webhooks.DeadLetters(c.Request.Context())
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.OK, models.WebhookDeliveryList{Count: len(deliveries), Deliveries: deliveries})
+}
+
+// ReplayWebhookDeadLetter godoc
+// @ID replay_webhook_dead_letter
+// @Router /webhooks/dead-letters/{id}/replay [POST]
+// @Summary Replay Webhook Dead Letter
+// @Description Send the failed delivery again, it becomes a dead letter again when every attempt fails
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 202 {object} http.Response{data=models.WebhookReplay} "Replayed"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Response 404 {object} http.Response{data=string} "Not Found"
+// @Response 409 {object} http.Response{data=string} "Subscription was removed"
+// @Response 429 {object} http.Response{data=string} "The delivery queue is full"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ReplayWebhookDeadLetter(c *gin.Context) {
+	if !h.ensureAdmin(c) {
+		return
+	}
+
+	err := h.webhooks.Replay(c.Request.Context(), c.Param("id"))
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		h.handleResponse(c, http.NotFound, "dead letter not found")
+		return
+	case errors.Is(err, webhook.ErrSubscriptionRemoved):
+		h.handleResponse(c, http.Conflict, err.Error())
+		return
+	case errors.Is(err, webhook.ErrQueueFull):
+		h.handleResponse(c, http.TooManyRequests, err.Error())
+		return
+	case err != nil:
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Accepted, models.WebhookReplay{Replayed: 1})
+}
+
+// ReplayWebhookDeadLetters godoc
+// @ID replay_webhook_dead_letters
+// @Router /webhooks/dead-letters/replay [POST]
+// @Summary Replay Webhook Dead Letters
+// @Description Send every failed delivery of the existing subscriptions again, as many as the delivery queue has room for
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Success 202 {object} http.Response{data=models.WebhookReplay} "Replayed"
+// @Response 403 {object} http.Response{data=string} "Forbidden"
+// @Failure 500 {object} http.Response{data=string} "Server Error"
+func (h *Handler) ReplayWebhookDeadLetters(c *gin.Context) {
+	if !h.ensureAdmin(c) {
+		return
+	}
+
+	replayed, err := h.webhooks.ReplayAll(c.Request.Context())
+	if err != nil {
+		h.handleResponse(c, http.GRPCError, err.Error())
+		return
+	}
+
+	h.handleResponse(c, http.Accepted, models.WebhookReplay{Replayed: replayed})
+}
+
+// ensureAdmin answers 403 unless the caller is an admin
+func (h *Handler) ensureAdmin(c *gin.Context) bool {
+	if h.getAuthRole(c) != config.RoleAdmin {
+		h.handleResponse(c, http.Forbidden, "only admins manage webhooks")
+		return false
+	}
+	return true
+}
+
+// publish sends the event to the webhooks and the streams, handlers call it after the change succeeded
+func (h *Handler) publish(eventType string, data interface{}) {
+	if h.webhooks != nil {
+		h.webhooks.Publish(eventType, data)
+	}
+	if h.events != nil {
+		h.publishStream(eventType, data)
+	}
+}
+
+// publishOrderStatus sends the event of the status the order moved to
+func (h *Handler) publishOrderStatus(order *order_service.Order, status string) {
+	if eventType, ok := orderStatusEvents[status]; ok {
+		h.publish(eventType, order)
+	}
+}
@@ -18,11 +18,21 @@ var (
 		Status:      "CREATED",
 		Description: "The request has been fulfilled and has resulted in one or more new resources being created",
 	}
+	Accepted = Status{
+		Code:        202,
+		Status:      "ACCEPTED",
+		Description: "The request has been accepted for processing, but the processing has not been completed",
+	}
 	NoContent = Status{
 		Code:        204,
 		Status:      "NO_CONTENT",
 		Description: "There is no content to send for this request, but the headers may be useful",
 	}
+	NotModified = Status{
+		Code:        304,
+		Status:      "NOT_MODIFIED",
+		Description: "The resource has not changed since the version the client has",
+	}
 	BadEnvironment = Status{
 		Code:        400,
 		Status:      "BAD_ENVIRONMENT",
@@ -48,6 +58,41 @@ var (
 		Status:      "FORBIDDEN",
 		Description: "...",
 	}
+	NotFound = Status{
+		Code:        404,
+		Status:      "NOT_FOUND",
+		Description: "The server can not find the requested resource",
+	}
+	Conflict = Status{
+		Code:        409,
+		Status:      "REQUEST_CONFLICT",
+		Description: "Requested operation resulted in conflict",
+	}
+	PreconditionFailed = Status{
+		Code:        412,
+		Status:      "PRECONDITION_FAILED",
+		Description: "The resource was modified since the version given in If-Match",
+	}
+	UnsupportedMediaType = Status{
+		Code:        415,
+		Status:      "UNSUPPORTED_MEDIA_TYPE",
+		Description: "The body is in a format the endpoint does not read",
+	}
+	UnprocessableEntity = Status{
+		Code:        422,
+		Status:      "UNPROCESSABLE_ENTITY",
+		Description: "The request was understood but its content breaks a business rule",
+	}
+	FailedDependency = Status{
+		Code:        424,
+		Status:      "FAILED_DEPENDENCY",
+		Description: "The request was not run because a request it depends on failed",
+	}
+	PreconditionRequired = Status{
+		Code:        428,
+		Status:      "PRECONDITION_REQUIRED",
+		Description: "The request must be conditional, send If-Match with the resource ETag",
+	}
 	TooManyRequests = Status{
 		Code:        429,
 		Status:      "TOO_MANY_REQUESTS",
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"Projects/Car24/car24_api_gateway/api"
+	"Projects/Car24/car24_api_gateway/api/handlers"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/grpc/client"
+	"Projects/Car24/car24_api_gateway/pkg/contract"
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"Projects/Car24/car24_api_gateway/pkg/lifecycle"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fixtures make the fake backend answer with data the gateway rules accept, so the success
+// responses of the routes are checked
+var fixtures = map[string]contract.Fixture{
+	"POST /batch":   {"method": "GET", "path": "/car", "headers": nil, "body": nil, "dependsOn": nil},
+	"POST /graphql": {"query": "{ cars(limit: 1) { count } }", "operationName": "", "variables": nil},
+
+	"POST /user":              validClient,
+	"PUT /user/{id}":          validClient,
+	"PATCH /user/{id}":        {"data": map[string]interface{}{"first_name": "Aziz"}},
+	"POST /user/{id}/block":   {"reason": "damage"},
+	"POST /user/{id}/unblock": {"reason": "appeal", "is_blocked": true},
+
+	"PATCH /order/{id}": {"data": map[string]interface{}{"day_count": 2}},
+	"PATCH /car/{id}":   {"data": map[string]interface{}{"status": true}},
+
+	"POST /order/{id}/confirm":         {"status": lifecycle.StatusDraft},
+	"POST /order/{id}/cancel":          {"status": lifecycle.StatusDraft},
+	"POST /order/{id}/pickup":          {"status": lifecycle.StatusConfirmed},
+	"POST /order/{id}/return":          {"status": lifecycle.StatusActive, "type": "pickup", "price_per_day": "100000"},
+	"POST /order/{id}/overdue":         {"status": lifecycle.StatusActive, "start_date": time.Now().AddDate(0, 0, -10).Format(helper.DateTimeLayout)},
+	"POST /order/{id}/complete":        {"status": lifecycle.StatusReturned},
+	"POST /order/{id}/payments":        {"status": lifecycle.StatusActive, "total_price": 100.0, "paid_price": 0.0, "amount": 10.0, "method": "cash"},
+	"POST /order/{id}/deposit/hold":    {"status": lifecycle.StatusConfirmed, "deposit_held": 0.0, "amount": 5000000.0, "method": "cash"},
+	"POST /order/{id}/deposit/capture": {"status": lifecycle.StatusReturned, "deposit_held": 100.0, "deposit_captured": 0.0, "amount": 10.0, "reason": "damage"},
+	"POST /order/{id}/deposit/release": {"status": lifecycle.StatusReturned, "deposit_held": 100.0, "deposit_captured": 0.0},
+
+	"POST /webhooks": {"url": "http://127.0.0.1:9/webhook", "events": []string{webhook.OrderPaid}, "secret": "0123456789abcdef"},
+}
+
+// validClient passes the document checks of the client requests
+var validClient = contract.Fixture{
+	"passport_number":        "AA1234567",
+	"passport_pinfl":         "10000000000007",
+	"driving_license_number": "AF1234567",
+}
+
+// contract calls every route of the OpenAPI document with sample requests against a fake of the
+// backend services and fails when a request or a response does not match the document
+func main() {
+	gin.SetMode(gin.ReleaseMode)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	backend := contract.NewBackend()
+	go func() {
+		_ = backend.Serve(listener)
+	}()
+	defer backend.Stop()
+
+	cfg := config.Load()
+	cfg.UserServiceHost, cfg.UserServicePort = listener.Addr().String(), ""
+	cfg.OrderServiceHost, cfg.OrderServicePort = listener.Addr().String(), ""
+
+	services, err := client.NewGrpcClients(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	r := gin.New()
+	api.SetUpAPI(r, handlers.NewHandler(cfg, logger.NewLogger("contract", logger.LevelError), services), cfg)
+
+	// admins may call every route, the webhooks are only managed by them
+	token, err := helper.GenerateJWT(map[string]interface{}{"id": contract.SampleID, "role": config.RoleAdmin}, time.Hour, cfg.SecretKey)
+	if err != nil {
+		panic(err)
+	}
+
+	runner := contract.Runner{
+		Doc:      handlers.OpenAPIDocument(r.Routes()),
+		Handler:  r,
+		Backend:  backend,
+		Fixtures: fixtures,
+		Header:   http.Header{"Authorization": {"Bearer " + token}},
+	}
+
+	var failed, skipped int
+	for _, result := range runner.Run() {
+		switch {
+		case len(result.Violations) > 0:
+			failed++
+			fmt.Printf("FAIL %s %d\n\t%s\n", result.Route, result.Status, strings.Join(result.Violations, "\n\t"))
+		case result.Skipped != "":
+			skipped++
+			fmt.Printf("SKIP %s %d %s\n", result.Route, result.Status, result.Skipped)
+		default:
+			fmt.Printf("ok   %s %d\n", result.Route, result.Status)
+		}
+	}
+
+	fmt.Printf("%d failed, %d not checked\n", failed, skipped)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
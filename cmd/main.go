@@ -7,11 +7,21 @@ import (
 	"Projects/Car24/car24_api_gateway/grpc/client"
 	"Projects/Car24/car24_api_gateway/pkg/logger"
 
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownTimeout is how long the requests in flight may take after a stop signal
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	cfg := config.Load()
 
@@ -21,6 +31,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	grpcSvcs = client.NewCachedClients(grpcSvcs, cfg)
 
 	var loggerLevel = new(string)
 
@@ -51,12 +62,26 @@ func main() {
 	r.Use(gin.Logger(), gin.Recovery())
 
 	h := handlers.NewHandler(cfg, log, grpcSvcs)
+	defer h.Close()
 
 	api.SetUpAPI(r, h, cfg)
 
 	fmt.Println("Start api gateway....")
 
-	if err := r.Run(cfg.ServicePort); err != nil {
-		return
+	server := &http.Server{Addr: cfg.ServicePort, Handler: r}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("listen", logger.Error(err))
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error("shutdown", logger.Error(err))
 	}
 }
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"Projects/Car24/car24_api_gateway/api"
+	"Projects/Car24/car24_api_gateway/api/handlers"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openapi registers the routes of the gateway without connecting to the services, fails when a
+// registered route is missing from the OpenAPI document and writes the document with -o
+func main() {
+	output := flag.String("o", "", "file to write the OpenAPI document to")
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+
+	cfg := config.Load()
+	log := logger.NewLogger("openapi", logger.LevelError)
+
+	r := gin.New()
+	api.SetUpAPI(r, handlers.NewHandler(cfg, log, nil), cfg)
+
+	doc := handlers.OpenAPIDocument(r.Routes())
+
+	missing := openapi.Missing(doc, r.Routes(), handlers.Endpoints())
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "routes missing from the OpenAPI document, annotate their handlers and add them to handlers.Endpoints:\n\t%s\n", strings.Join(missing, "\n\t"))
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	err = os.WriteFile(*output, append(data, '\n'), 0o644)
+	if err != nil {
+		panic(err)
+	}
+}
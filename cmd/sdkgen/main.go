@@ -0,0 +1,515 @@
+package main
+
+import (
+	"Projects/Car24/car24_api_gateway/api"
+	"Projects/Car24/car24_api_gateway/api/handlers"
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// module is the import path the schema packages are found under
+const module = "Projects/Car24/car24_api_gateway"
+
+// packages are the Go packages of the schema components, keyed by the component prefix
+var packages = map[string]string{
+	"order_service":  module + "/genproto/order_service",
+	"client_service": module + "/genproto/client_service",
+	"models":         module + "/models",
+	"webhook":        module + "/pkg/webhook",
+}
+
+// services are the names of the SDK services, keyed by the tag of the operations
+var services = map[string]string{
+	"Order":    "Orders",
+	"Client":   "Clients",
+	"Car":      "Cars",
+	"OTP":      "Auth",
+	"Model":    "Models",
+	"Tarif":    "Tarifs",
+	"Mechanic": "Mechanics",
+	"Discount": "Discounts",
+	"Batch":    "Batch",
+	"GraphQL":  "GraphQL",
+	"Webhook":  "Webhooks",
+}
+
+// methods are the names that cannot be derived from the operationId
+var methods = map[string]string{
+	"batch":   "Run",
+	"graphql": "Query",
+}
+
+// initialisms are written in upper case in the Go names
+var initialisms = map[string]string{
+	"id":  "ID",
+	"otp": "OTP",
+	"url": "URL",
+	"jwt": "JWT",
+}
+
+// sdkgen generates the typed client in pkg/sdk from the OpenAPI document of the gateway
+func main() {
+	output := flag.String("o", "pkg/sdk/sdk_gen.go", "file to write the client to")
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+
+	cfg := config.Load()
+	log := logger.NewLogger("sdkgen", logger.LevelError)
+
+	r := gin.New()
+	api.SetUpAPI(r, handlers.NewHandler(cfg, log, nil), cfg)
+
+	g := &generator{doc: handlers.OpenAPIDocument(r.Routes()), imports: map[string]bool{}}
+	file, err := g.generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	err = os.WriteFile(*output, file, 0o644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+type generator struct {
+	doc     *openapi.Document
+	imports map[string]bool
+}
+
+type service struct {
+	Name    string
+	Tag     string
+	Methods []*method
+}
+
+type method struct {
+	Name       string
+	Summary    string
+	Verb       string
+	Route      string
+	Path       string
+	PathParams []string
+	Body       string
+	Result     string
+	// New is the type the result is allocated with when it is a pointer
+	New    string
+	Params *params
+	Pager  *pager
+}
+
+type params struct {
+	Name   string
+	Fields []field
+}
+
+type field struct {
+	Name string
+	Type string
+	Key  string
+	In   string
+	Set  string
+	Doc  string
+}
+
+type pager struct {
+	Item  string
+	Items string
+}
+
+func (g *generator) generate() ([]byte, error) {
+	byName := map[string]*service{}
+
+	for route, operations := range g.doc.Paths {
+		for verb, operation := range operations {
+			if len(operation.Tags) == 0 {
+				continue
+			}
+
+			tag := operation.Tags[0]
+			name, ok := services[tag]
+			if !ok {
+				return nil, fmt.Errorf("operation %s has the tag %s without a service, add it to services", operation.OperationID, tag)
+			}
+
+			if byName[name] == nil {
+				byName[name] = &service{Name: name, Tag: tag}
+			}
+
+			m, err := g.method(byName[name], route, strings.ToUpper(verb), operation)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(verb), route, err)
+			}
+			byName[name].Methods = append(byName[name].Methods, m)
+		}
+	}
+
+	var list []*service
+	for _, s := range byName {
+		sort.Slice(s.Methods, func(i, j int) bool { return s.Methods[i].Name < s.Methods[j].Name })
+		for i := 1; i < len(s.Methods); i++ {
+			if s.Methods[i].Name == s.Methods[i-1].Name {
+				return nil, fmt.Errorf("%s has two methods named %s, add one to methods", s.Name, s.Methods[i].Name)
+			}
+		}
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	var imports []string
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	err := sdkTemplate.Execute(&buf, map[string]interface{}{"Imports": imports, "Services": list})
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format: %w\n%s", err, buf.Bytes())
+	}
+	return file, nil
+}
+
+func (g *generator) method(s *service, route, verb string, operation *openapi.Operation) (*method, error) {
+	m := &method{
+		Name:    g.methodName(s, operation.OperationID),
+		Summary: operation.Summary,
+		Verb:    verb,
+		Route:   route,
+	}
+
+	var (
+		p     = &params{Name: s.Name + m.Name + "Params"}
+		paged = map[string]bool{}
+	)
+	for _, parameter := range operation.Parameters {
+		if parameter.In == "path" {
+			m.PathParams = append(m.PathParams, lowerFirst(goName(parameter.Name)))
+			continue
+		}
+
+		f := field{Name: goName(parameter.Name), Key: parameter.Name, In: parameter.In, Doc: parameter.Description}
+		switch kind, _ := parameter.Schema.Type.(string); kind {
+		case "integer":
+			f.Type, f.Set = "int", "setInt"
+		case "number":
+			f.Type, f.Set = "*float64", "setFloat"
+		case "boolean":
+			f.Type, f.Set = "*bool", "setBool"
+		default:
+			f.Type, f.Set = "string", "setString"
+		}
+		p.Fields = append(p.Fields, f)
+		paged[parameter.Name] = true
+	}
+	if len(p.Fields) > 0 {
+		m.Params = p
+	}
+
+	m.Path = pathExpression(route)
+	if strings.Contains(m.Path, "url.PathEscape") {
+		g.imports["net/url"] = true
+	}
+
+	if operation.RequestBody != nil {
+		media := operation.RequestBody.Content["application/json"]
+		if media == nil {
+			return nil, fmt.Errorf("the body is not JSON")
+		}
+		body, err := g.goType(media.Schema)
+		if err != nil {
+			return nil, err
+		}
+		m.Body = body
+	}
+
+	status := ""
+	for code := range operation.Responses {
+		if code != "default" && code < "300" && (status == "" || code < status) {
+			status = code
+		}
+	}
+	if response := operation.Responses[status]; response != nil && response.Content["application/json"] != nil {
+		schema := response.Content["application/json"].Schema
+		result, err := g.goType(schema)
+		if err != nil {
+			return nil, err
+		}
+		m.Result = result
+		if strings.HasPrefix(result, "*") {
+			m.New = result[1:]
+		}
+
+		if paged["offset"] && paged["limit"] && paged["cursor"] {
+			m.Pager, err = g.pager(schema)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// methodName drops the resource from the operationId of resource services, get_order_list becomes List
+func (g *generator) methodName(s *service, operationID string) string {
+	if name, ok := methods[operationID]; ok {
+		return name
+	}
+
+	var (
+		resource = strings.ToLower(s.Tag)
+		words    []string
+	)
+	for _, word := range strings.Split(operationID, "_") {
+		if s.Name == s.Tag+"s" && (word == resource || word == resource+"s") {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	if len(words) == 2 && words[0] == "get" && words[1] == "list" {
+		return "List"
+	}
+	return goName(strings.Join(words, "_"))
+}
+
+// pager finds the items of a list response, the array next to next_cursor
+func (g *generator) pager(schema *openapi.Schema) (*pager, error) {
+	response := g.resolve(schema)
+	if response == nil || response.Properties["next_cursor"] == nil {
+		return nil, fmt.Errorf("the list response has no next_cursor")
+	}
+
+	for name, property := range response.Properties {
+		if property.Items == nil {
+			continue
+		}
+		item, err := g.goType(property.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &pager{Item: item, Items: protoName(name)}, nil
+	}
+
+	return nil, fmt.Errorf("the list response has no items")
+}
+
+func (g *generator) resolve(schema *openapi.Schema) *openapi.Schema {
+	if schema.Ref == "" {
+		return schema
+	}
+	return g.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+}
+
+// goType is the Go type of the schema, components are pointers to the types of their packages
+func (g *generator) goType(schema *openapi.Schema) (string, error) {
+	if schema.Ref != "" {
+		component := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		prefix, name, _ := strings.Cut(component, ".")
+		path, ok := packages[prefix]
+		if !ok {
+			return "", fmt.Errorf("schema %s has no Go package, add it to packages", component)
+		}
+		g.imports[path] = true
+		return "*" + prefix + "." + strings.ReplaceAll(name, ".", "_"), nil
+	}
+
+	kind, _ := schema.Type.(string)
+	if kinds, ok := schema.Type.([]string); ok && len(kinds) > 0 {
+		kind = kinds[0]
+	}
+
+	switch kind {
+	case "array":
+		item, err := g.goType(schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		return "map[string]interface{}", nil
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	}
+
+	g.imports["encoding/json"] = true
+	return "json.RawMessage", nil
+}
+
+// pathExpression is the Go expression of the path with the escaped path parameters
+func pathExpression(route string) string {
+	var parts []string
+	for {
+		start := strings.Index(route, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(route, "}")
+		parts = append(parts, fmt.Sprintf("%q", route[:start]), "url.PathEscape("+lowerFirst(goName(route[start+1:end]))+")")
+		route = route[end+1:]
+	}
+	if route != "" {
+		parts = append(parts, fmt.Sprintf("%q", route))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goName is the exported Go name of a snake case, kebab case or bracketed name like start_date[gte]
+func goName(name string) string {
+	var out strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			out.WriteString(initialism)
+			continue
+		}
+		out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return out.String()
+}
+
+// protoName is the name protoc-gen-go gives the field, without initialisms
+func protoName(name string) string {
+	var out strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word != "" {
+			out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return out.String()
+}
+
+func lowerFirst(name string) string {
+	for initialism := range initialisms {
+		if strings.EqualFold(name, initialism) {
+			return strings.ToLower(name)
+		}
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+var sdkTemplate = template.Must(template.New("sdk").Parse(`// Code generated by cmd/sdkgen from the OpenAPI document of the gateway. DO NOT EDIT.
+
+package sdk
+
+import (
+	"context"
+	"net/http"
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
+)
+
+type services struct {
+	{{- range .Services}}
+	{{.Name}} *{{.Name}}Service
+	{{- end}}
+}
+
+func newServices(c *Client) services {
+	return services{
+		{{- range .Services}}
+		{{.Name}}: &{{.Name}}Service{client: c},
+		{{- end}}
+	}
+}
+{{range $s := .Services}}
+// {{$s.Name}}Service calls the {{$s.Tag}} routes
+type {{$s.Name}}Service struct {
+	client *Client
+}
+{{range $m := $s.Methods}}
+{{- with $m.Params}}
+// {{.Name}} are the parameters of {{$s.Name}}.{{$m.Name}}
+type {{.Name}} struct {
+	{{- range .Fields}}
+	{{- if .Doc}}
+	// {{.Doc}}
+	{{- end}}
+	{{.Name}} {{.Type}}
+	{{- end}}
+}
+
+func (p *{{.Name}}) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	{{- range .Fields}}
+	{{.Set}}({{if eq .In "header"}}header{{else}}query{{end}}, "{{.Key}}", p.{{.Name}})
+	{{- end}}
+	return query, header
+}
+{{end}}
+// {{$m.Name}} calls {{$m.Verb}} {{$m.Route}}{{with $m.Summary}}: {{.}}{{end}}
+func (s *{{$s.Name}}Service) {{$m.Name}}(ctx context.Context
+	{{- range $m.PathParams}}, {{.}} string{{end}}
+	{{- with $m.Body}}, body {{.}}{{end}}
+	{{- with $m.Params}}, params *{{.Name}}{{end}}) ({{with $m.Result}}{{.}}, {{end}}error) {
+	{{- if $m.Params}}
+	query, header := params.encode()
+	{{- end}}
+	{{- if $m.New}}
+	out := new({{$m.New}})
+	err := s.client.do(ctx, "{{$m.Verb}}", {{$m.Path}}, {{if $m.Params}}query, header{{else}}nil, nil{{end}}, {{if $m.Body}}body{{else}}nil{{end}}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+	{{- else if $m.Result}}
+	var out {{$m.Result}}
+	err := s.client.do(ctx, "{{$m.Verb}}", {{$m.Path}}, {{if $m.Params}}query, header{{else}}nil, nil{{end}}, {{if $m.Body}}body{{else}}nil{{end}}, &out)
+	return out, err
+	{{- else}}
+	return s.client.do(ctx, "{{$m.Verb}}", {{$m.Path}}, {{if $m.Params}}query, header{{else}}nil, nil{{end}}, {{if $m.Body}}body{{else}}nil{{end}}, nil)
+	{{- end}}
+}
+{{with $m.Pager}}
+// {{$m.Name}}All walks every item of {{$s.Name}}.{{$m.Name}}, following the cursors of the pages
+func (s *{{$s.Name}}Service) {{$m.Name}}All({{range $m.PathParams}}{{.}} string, {{end}}params *{{$m.Params.Name}}) *Pager[{{.Item}}] {
+	var current {{$m.Params.Name}}
+	if params != nil {
+		current = *params
+	}
+
+	return newPager(func(ctx context.Context, cursor string) (Page[{{.Item}}], error) {
+		if cursor != "" {
+			current.Cursor, current.Offset = cursor, 0
+		}
+
+		resp, err := s.{{$m.Name}}(ctx{{range $m.PathParams}}, {{.}}{{end}}, &current)
+		if err != nil {
+			return Page[{{.Item}}]{}, err
+		}
+		return Page[{{.Item}}]{Items: resp.{{.Items}}, Next: resp.NextCursor}, nil
+	})
+}
+{{end}}
+{{- end}}
+{{- end}}
+`))
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -17,6 +18,15 @@ const (
 	ReleaseMode = "release"
 )
 
+const (
+	// RoleAdmin can change every editable field.
+	RoleAdmin = "admin"
+	// RoleOperator works with orders and clients at the office.
+	RoleOperator = "operator"
+	// RoleClient is a customer authenticated with an OTP token.
+	RoleClient = "client"
+)
+
 type Config struct {
 	ServiceName string
 	ServiceHost string
@@ -25,6 +35,7 @@ type Config struct {
 	Environment     string // debug, test, release
 	Version         string
 	SecretKey       string
+	RequireIfMatch  bool
 
 	UserServiceHost string
 	UserServicePort string
@@ -34,6 +45,43 @@ type Config struct {
 
 	DefaultOffset    string
 	DefaultLimit     string
+	MaxPageSize      int
+
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	MaxBatchSize int
+
+	ValidateContract bool
+
+	WebhookMaxAttempts    int
+	WebhookBackoff        time.Duration
+	WebhookMaxBackoff     time.Duration
+	WebhookTimeout        time.Duration
+	WebhookMaxDeadLetters int
+	WebhookConcurrency    int
+	WebhookQueueSize      int
+	WebhookRefresh        time.Duration
+
+	StreamBufferSize int
+	StreamHeartbeat  time.Duration
+
+	ReferenceCacheTTL         time.Duration
+	ReferenceCacheSize        int
+	ReferenceCacheLoadTimeout time.Duration
+
+	CompressionMinSize int
+
+	MileageAllowancePerDay int
+	OverageRatePerKm       float64
+	RefuelFeePerPercent    float64
+	RefuelServiceFee       float64
+
+	LateGracePeriod      time.Duration
+	LateHourlyRateFactor float64
+	LateDailyCapFactor   float64
+
+	DefaultDeposit float64
 	
 	PostgresHost     string
 	PostgresPort     int
@@ -66,9 +114,55 @@ func Load() Config {
 	config.OrderServicePort = cast.ToString(getOrReturnDefaultValue("ORDER_SERVICE_PORT", ":9091"))
 
 	config.SecretKey = "hello"
+	config.RequireIfMatch = cast.ToBool(getOrReturnDefaultValue("REQUIRE_IF_MATCH", false))
 
 	config.DefaultOffset = cast.ToString(getOrReturnDefaultValue("DEFAULT_OFFSET", "0"))
 	config.DefaultLimit = cast.ToString(getOrReturnDefaultValue("DEFAULT_LIMIT", "10"))
+	config.MaxPageSize = cast.ToInt(getOrReturnDefaultValue("MAX_PAGE_SIZE", 100))
+
+	config.GraphQLMaxDepth = cast.ToInt(getOrReturnDefaultValue("GRAPHQL_MAX_DEPTH", 6))
+	config.GraphQLMaxComplexity = cast.ToInt(getOrReturnDefaultValue("GRAPHQL_MAX_COMPLEXITY", 2000))
+
+	config.MaxBatchSize = cast.ToInt(getOrReturnDefaultValue("MAX_BATCH_SIZE", 50))
+
+	// in debug mode logs the requests and responses that do not match /openapi.json
+	config.ValidateContract = cast.ToBool(getOrReturnDefaultValue("VALIDATE_CONTRACT", false))
+
+	// deliveries are retried after 10s, 20s, 40s ... at most every 10m until the attempts run out
+	config.WebhookMaxAttempts = cast.ToInt(getOrReturnDefaultValue("WEBHOOK_MAX_ATTEMPTS", 8))
+	config.WebhookBackoff = cast.ToDuration(getOrReturnDefaultValue("WEBHOOK_BACKOFF", "10s"))
+	config.WebhookMaxBackoff = cast.ToDuration(getOrReturnDefaultValue("WEBHOOK_MAX_BACKOFF", "10m"))
+	config.WebhookTimeout = cast.ToDuration(getOrReturnDefaultValue("WEBHOOK_TIMEOUT", "10s"))
+	config.WebhookMaxDeadLetters = cast.ToInt(getOrReturnDefaultValue("WEBHOOK_MAX_DEAD_LETTERS", 1000))
+	config.WebhookConcurrency = cast.ToInt(getOrReturnDefaultValue("WEBHOOK_CONCURRENCY", 16))
+	// deliveries that find WEBHOOK_QUEUE_SIZE others waiting become dead letters right away
+	config.WebhookQueueSize = cast.ToInt(getOrReturnDefaultValue("WEBHOOK_QUEUE_SIZE", 1000))
+	// the subscriptions added or removed on another replica are seen after WEBHOOK_REFRESH
+	config.WebhookRefresh = cast.ToDuration(getOrReturnDefaultValue("WEBHOOK_REFRESH", "1m"))
+
+	// reconnecting subscribers of /ws and /events get the missed events of the last STREAM_BUFFER_SIZE
+	config.StreamBufferSize = cast.ToInt(getOrReturnDefaultValue("STREAM_BUFFER_SIZE", 1000))
+	config.StreamHeartbeat = cast.ToDuration(getOrReturnDefaultValue("STREAM_HEARTBEAT", "15s"))
+
+	// models, tariffs and discounts are cached per replica, a TTL of 0 turns the cache off
+	config.ReferenceCacheTTL = cast.ToDuration(getOrReturnDefaultValue("REFERENCE_CACHE_TTL", "10m"))
+	config.ReferenceCacheSize = cast.ToInt(getOrReturnDefaultValue("REFERENCE_CACHE_SIZE", 1000))
+	// a load shared by concurrent misses does not end with the request that started it
+	config.ReferenceCacheLoadTimeout = cast.ToDuration(getOrReturnDefaultValue("REFERENCE_CACHE_LOAD_TIMEOUT", "5s"))
+
+	// smaller responses are not worth the cost of compressing them
+	config.CompressionMinSize = cast.ToInt(getOrReturnDefaultValue("COMPRESSION_MIN_SIZE", 1024))
+
+	config.MileageAllowancePerDay = cast.ToInt(getOrReturnDefaultValue("MILEAGE_ALLOWANCE_PER_DAY", 200))
+	config.OverageRatePerKm = cast.ToFloat64(getOrReturnDefaultValue("OVERAGE_RATE_PER_KM", 2000))
+	config.RefuelFeePerPercent = cast.ToFloat64(getOrReturnDefaultValue("REFUEL_FEE_PER_PERCENT", 5000))
+	config.RefuelServiceFee = cast.ToFloat64(getOrReturnDefaultValue("REFUEL_SERVICE_FEE", 30000))
+
+	config.LateGracePeriod = cast.ToDuration(getOrReturnDefaultValue("LATE_GRACE_PERIOD", "1h"))
+	config.LateHourlyRateFactor = cast.ToFloat64(getOrReturnDefaultValue("LATE_HOURLY_RATE_FACTOR", 1.5))
+	config.LateDailyCapFactor = cast.ToFloat64(getOrReturnDefaultValue("LATE_DAILY_CAP_FACTOR", 1))
+
+	config.DefaultDeposit = cast.ToFloat64(getOrReturnDefaultValue("DEFAULT_DEPOSIT", 1000000))
 
 	config.PostgresHost = cast.ToString(getOrReturnDefaultValue("POSTGRES_HOST", "0.0.0.0"))
 	config.PostgresPort = cast.ToInt(getOrReturnDefaultValue("POSTGRES_PORT", 5432))
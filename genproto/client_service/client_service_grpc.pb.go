@@ -28,6 +28,9 @@ type ClientServiceClient interface {
 	CreateUserOTP(ctx context.Context, in *CreateOTP, opts ...grpc.CallOption) (*empty.Empty, error)
 	VerifyUserOTP(ctx context.Context, in *VerifyOTP, opts ...grpc.CallOption) (*empty.Empty, error)
 	Check(ctx context.Context, in *ClientPhoneNumberReq, opts ...grpc.CallOption) (*Client, error)
+	Block(ctx context.Context, in *BlockClient, opts ...grpc.CallOption) (*Client, error)
+	Unblock(ctx context.Context, in *BlockClient, opts ...grpc.CallOption) (*Client, error)
+	GetBlockHistory(ctx context.Context, in *CLientPrimaryKey, opts ...grpc.CallOption) (*GetClientBlockHistoryResponse, error)
 }
 
 type clientServiceClient struct {
@@ -119,6 +122,33 @@ func (c *clientServiceClient) Check(ctx context.Context, in *ClientPhoneNumberRe
 	return out, nil
 }
 
+func (c *clientServiceClient) Block(ctx context.Context, in *BlockClient, opts ...grpc.CallOption) (*Client, error) {
+	out := new(Client)
+	err := c.cc.Invoke(ctx, "/client_service.ClientService/Block", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) Unblock(ctx context.Context, in *BlockClient, opts ...grpc.CallOption) (*Client, error) {
+	out := new(Client)
+	err := c.cc.Invoke(ctx, "/client_service.ClientService/Unblock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) GetBlockHistory(ctx context.Context, in *CLientPrimaryKey, opts ...grpc.CallOption) (*GetClientBlockHistoryResponse, error) {
+	out := new(GetClientBlockHistoryResponse)
+	err := c.cc.Invoke(ctx, "/client_service.ClientService/GetBlockHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClientServiceServer is the server API for ClientService service.
 // All implementations must embed UnimplementedClientServiceServer
 // for forward compatibility
@@ -132,6 +162,9 @@ type ClientServiceServer interface {
 	CreateUserOTP(context.Context, *CreateOTP) (*empty.Empty, error)
 	VerifyUserOTP(context.Context, *VerifyOTP) (*empty.Empty, error)
 	Check(context.Context, *ClientPhoneNumberReq) (*Client, error)
+	Block(context.Context, *BlockClient) (*Client, error)
+	Unblock(context.Context, *BlockClient) (*Client, error)
+	GetBlockHistory(context.Context, *CLientPrimaryKey) (*GetClientBlockHistoryResponse, error)
 	mustEmbedUnimplementedClientServiceServer()
 }
 
@@ -166,6 +199,15 @@ func (UnimplementedClientServiceServer) VerifyUserOTP(context.Context, *VerifyOT
 func (UnimplementedClientServiceServer) Check(context.Context, *ClientPhoneNumberReq) (*Client, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (UnimplementedClientServiceServer) Block(context.Context, *BlockClient) (*Client, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Block not implemented")
+}
+func (UnimplementedClientServiceServer) Unblock(context.Context, *BlockClient) (*Client, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unblock not implemented")
+}
+func (UnimplementedClientServiceServer) GetBlockHistory(context.Context, *CLientPrimaryKey) (*GetClientBlockHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHistory not implemented")
+}
 func (UnimplementedClientServiceServer) mustEmbedUnimplementedClientServiceServer() {}
 
 // UnsafeClientServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -341,6 +383,60 @@ func _ClientService_Check_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientService_Block_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockClient)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).Block(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client_service.ClientService/Block",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).Block(ctx, req.(*BlockClient))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_Unblock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockClient)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).Unblock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client_service.ClientService/Unblock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).Unblock(ctx, req.(*BlockClient))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_GetBlockHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CLientPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).GetBlockHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client_service.ClientService/GetBlockHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).GetBlockHistory(ctx, req.(*CLientPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClientService_ServiceDesc is the grpc.ServiceDesc for ClientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -384,6 +480,18 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _ClientService_Check_Handler,
 		},
+		{
+			MethodName: "Block",
+			Handler:    _ClientService_Block_Handler,
+		},
+		{
+			MethodName: "Unblock",
+			Handler:    _ClientService_Unblock_Handler,
+		},
+		{
+			MethodName: "GetBlockHistory",
+			Handler:    _ClientService_GetBlockHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "client_service.proto",
@@ -25,6 +25,20 @@ type OrderServiceClient interface {
 	Update(ctx context.Context, in *UpdateOrder, opts ...grpc.CallOption) (*Order, error)
 	UpdatePatch(ctx context.Context, in *UpdatePatchOrder, opts ...grpc.CallOption) (*Order, error)
 	Delete(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangeStatus(ctx context.Context, in *ChangeOrderStatus, opts ...grpc.CallOption) (*Order, error)
+	GetStatusHistory(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetOrderStatusHistoryResponse, error)
+	CreateInspection(ctx context.Context, in *CreateVehicleInspection, opts ...grpc.CallOption) (*VehicleInspection, error)
+	GetInspections(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetVehicleInspectionsResponse, error)
+	AddCharge(ctx context.Context, in *CreateOrderCharge, opts ...grpc.CallOption) (*Order, error)
+	Pickup(ctx context.Context, in *PickupOrder, opts ...grpc.CallOption) (*Order, error)
+	Return(ctx context.Context, in *ReturnOrder, opts ...grpc.CallOption) (*Order, error)
+	GetCharges(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetOrderChargesResponse, error)
+	CreatePayment(ctx context.Context, in *CreateOrderPayment, opts ...grpc.CallOption) (*Payment, error)
+	GetPayments(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetListPaymentResponse, error)
+	GetPaymentTotals(ctx context.Context, in *GetPaymentTotalsRequest, opts ...grpc.CallOption) (*GetPaymentTotalsResponse, error)
+	GetClientDebts(ctx context.Context, in *GetClientDebtsRequest, opts ...grpc.CallOption) (*GetClientDebtsResponse, error)
+	AddDepositTransaction(ctx context.Context, in *CreateDepositTransaction, opts ...grpc.CallOption) (*Order, error)
+	GetDepositTransactions(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetDepositTransactionsResponse, error)
 }
 
 type orderServiceClient struct {
@@ -89,6 +103,132 @@ func (c *orderServiceClient) Delete(ctx context.Context, in *OrderPrimaryKey, op
 	return out, nil
 }
 
+func (c *orderServiceClient) ChangeStatus(ctx context.Context, in *ChangeOrderStatus, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/ChangeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetStatusHistory(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetOrderStatusHistoryResponse, error) {
+	out := new(GetOrderStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetStatusHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateInspection(ctx context.Context, in *CreateVehicleInspection, opts ...grpc.CallOption) (*VehicleInspection, error) {
+	out := new(VehicleInspection)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/CreateInspection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetInspections(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetVehicleInspectionsResponse, error) {
+	out := new(GetVehicleInspectionsResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetInspections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AddCharge(ctx context.Context, in *CreateOrderCharge, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/AddCharge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Pickup(ctx context.Context, in *PickupOrder, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/Pickup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Return(ctx context.Context, in *ReturnOrder, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/Return", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetCharges(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetOrderChargesResponse, error) {
+	out := new(GetOrderChargesResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetCharges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreatePayment(ctx context.Context, in *CreateOrderPayment, opts ...grpc.CallOption) (*Payment, error) {
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/CreatePayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetPayments(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetListPaymentResponse, error) {
+	out := new(GetListPaymentResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetPayments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetPaymentTotals(ctx context.Context, in *GetPaymentTotalsRequest, opts ...grpc.CallOption) (*GetPaymentTotalsResponse, error) {
+	out := new(GetPaymentTotalsResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetPaymentTotals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetClientDebts(ctx context.Context, in *GetClientDebtsRequest, opts ...grpc.CallOption) (*GetClientDebtsResponse, error) {
+	out := new(GetClientDebtsResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetClientDebts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) AddDepositTransaction(ctx context.Context, in *CreateDepositTransaction, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/AddDepositTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetDepositTransactions(ctx context.Context, in *OrderPrimaryKey, opts ...grpc.CallOption) (*GetDepositTransactionsResponse, error) {
+	out := new(GetDepositTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/order_service.OrderService/GetDepositTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
@@ -99,6 +239,20 @@ type OrderServiceServer interface {
 	Update(context.Context, *UpdateOrder) (*Order, error)
 	UpdatePatch(context.Context, *UpdatePatchOrder) (*Order, error)
 	Delete(context.Context, *OrderPrimaryKey) (*empty.Empty, error)
+	ChangeStatus(context.Context, *ChangeOrderStatus) (*Order, error)
+	GetStatusHistory(context.Context, *OrderPrimaryKey) (*GetOrderStatusHistoryResponse, error)
+	CreateInspection(context.Context, *CreateVehicleInspection) (*VehicleInspection, error)
+	GetInspections(context.Context, *OrderPrimaryKey) (*GetVehicleInspectionsResponse, error)
+	AddCharge(context.Context, *CreateOrderCharge) (*Order, error)
+	Pickup(context.Context, *PickupOrder) (*Order, error)
+	Return(context.Context, *ReturnOrder) (*Order, error)
+	GetCharges(context.Context, *OrderPrimaryKey) (*GetOrderChargesResponse, error)
+	CreatePayment(context.Context, *CreateOrderPayment) (*Payment, error)
+	GetPayments(context.Context, *OrderPrimaryKey) (*GetListPaymentResponse, error)
+	GetPaymentTotals(context.Context, *GetPaymentTotalsRequest) (*GetPaymentTotalsResponse, error)
+	GetClientDebts(context.Context, *GetClientDebtsRequest) (*GetClientDebtsResponse, error)
+	AddDepositTransaction(context.Context, *CreateDepositTransaction) (*Order, error)
+	GetDepositTransactions(context.Context, *OrderPrimaryKey) (*GetDepositTransactionsResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -124,6 +278,48 @@ func (UnimplementedOrderServiceServer) UpdatePatch(context.Context, *UpdatePatch
 func (UnimplementedOrderServiceServer) Delete(context.Context, *OrderPrimaryKey) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedOrderServiceServer) ChangeStatus(context.Context, *ChangeOrderStatus) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeStatus not implemented")
+}
+func (UnimplementedOrderServiceServer) GetStatusHistory(context.Context, *OrderPrimaryKey) (*GetOrderStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatusHistory not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateInspection(context.Context, *CreateVehicleInspection) (*VehicleInspection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInspection not implemented")
+}
+func (UnimplementedOrderServiceServer) GetInspections(context.Context, *OrderPrimaryKey) (*GetVehicleInspectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInspections not implemented")
+}
+func (UnimplementedOrderServiceServer) AddCharge(context.Context, *CreateOrderCharge) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCharge not implemented")
+}
+func (UnimplementedOrderServiceServer) Pickup(context.Context, *PickupOrder) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pickup not implemented")
+}
+func (UnimplementedOrderServiceServer) Return(context.Context, *ReturnOrder) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Return not implemented")
+}
+func (UnimplementedOrderServiceServer) GetCharges(context.Context, *OrderPrimaryKey) (*GetOrderChargesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCharges not implemented")
+}
+func (UnimplementedOrderServiceServer) CreatePayment(context.Context, *CreateOrderPayment) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePayment not implemented")
+}
+func (UnimplementedOrderServiceServer) GetPayments(context.Context, *OrderPrimaryKey) (*GetListPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayments not implemented")
+}
+func (UnimplementedOrderServiceServer) GetPaymentTotals(context.Context, *GetPaymentTotalsRequest) (*GetPaymentTotalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentTotals not implemented")
+}
+func (UnimplementedOrderServiceServer) GetClientDebts(context.Context, *GetClientDebtsRequest) (*GetClientDebtsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientDebts not implemented")
+}
+func (UnimplementedOrderServiceServer) AddDepositTransaction(context.Context, *CreateDepositTransaction) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDepositTransaction not implemented")
+}
+func (UnimplementedOrderServiceServer) GetDepositTransactions(context.Context, *OrderPrimaryKey) (*GetDepositTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepositTransactions not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -245,6 +441,258 @@ func _OrderService_Delete_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_ChangeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeOrderStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ChangeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/ChangeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ChangeStatus(ctx, req.(*ChangeOrderStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetStatusHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetStatusHistory(ctx, req.(*OrderPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateInspection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehicleInspection)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateInspection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/CreateInspection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateInspection(ctx, req.(*CreateVehicleInspection))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetInspections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetInspections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetInspections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetInspections(ctx, req.(*OrderPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_AddCharge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderCharge)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddCharge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/AddCharge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddCharge(ctx, req.(*CreateOrderCharge))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Pickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickupOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Pickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/Pickup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Pickup(ctx, req.(*PickupOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Return_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnOrder)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Return(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/Return",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Return(ctx, req.(*ReturnOrder))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetCharges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetCharges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetCharges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetCharges(ctx, req.(*OrderPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreatePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderPayment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreatePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/CreatePayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreatePayment(ctx, req.(*CreateOrderPayment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetPayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetPayments(ctx, req.(*OrderPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetPaymentTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPaymentTotalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetPaymentTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetPaymentTotals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetPaymentTotals(ctx, req.(*GetPaymentTotalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetClientDebts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientDebtsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetClientDebts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetClientDebts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetClientDebts(ctx, req.(*GetClientDebtsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_AddDepositTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDepositTransaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddDepositTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/AddDepositTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddDepositTransaction(ctx, req.(*CreateDepositTransaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetDepositTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderPrimaryKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetDepositTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.OrderService/GetDepositTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetDepositTransactions(ctx, req.(*OrderPrimaryKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -276,6 +724,62 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _OrderService_Delete_Handler,
 		},
+		{
+			MethodName: "ChangeStatus",
+			Handler:    _OrderService_ChangeStatus_Handler,
+		},
+		{
+			MethodName: "GetStatusHistory",
+			Handler:    _OrderService_GetStatusHistory_Handler,
+		},
+		{
+			MethodName: "CreateInspection",
+			Handler:    _OrderService_CreateInspection_Handler,
+		},
+		{
+			MethodName: "GetInspections",
+			Handler:    _OrderService_GetInspections_Handler,
+		},
+		{
+			MethodName: "AddCharge",
+			Handler:    _OrderService_AddCharge_Handler,
+		},
+		{
+			MethodName: "Pickup",
+			Handler:    _OrderService_Pickup_Handler,
+		},
+		{
+			MethodName: "Return",
+			Handler:    _OrderService_Return_Handler,
+		},
+		{
+			MethodName: "GetCharges",
+			Handler:    _OrderService_GetCharges_Handler,
+		},
+		{
+			MethodName: "CreatePayment",
+			Handler:    _OrderService_CreatePayment_Handler,
+		},
+		{
+			MethodName: "GetPayments",
+			Handler:    _OrderService_GetPayments_Handler,
+		},
+		{
+			MethodName: "GetPaymentTotals",
+			Handler:    _OrderService_GetPaymentTotals_Handler,
+		},
+		{
+			MethodName: "GetClientDebts",
+			Handler:    _OrderService_GetClientDebts_Handler,
+		},
+		{
+			MethodName: "AddDepositTransaction",
+			Handler:    _OrderService_AddDepositTransaction_Handler,
+		},
+		{
+			MethodName: "GetDepositTransactions",
+			Handler:    _OrderService_GetDepositTransactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "order_service.proto",
@@ -0,0 +1,318 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package order_service
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateSubscription(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*WebhookSubscriptions, error)
+	DeleteSubscription(ctx context.Context, in *WebhookPK, opts ...grpc.CallOption) (*empty.Empty, error)
+	SaveDeadLetter(ctx context.Context, in *SaveWebhookDeadLetter, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetDeadLetters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*WebhookDeliveries, error)
+	GetDeadLetter(ctx context.Context, in *WebhookPK, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	DeleteDeadLetter(ctx context.Context, in *WebhookPK, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateSubscription(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/CreateSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetSubscriptions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*WebhookSubscriptions, error) {
+	out := new(WebhookSubscriptions)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/GetSubscriptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteSubscription(ctx context.Context, in *WebhookPK, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/DeleteSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) SaveDeadLetter(ctx context.Context, in *SaveWebhookDeadLetter, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/SaveDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetDeadLetters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*WebhookDeliveries, error) {
+	out := new(WebhookDeliveries)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/GetDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetDeadLetter(ctx context.Context, in *WebhookPK, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/GetDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteDeadLetter(ctx context.Context, in *WebhookPK, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/order_service.WebhookService/DeleteDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility
+type WebhookServiceServer interface {
+	CreateSubscription(context.Context, *WebhookSubscription) (*WebhookSubscription, error)
+	GetSubscriptions(context.Context, *empty.Empty) (*WebhookSubscriptions, error)
+	DeleteSubscription(context.Context, *WebhookPK) (*empty.Empty, error)
+	SaveDeadLetter(context.Context, *SaveWebhookDeadLetter) (*empty.Empty, error)
+	GetDeadLetters(context.Context, *empty.Empty) (*WebhookDeliveries, error)
+	GetDeadLetter(context.Context, *WebhookPK) (*WebhookDelivery, error)
+	DeleteDeadLetter(context.Context, *WebhookPK) (*empty.Empty, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
+}
+
+func (UnimplementedWebhookServiceServer) CreateSubscription(context.Context, *WebhookSubscription) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubscription not implemented")
+}
+func (UnimplementedWebhookServiceServer) GetSubscriptions(context.Context, *empty.Empty) (*WebhookSubscriptions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubscriptions not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteSubscription(context.Context, *WebhookPK) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubscription not implemented")
+}
+func (UnimplementedWebhookServiceServer) SaveDeadLetter(context.Context, *SaveWebhookDeadLetter) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDeadLetter not implemented")
+}
+func (UnimplementedWebhookServiceServer) GetDeadLetters(context.Context, *empty.Empty) (*WebhookDeliveries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetters not implemented")
+}
+func (UnimplementedWebhookServiceServer) GetDeadLetter(context.Context, *WebhookPK) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetter not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteDeadLetter(context.Context, *WebhookPK) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeadLetter not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_CreateSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscription)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/CreateSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateSubscription(ctx, req.(*WebhookSubscription))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/GetSubscriptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetSubscriptions(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookPK)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/DeleteSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteSubscription(ctx, req.(*WebhookPK))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_SaveDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveWebhookDeadLetter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).SaveDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/SaveDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).SaveDeadLetter(ctx, req.(*SaveWebhookDeadLetter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/GetDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetDeadLetters(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookPK)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/GetDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetDeadLetter(ctx, req.(*WebhookPK))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookPK)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/order_service.WebhookService/DeleteDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteDeadLetter(ctx, req.(*WebhookPK))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "order_service.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSubscription",
+			Handler:    _WebhookService_CreateSubscription_Handler,
+		},
+		{
+			MethodName: "GetSubscriptions",
+			Handler:    _WebhookService_GetSubscriptions_Handler,
+		},
+		{
+			MethodName: "DeleteSubscription",
+			Handler:    _WebhookService_DeleteSubscription_Handler,
+		},
+		{
+			MethodName: "SaveDeadLetter",
+			Handler:    _WebhookService_SaveDeadLetter_Handler,
+		},
+		{
+			MethodName: "GetDeadLetters",
+			Handler:    _WebhookService_GetDeadLetters_Handler,
+		},
+		{
+			MethodName: "GetDeadLetter",
+			Handler:    _WebhookService_GetDeadLetter_Handler,
+		},
+		{
+			MethodName: "DeleteDeadLetter",
+			Handler:    _WebhookService_DeleteDeadLetter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webhook_service.proto",
+}
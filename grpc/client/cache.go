@@ -0,0 +1,149 @@
+package client
+
+import (
+	"Projects/Car24/car24_api_gateway/config"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/cache"
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// cachedClients answers GetByID of the reference data, models, tariffs and discounts, from a cache,
+// they change a few times a month but are looked up for every order
+type cachedClients struct {
+	ServiceManagerI
+
+	models    order_service.ModelServiceClient
+	tarifs    order_service.TarifServiceClient
+	discounts order_service.DiscountServiceClient
+}
+
+// NewCachedClients wraps the clients of the reference data with read-through caches, a ttl of zero
+// turns them off. Only the changes made through the gateway invalidate the caches
+func NewCachedClients(services ServiceManagerI, cfg config.Config) ServiceManagerI {
+	return &cachedClients{
+		ServiceManagerI: services,
+		models: &cachedModelService{
+			ModelServiceClient: services.ModelService(),
+			cache:              cache.New[*order_service.Model](cfg.ReferenceCacheTTL, cfg.ReferenceCacheSize, cfg.ReferenceCacheLoadTimeout),
+		},
+		tarifs: &cachedTarifService{
+			TarifServiceClient: services.TarifService(),
+			cache:              cache.New[*order_service.Tarif](cfg.ReferenceCacheTTL, cfg.ReferenceCacheSize, cfg.ReferenceCacheLoadTimeout),
+		},
+		discounts: &cachedDiscountService{
+			DiscountServiceClient: services.DiscountService(),
+			cache:                 cache.New[*order_service.Discount](cfg.ReferenceCacheTTL, cfg.ReferenceCacheSize, cfg.ReferenceCacheLoadTimeout),
+		},
+	}
+}
+
+func (g *cachedClients) ModelService() order_service.ModelServiceClient {
+	return g.models
+}
+
+func (g *cachedClients) TarifService() order_service.TarifServiceClient {
+	return g.tarifs
+}
+
+func (g *cachedClients) DiscountService() order_service.DiscountServiceClient {
+	return g.discounts
+}
+
+// The cached messages are shared, callers get copies so they may change them
+
+type cachedModelService struct {
+	order_service.ModelServiceClient
+	cache *cache.Cache[*order_service.Model]
+}
+
+func (s *cachedModelService) Create(ctx context.Context, in *order_service.CreateModel, opts ...grpc.CallOption) (*order_service.Model, error) {
+	model, err := s.ModelServiceClient.Create(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(model.Id, proto.Clone(model).(*order_service.Model))
+	return model, nil
+}
+
+func (s *cachedModelService) GetByID(ctx context.Context, in *order_service.ModelPK, opts ...grpc.CallOption) (*order_service.Model, error) {
+	model, err := s.cache.Load(ctx, in.Id, func(ctx context.Context) (*order_service.Model, error) {
+		return s.ModelServiceClient.GetByID(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(model).(*order_service.Model), nil
+}
+
+func (s *cachedModelService) Delete(ctx context.Context, in *order_service.ModelPK, opts ...grpc.CallOption) (*empty.Empty, error) {
+	defer s.cache.Delete(in.Id)
+	return s.ModelServiceClient.Delete(ctx, in, opts...)
+}
+
+type cachedTarifService struct {
+	order_service.TarifServiceClient
+	cache *cache.Cache[*order_service.Tarif]
+}
+
+func (s *cachedTarifService) Create(ctx context.Context, in *order_service.CreateTarif, opts ...grpc.CallOption) (*order_service.Tarif, error) {
+	tarif, err := s.TarifServiceClient.Create(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(tarif.Id, proto.Clone(tarif).(*order_service.Tarif))
+	return tarif, nil
+}
+
+func (s *cachedTarifService) GetByID(ctx context.Context, in *order_service.TarifPK, opts ...grpc.CallOption) (*order_service.Tarif, error) {
+	tarif, err := s.cache.Load(ctx, in.Id, func(ctx context.Context) (*order_service.Tarif, error) {
+		return s.TarifServiceClient.GetByID(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(tarif).(*order_service.Tarif), nil
+}
+
+func (s *cachedTarifService) Delete(ctx context.Context, in *order_service.TarifPK, opts ...grpc.CallOption) (*empty.Empty, error) {
+	defer s.cache.Delete(in.Id)
+	return s.TarifServiceClient.Delete(ctx, in, opts...)
+}
+
+type cachedDiscountService struct {
+	order_service.DiscountServiceClient
+	cache *cache.Cache[*order_service.Discount]
+}
+
+func (s *cachedDiscountService) Create(ctx context.Context, in *order_service.CreateDiscount, opts ...grpc.CallOption) (*order_service.Discount, error) {
+	discount, err := s.DiscountServiceClient.Create(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(discount.Id, proto.Clone(discount).(*order_service.Discount))
+	return discount, nil
+}
+
+func (s *cachedDiscountService) GetByID(ctx context.Context, in *order_service.DiscountPK, opts ...grpc.CallOption) (*order_service.Discount, error) {
+	discount, err := s.cache.Load(ctx, in.Id, func(ctx context.Context) (*order_service.Discount, error) {
+		return s.DiscountServiceClient.GetByID(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(discount).(*order_service.Discount), nil
+}
+
+func (s *cachedDiscountService) Delete(ctx context.Context, in *order_service.DiscountPK, opts ...grpc.CallOption) (*empty.Empty, error) {
+	defer s.cache.Delete(in.Id)
+	return s.DiscountServiceClient.Delete(ctx, in, opts...)
+}
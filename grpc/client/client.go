@@ -18,6 +18,7 @@ type ServiceManagerI interface {
 	MechanicService() order_service.MechanicServiceClient
 	ModelService() order_service.ModelServiceClient
 	TarifService() order_service.TarifServiceClient
+	WebhookService() order_service.WebhookServiceClient
 }
 
 type grpcClients struct {
@@ -29,6 +30,7 @@ type grpcClients struct {
 	mechanicService order_service.MechanicServiceClient
 	modelService    order_service.ModelServiceClient
 	tarifService    order_service.TarifServiceClient
+	webhookService  order_service.WebhookServiceClient
 }
 
 func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
@@ -88,6 +90,14 @@ func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
 		return nil, err
 	}
 
+	connWebhookService, err := grpc.Dial(
+		cfg.OrderServiceHost+cfg.OrderServicePort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &grpcClients{
 		userService:     client_service.NewClientServiceClient(connUserService),
 		orderService:    order_service.NewOrderServiceClient(connOrderService),
@@ -96,6 +106,7 @@ func NewGrpcClients(cfg config.Config) (ServiceManagerI, error) {
 		mechanicService: order_service.NewMechanicServiceClient(connMechService),
 		modelService:    order_service.NewModelServiceClient(connModelService),
 		tarifService:    order_service.NewTarifServiceClient(connTarifService),
+		webhookService:  order_service.NewWebhookServiceClient(connWebhookService),
 	}, nil
 }
 
@@ -126,3 +137,7 @@ func (g *grpcClients) ModelService() order_service.ModelServiceClient {
 func (g *grpcClients) TarifService() order_service.TarifServiceClient {
 	return g.tarifService
 }
+
+func (g *grpcClients) WebhookService() order_service.WebhookServiceClient {
+	return g.webhookService
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// webhookStore keeps the webhook subscriptions and dead letters in the order service
+type webhookStore struct {
+	webhooks order_service.WebhookServiceClient
+}
+
+// NewWebhookStore stores the webhooks with the WebhookService of the backend
+func NewWebhookStore(services ServiceManagerI) webhook.Store {
+	return &webhookStore{webhooks: services.WebhookService()}
+}
+
+func (s *webhookStore) SaveSubscription(ctx context.Context, subscription *webhook.Subscription) error {
+	_, err := s.webhooks.CreateSubscription(ctx, &order_service.WebhookSubscription{
+		Id:        subscription.ID,
+		Url:       subscription.URL,
+		Events:    subscription.Events,
+		Secret:    subscription.Secret,
+		CreatedAt: formatTime(subscription.CreatedAt),
+	})
+	return err
+}
+
+func (s *webhookStore) DeleteSubscription(ctx context.Context, id string) error {
+	_, err := s.webhooks.DeleteSubscription(ctx, &order_service.WebhookPK{Id: id})
+	return notFound(err)
+}
+
+func (s *webhookStore) Subscriptions(ctx context.Context) ([]*webhook.Subscription, error) {
+	resp, err := s.webhooks.GetSubscriptions(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*webhook.Subscription, 0, len(resp.Subscriptions))
+	for _, subscription := range resp.Subscriptions {
+		subscriptions = append(subscriptions, &webhook.Subscription{
+			ID:        subscription.Id,
+			URL:       subscription.Url,
+			Events:    subscription.Events,
+			Secret:    subscription.Secret,
+			CreatedAt: parseTime(subscription.CreatedAt),
+		})
+	}
+
+	return subscriptions, nil
+}
+
+func (s *webhookStore) SaveDeadLetter(ctx context.Context, delivery *webhook.Delivery, keep int) error {
+	_, err := s.webhooks.SaveDeadLetter(ctx, &order_service.SaveWebhookDeadLetter{
+		Delivery: &order_service.WebhookDelivery{
+			Id:             delivery.ID,
+			SubscriptionId: delivery.SubscriptionID,
+			Url:            delivery.URL,
+			Event: &order_service.WebhookEvent{
+				Id:        delivery.Event.ID,
+				Type:      delivery.Event.Type,
+				CreatedAt: formatTime(delivery.Event.CreatedAt),
+				Data:      delivery.Event.Data,
+			},
+			Attempts:   int32(delivery.Attempts),
+			LastStatus: int32(delivery.LastStatus),
+			LastError:  delivery.LastError,
+			FailedAt:   formatTime(delivery.FailedAt),
+		},
+		Keep: int32(keep),
+	})
+	return err
+}
+
+func (s *webhookStore) DeadLetters(ctx context.Context) ([]*webhook.Delivery, error) {
+	resp, err := s.webhooks.GetDeadLetters(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*webhook.Delivery, 0, len(resp.Deliveries))
+	for _, delivery := range resp.Deliveries {
+		deliveries = append(deliveries, toDelivery(delivery))
+	}
+
+	return deliveries, nil
+}
+
+func (s *webhookStore) DeadLetter(ctx context.Context, id string) (*webhook.Delivery, error) {
+	delivery, err := s.webhooks.GetDeadLetter(ctx, &order_service.WebhookPK{Id: id})
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return toDelivery(delivery), nil
+}
+
+func (s *webhookStore) DeleteDeadLetter(ctx context.Context, id string) error {
+	_, err := s.webhooks.DeleteDeadLetter(ctx, &order_service.WebhookPK{Id: id})
+	return notFound(err)
+}
+
+func toDelivery(delivery *order_service.WebhookDelivery) *webhook.Delivery {
+	event := delivery.GetEvent()
+
+	// the data is stored as the JSON that was sent, anything else would break the answer
+	data := json.RawMessage(event.GetData())
+	if !json.Valid(data) {
+		data = nil
+	}
+
+	return &webhook.Delivery{
+		ID:             delivery.Id,
+		SubscriptionID: delivery.SubscriptionId,
+		URL:            delivery.Url,
+		Event: webhook.Event{
+			ID:        event.GetId(),
+			Type:      event.GetType(),
+			CreatedAt: parseTime(event.GetCreatedAt()),
+			Data:      data,
+		},
+		Attempts:   int(delivery.Attempts),
+		LastStatus: int(delivery.LastStatus),
+		LastError:  delivery.LastError,
+		FailedAt:   parseTime(delivery.FailedAt),
+	}
+}
+
+// notFound turns the NotFound answer of the backend into webhook.ErrNotFound
+func notFound(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return webhook.ErrNotFound
+	}
+	return err
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, value)
+	return t
+}
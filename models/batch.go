@@ -0,0 +1,17 @@
+package models
+
+import "encoding/json"
+
+type BatchRequest struct {
+	Method    string            `json:"method"`
+	Path      string            `json:"path"`
+	Headers   map[string]string `json:"headers"`
+	Body      json.RawMessage   `json:"body" swaggertype:"object"`
+	DependsOn []int             `json:"dependsOn"`
+}
+
+type BatchResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty" swaggertype:"object"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/expand"
+)
+
+type CarRelations struct {
+	Model *order_service.Model `json:"model,omitempty"`
+	Tarif *order_service.Tarif `json:"tarif,omitempty"`
+}
+
+type ExpandedCar struct {
+	*order_service.Car
+	Expanded     *CarRelations  `json:"expanded"`
+	ExpandErrors []expand.Error `json:"expand_errors,omitempty"`
+}
+
+type ExpandedCarList struct {
+	Count        int64          `json:"count"`
+	Cars         []*ExpandedCar `json:"cars"`
+	NextCursor   string         `json:"next_cursor,omitempty"`
+	PrevCursor   string         `json:"prev_cursor,omitempty"`
+	ExpandErrors []expand.Error `json:"expand_errors,omitempty"`
+}
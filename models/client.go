@@ -0,0 +1,25 @@
+package models
+
+import "Projects/Car24/car24_api_gateway/pkg/validation"
+
+type BlockClient struct {
+	Reason  string `json:"reason" binding:"required"`
+	Comment string `json:"comment" binding:"required"`
+}
+
+type BlockSuggestion struct {
+	ClientID           string   `json:"client_id"`
+	Reasons            []string `json:"reasons"`
+	OutstandingBalance float64  `json:"outstanding_balance"`
+	DamageClaims       float64  `json:"damage_claims"`
+	OrderIDs           []string `json:"order_ids"`
+}
+
+type BlockSuggestions struct {
+	Count       int                `json:"count"`
+	Suggestions []*BlockSuggestion `json:"suggestions"`
+}
+
+type ValidationFailed struct {
+	Errors []validation.FieldError `json:"errors"`
+}
@@ -1,6 +1,19 @@
 package models
 
+import (
+	"Projects/Car24/car24_api_gateway/pkg/patch"
+	"Projects/Car24/car24_api_gateway/pkg/query"
+)
+
 type UpdatePatch struct {
 	ID   string                 `json:"id"`
 	Data map[string]interface{} `json:"data"`
 }
+
+type PatchRejected struct {
+	Rejected []patch.Rejection `json:"rejected"`
+}
+
+type QueryRejected struct {
+	Rejected []query.Rejection `json:"rejected"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/pkg/eligibility"
+	"Projects/Car24/car24_api_gateway/pkg/expand"
+)
+
+type ChangeOrderStatus struct {
+	Comment string `json:"comment"`
+}
+
+type ChecklistItem struct {
+	Name string `json:"name" binding:"required"`
+	Ok   bool   `json:"ok"`
+	Note string `json:"note"`
+}
+
+type VehicleHandover struct {
+	Mileage   *int32          `json:"mileage" binding:"required,min=0"`
+	FuelLevel *int32          `json:"fuel_level" binding:"required,min=0,max=100"`
+	Checklist []ChecklistItem `json:"checklist" binding:"dive"`
+	Notes     string          `json:"notes"`
+	Comment   string          `json:"comment"`
+}
+
+type CreatePayment struct {
+	Amount  float64 `json:"amount" binding:"required"`
+	Method  string  `json:"method" binding:"required"`
+	Comment string  `json:"comment"`
+}
+
+type DepositHold struct {
+	Amount  float64 `json:"amount" binding:"required"`
+	Method  string  `json:"method" binding:"required"`
+	Comment string  `json:"comment"`
+}
+
+type DepositCapture struct {
+	Amount  float64 `json:"amount" binding:"required"`
+	Reason  string  `json:"reason" binding:"required"`
+	Comment string  `json:"comment"`
+}
+
+type DepositRelease struct {
+	Comment string `json:"comment"`
+}
+
+type EligibilityFailed struct {
+	ClientID string                `json:"client_id"`
+	Failures []eligibility.Failure `json:"failures"`
+}
+
+type OrderRelations struct {
+	Client   *client_service.Client  `json:"client,omitempty"`
+	Car      *order_service.Car      `json:"car,omitempty"`
+	Tarif    *order_service.Tarif    `json:"tarif,omitempty"`
+	Mechanic *order_service.Mechanic `json:"mechanic,omitempty"`
+	Discount *order_service.Discount `json:"discount,omitempty"`
+}
+
+type ExpandedOrder struct {
+	*order_service.Order
+	Expanded     *OrderRelations `json:"expanded"`
+	ExpandErrors []expand.Error  `json:"expand_errors,omitempty"`
+}
+
+type ExpandedOrderList struct {
+	Count        int64            `json:"count"`
+	Orders       []*ExpandedOrder `json:"orders"`
+	NextCursor   string           `json:"next_cursor,omitempty"`
+	PrevCursor   string           `json:"prev_cursor,omitempty"`
+	ExpandErrors []expand.Error   `json:"expand_errors,omitempty"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "Projects/Car24/car24_api_gateway/pkg/webhook"
+
+type CreateWebhook struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+	Secret string   `json:"secret" binding:"required"`
+}
+
+type WebhookList struct {
+	Count    int                     `json:"count"`
+	Webhooks []*webhook.Subscription `json:"webhooks"`
+}
+
+type WebhookDeliveryList struct {
+	Count      int                 `json:"count"`
+	Deliveries []*webhook.Delivery `json:"deliveries"`
+}
+
+type WebhookReplay struct {
+	Replayed int `json:"replayed"`
+}
@@ -0,0 +1,144 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// referencePattern matches "$2.id" or "$1.orders.0.client_id", a reference to a field of the response
+// of an earlier request, the requests are counted from 1
+var referencePattern = regexp.MustCompile(`\$(\d+)((?:\.[A-Za-z0-9_\-]+)*)`)
+
+// References returns the requests the texts refer to, counted from 1, in the order they appear
+func References(texts ...string) []int {
+	var (
+		references []int
+		seen       = map[int]bool{}
+	)
+
+	for _, text := range texts {
+		for _, match := range referencePattern.FindAllStringSubmatch(text, -1) {
+			index, err := strconv.Atoi(match[1])
+			if err != nil || seen[index] {
+				continue
+			}
+			seen[index] = true
+			references = append(references, index)
+		}
+	}
+
+	return references
+}
+
+// Results are the decoded response bodies of the requests run so far, a nil body is a request that
+// did not succeed
+type Results []interface{}
+
+// Path replaces the references in a request path, the values are escaped as path segments
+func (r Results) Path(path string) (string, error) {
+	return r.replace(path, func(value interface{}) string {
+		return url.PathEscape(text(value))
+	})
+}
+
+// Body replaces the references in the strings of a decoded JSON body, a string that is a single
+// reference takes the referenced value with its type, like a number or an object
+func (r Results) Body(body interface{}) (interface{}, error) {
+	switch body := body.(type) {
+	case string:
+		if match := referencePattern.FindStringSubmatch(body); match != nil && match[0] == body {
+			return r.lookup(match)
+		}
+		return r.replace(body, text)
+	case map[string]interface{}:
+		for key, value := range body {
+			replaced, err := r.Body(value)
+			if err != nil {
+				return nil, err
+			}
+			body[key] = replaced
+		}
+		return body, nil
+	case []interface{}:
+		for i, value := range body {
+			replaced, err := r.Body(value)
+			if err != nil {
+				return nil, err
+			}
+			body[i] = replaced
+		}
+		return body, nil
+	}
+	return body, nil
+}
+
+func (r Results) replace(value string, format func(interface{}) string) (string, error) {
+	var failed error
+
+	replaced := referencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		found, err := r.lookup(referencePattern.FindStringSubmatch(reference))
+		if err != nil {
+			failed = err
+			return reference
+		}
+		return format(found)
+	})
+
+	return replaced, failed
+}
+
+func (r Results) lookup(match []string) (interface{}, error) {
+	index, err := strconv.Atoi(match[1])
+	if err != nil || index < 1 || index > len(r) {
+		return nil, fmt.Errorf("%s refers to a request that has not run before", match[0])
+	}
+
+	value := r[index-1]
+	if value == nil {
+		return nil, fmt.Errorf("%s refers to a request that failed", match[0])
+	}
+
+	for _, segment := range strings.Split(strings.TrimPrefix(match[2], "."), ".") {
+		if segment == "" {
+			continue
+		}
+
+		switch current := value.(type) {
+		case map[string]interface{}:
+			field, ok := current[segment]
+			if !ok {
+				return nil, fmt.Errorf("%s: the response has no field %q", match[0], segment)
+			}
+			value = field
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(current) {
+				return nil, fmt.Errorf("%s: the response has no item %q", match[0], segment)
+			}
+			value = current[i]
+		default:
+			return nil, fmt.Errorf("%s: %q is not inside an object or a list", match[0], segment)
+		}
+	}
+
+	return value, nil
+}
+
+func text(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
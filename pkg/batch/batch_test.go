@@ -0,0 +1,122 @@
+package batch
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// decode reads the JSON like the batch handler, numbers stay json.Number
+func decode(t *testing.T, data string) interface{} {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestReferences(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []int
+	}{
+		{name: "none", texts: []string{"/order", `{"car_id":"c1"}`}},
+		{name: "in order of appearance", texts: []string{"/order/$2.id", `{"client_id":"$1.client_id"}`}, want: []int{2, 1}},
+		{name: "repeated", texts: []string{"$1.id/$1.car_id"}, want: []int{1}},
+		{name: "nested path", texts: []string{"$3.orders.0.client_id"}, want: []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := References(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("References = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	results := Results{
+		decode(t, `{"id":"o1","orders":[{"client_id":"c 1"}],"count":2}`),
+		nil,
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+		err  string
+	}{
+		{name: "field", path: "/order/$1.id", want: "/order/o1"},
+		{name: "escaped", path: "/user/$1.orders.0.client_id", want: "/user/c%201"},
+		{name: "number", path: "/page/$1.count", want: "/page/2"},
+		{name: "failed request", path: "/order/$2.id", err: "refers to a request that failed"},
+		{name: "not run yet", path: "/order/$3.id", err: "has not run before"},
+		{name: "zero", path: "/order/$0.id", err: "has not run before"},
+		{name: "unknown field", path: "/order/$1.nope", err: `no field "nope"`},
+		{name: "item out of range", path: "/order/$1.orders.1", err: `no item "1"`},
+		{name: "inside a scalar", path: "/order/$1.id.x", err: "is not inside an object or a list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := results.Path(tt.path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("Path = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBody(t *testing.T) {
+	results := Results{decode(t, `{"id":"o1","total":150,"car":{"id":"c1"},"tags":["a","b"]}`)}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+		err  bool
+	}{
+		{name: "keeps the type of a whole reference", body: `{"total":"$1.total"}`, want: `{"total":150}`},
+		{name: "object", body: `{"car":"$1.car"}`, want: `{"car":{"id":"c1"}}`},
+		{name: "inside a text", body: `{"note":"order $1.id of $1.total"}`, want: `{"note":"order o1 of 150"}`},
+		{name: "list in a text", body: `{"note":"tags $1.tags"}`, want: `{"note":"tags [\"a\",\"b\"]"}`},
+		{name: "nested", body: `{"items":[{"order_id":"$1.id"}]}`, want: `{"items":[{"order_id":"o1"}]}`},
+		{name: "untouched", body: `{"n":1,"ok":true,"text":"$ 5"}`, want: `{"n":1,"ok":true,"text":"$ 5"}`},
+		{name: "unknown field", body: `{"x":"$1.nope"}`, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := results.Body(decode(t, tt.body))
+			if tt.err {
+				if err == nil {
+					t.Fatal("no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, decode(t, tt.want)) {
+				encoded, _ := json.Marshal(got)
+				t.Fatalf("Body = %s, want %s", encoded, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package billing
+
+import "fmt"
+
+// Deposit transaction types
+const (
+	DepositHold    = "hold"
+	DepositCapture = "capture"
+	DepositRelease = "release"
+)
+
+// Deposit statuses
+const (
+	DepositStatusNone              = "none"
+	DepositStatusHeld              = "held"
+	DepositStatusPartiallyCaptured = "partially_captured"
+	DepositStatusCaptured          = "captured"
+	DepositStatusReleased          = "released"
+)
+
+// Capture reasons
+const (
+	CaptureDamage = "damage"
+	CaptureFine   = "fine"
+	CaptureOther  = "other"
+)
+
+// IsValidCaptureReason ...
+func IsValidCaptureReason(reason string) bool {
+	switch reason {
+	case CaptureDamage, CaptureFine, CaptureOther:
+		return true
+	}
+	return false
+}
+
+// DepositRemaining returns the part of the deposit that is still held
+func DepositRemaining(held, captured, released float64) float64 {
+	return RoundMoney(held - captured - released)
+}
+
+// DepositStatus derives the deposit state from the held, captured and released amounts
+func DepositStatus(held, captured, released float64) string {
+	remaining := DepositRemaining(held, captured, released)
+
+	switch {
+	case held == 0:
+		return DepositStatusNone
+	case remaining > 0 && captured > 0:
+		return DepositStatusPartiallyCaptured
+	case remaining > 0:
+		return DepositStatusHeld
+	case released > 0:
+		return DepositStatusReleased
+	default:
+		return DepositStatusCaptured
+	}
+}
+
+// ValidateDepositHold checks the deposit taken covers the required amount
+func ValidateDepositHold(amount, required, held float64) error {
+	if held > 0 {
+		return fmt.Errorf("deposit of %.2f is already held", held)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("deposit amount must be positive")
+	}
+	if amount < required {
+		return fmt.Errorf("deposit %.2f is lower than required %.2f", amount, required)
+	}
+	return nil
+}
+
+// ValidateDepositCapture checks a deduction fits in what is left of the deposit
+func ValidateDepositCapture(amount, remaining float64) error {
+	if amount <= 0 {
+		return fmt.Errorf("captured amount must be positive")
+	}
+	if amount > remaining {
+		return fmt.Errorf("captured amount %.2f exceeds remaining deposit %.2f", amount, remaining)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package billing
+
+import "fmt"
+
+// Charge types
+const (
+	ChargeMileageOverage = "mileage_overage"
+	ChargeRefuel         = "refuel"
+)
+
+// Charge is an amount added to the order total on top of the tariff
+type Charge struct {
+	Type        string
+	Amount      float64
+	Description string
+}
+
+// HandoverPolicy holds the rates applied when a car is returned
+type HandoverPolicy struct {
+	AllowancePerDay     int
+	OverageRatePerKm    float64
+	RefuelFeePerPercent float64
+	RefuelServiceFee    float64
+}
+
+// Distance returns the kilometres driven between pickup and return
+func Distance(pickupMileage, returnMileage int32) (int32, error) {
+	if returnMileage < pickupMileage {
+		return 0, fmt.Errorf("return mileage %d is lower than pickup mileage %d", returnMileage, pickupMileage)
+	}
+	return returnMileage - pickupMileage, nil
+}
+
+// ReturnCharges computes mileage overage and refuelling charges for a returned car
+func (p HandoverPolicy) ReturnCharges(dayCount, distance, pickupFuel, returnFuel int32) []Charge {
+	var charges []Charge
+
+	if dayCount < 1 {
+		dayCount = 1
+	}
+
+	allowance := int32(p.AllowancePerDay) * dayCount
+	if p.AllowancePerDay > 0 && distance > allowance {
+		overage := distance - allowance
+		charges = append(charges, Charge{
+			Type:        ChargeMileageOverage,
+			Amount:      float64(overage) * p.OverageRatePerKm,
+			Description: fmt.Sprintf("%d km driven over the %d km allowance", overage, allowance),
+		})
+	}
+
+	if returnFuel < pickupFuel {
+		missing := pickupFuel - returnFuel
+		charges = append(charges, Charge{
+			Type:        ChargeRefuel,
+			Amount:      float64(missing)*p.RefuelFeePerPercent + p.RefuelServiceFee,
+			Description: fmt.Sprintf("fuel returned at %d%% instead of %d%%", returnFuel, pickupFuel),
+		})
+	}
+
+	return charges
+}
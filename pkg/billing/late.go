@@ -0,0 +1,58 @@
+package billing
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ChargeLateReturn is the type of the penalty for returning a car after the due time
+const ChargeLateReturn = "late_return"
+
+// LatePolicy describes how late returns are penalised
+type LatePolicy struct {
+	GracePeriod time.Duration
+	// HourlyRateFactor multiplies the tariff hourly price (price per day / 24)
+	HourlyRateFactor float64
+	// DailyCapFactor caps the penalty of each late day at this many tariff days
+	DailyCapFactor float64
+}
+
+// DueTime returns the moment an order rented for dayCount days must be returned
+func DueTime(start time.Time, dayCount int32) time.Time {
+	return start.Add(time.Duration(dayCount) * 24 * time.Hour)
+}
+
+// IsLate reports whether a car returned at returnedAt is past the due time and the grace period
+func (p LatePolicy) IsLate(due, returnedAt time.Time) bool {
+	return returnedAt.Sub(due) > p.GracePeriod
+}
+
+// LatePenalty computes the penalty for a car returned at returnedAt, nil when it is in time
+func (p LatePolicy) LatePenalty(due, returnedAt time.Time, pricePerDay float64) *Charge {
+	if !p.IsLate(due, returnedAt) {
+		return nil
+	}
+
+	late := returnedAt.Sub(due)
+
+	hours := int(math.Ceil(late.Hours()))
+	hourlyRate := pricePerDay / 24 * p.HourlyRateFactor
+	dailyCap := pricePerDay * p.DailyCapFactor
+
+	dayPenalty := 24 * hourlyRate
+	if p.DailyCapFactor > 0 && dayPenalty > dailyCap {
+		dayPenalty = dailyCap
+	}
+
+	restPenalty := float64(hours%24) * hourlyRate
+	if p.DailyCapFactor > 0 && restPenalty > dailyCap {
+		restPenalty = dailyCap
+	}
+
+	return &Charge{
+		Type:        ChargeLateReturn,
+		Amount:      float64(hours/24)*dayPenalty + restPenalty,
+		Description: fmt.Sprintf("returned %d hours after %s", hours, due.Format("2006-01-02 15:04")),
+	}
+}
@@ -0,0 +1,44 @@
+package billing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatePenalty(t *testing.T) {
+	var (
+		policy = LatePolicy{GracePeriod: time.Hour, HourlyRateFactor: 1.5, DailyCapFactor: 1}
+		due    = time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name       string
+		returnedAt time.Time
+		late       bool
+		amount     float64
+	}{
+		{name: "early", returnedAt: due.Add(-time.Hour)},
+		{name: "within the grace period", returnedAt: due.Add(time.Hour)},
+		{name: "an hour late", returnedAt: due.Add(time.Hour + time.Minute), late: true, amount: 2 * 1.5},
+		{name: "a day late is capped", returnedAt: due.Add(24 * time.Hour), late: true, amount: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if late := policy.IsLate(due, tt.returnedAt); late != tt.late {
+				t.Fatalf("late = %v, want %v", late, tt.late)
+			}
+
+			charge := policy.LatePenalty(due, tt.returnedAt, 24)
+			if !tt.late {
+				if charge != nil {
+					t.Fatalf("charged %v for a return in time", charge.Amount)
+				}
+				return
+			}
+			if charge == nil || charge.Amount != tt.amount {
+				t.Fatalf("charge %+v, want %v", charge, tt.amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Payment methods
+const (
+	PaymentCash     = "cash"
+	PaymentCard     = "card"
+	PaymentTransfer = "transfer"
+)
+
+// minorUnits is the number of minor units in a unit of the currency the prices are in, a sum has 100 tiyin
+const minorUnits = 100
+
+// IsValidPaymentMethod ...
+func IsValidPaymentMethod(method string) bool {
+	switch method {
+	case PaymentCash, PaymentCard, PaymentTransfer:
+		return true
+	}
+	return false
+}
+
+// RoundMoney rounds the amount to the minor unit of the currency, sums of ledger entries drift below it
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*minorUnits) / minorUnits
+}
+
+// Balance returns the amount the client still owes
+func Balance(totalPrice, paidPrice float64) float64 {
+	return RoundMoney(totalPrice - paidPrice)
+}
+
+// ValidatePayment checks a ledger entry against what was already paid, refunds are negative amounts.
+// The order service checks the same when it records the entry, this check answers early
+func ValidatePayment(amount, totalPrice, paidPrice float64) error {
+	if amount == 0 {
+		return errors.New("payment amount must not be zero")
+	}
+
+	if RoundMoney(amount) != amount {
+		return errors.New("payment amount must not have fractions of a tiyin")
+	}
+
+	if amount > 0 && amount > Balance(totalPrice, paidPrice) {
+		return fmt.Errorf("payment %.2f exceeds outstanding balance %.2f", amount, Balance(totalPrice, paidPrice))
+	}
+
+	if amount < 0 && -amount > paidPrice {
+		return fmt.Errorf("refund %.2f exceeds paid amount %.2f", -amount, paidPrice)
+	}
+
+	return nil
+}
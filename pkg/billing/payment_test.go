@@ -0,0 +1,52 @@
+package billing
+
+import "testing"
+
+func TestRoundMoney(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount float64
+		want   float64
+	}{
+		{name: "whole", amount: 150, want: 150},
+		{name: "sum of ledger entries", amount: 0.1 + 0.2, want: 0.3},
+		{name: "half a tiyin up", amount: 10.005, want: 10.01},
+		{name: "below half a tiyin", amount: 10.004, want: 10},
+		{name: "refund", amount: -0.1 - 0.2, want: -0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RoundMoney(tt.amount); got != tt.want {
+				t.Fatalf("RoundMoney(%v) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePayment(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     float64
+		totalPrice float64
+		paidPrice  float64
+		valid      bool
+	}{
+		{name: "part of the balance", amount: 50, totalPrice: 150, paidPrice: 50, valid: true},
+		{name: "the whole balance", amount: 100, totalPrice: 150, paidPrice: 50, valid: true},
+		{name: "the balance after drifting sums", amount: 0.3, totalPrice: 0.6, paidPrice: 0.1 + 0.2, valid: true},
+		{name: "more than the balance", amount: 100.01, totalPrice: 150, paidPrice: 50},
+		{name: "refund of the paid amount", amount: -50, totalPrice: 150, paidPrice: 50, valid: true},
+		{name: "refund of more than was paid", amount: -50.01, totalPrice: 150, paidPrice: 50},
+		{name: "zero", amount: 0, totalPrice: 150},
+		{name: "fraction of a tiyin", amount: 0.001, totalPrice: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePayment(tt.amount, tt.totalPrice, tt.paidPrice); (err == nil) != tt.valid {
+				t.Fatalf("ValidatePayment(%v, %v, %v) = %v, want valid %v", tt.amount, tt.totalPrice, tt.paidPrice, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache keeps the values loaded by key for a while, at most size of them, the least recently used
+// are dropped first. Concurrent misses of a key share one load
+type Cache[V any] struct {
+	ttl  time.Duration
+	size int
+	// loadTimeout bounds a shared load, it does not run on the context of any one caller
+	loadTimeout time.Duration
+	group       singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	// generation changes with every invalidation so a load that started before it is not stored
+	generation uint64
+}
+
+type entry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// New creates a cache that keeps the values for ttl, a ttl of zero or a size below one disables it.
+// A load shared by concurrent misses is given at most loadTimeout
+func New[V any](ttl time.Duration, size int, loadTimeout time.Duration) *Cache[V] {
+	return &Cache[V]{
+		ttl:         ttl,
+		size:        size,
+		loadTimeout: loadTimeout,
+		entries:     map[string]*list.Element{},
+		order:       list.New(),
+	}
+}
+
+// Load returns the cached value of the key or loads it, the errors of load are not cached. The load is
+// shared by the callers missing the key at the same time, so it runs on its own context with the load
+// timeout: a caller that gives up gets the error of its ctx while the others keep waiting for the value
+func (c *Cache[V]) Load(ctx context.Context, key string, load func(context.Context) (V, error)) (V, error) {
+	if c.disabled() {
+		return load(ctx)
+	}
+
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	c.mu.Lock()
+	generation := c.generation
+	c.mu.Unlock()
+
+	results := c.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.Background(), c.loadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return value, err
+		}
+
+		c.mu.Lock()
+		if c.generation == generation {
+			c.store(key, value)
+		}
+		c.mu.Unlock()
+
+		return value, nil
+	})
+
+	var value V
+	select {
+	case result := <-results:
+		value, _ = result.Val.(V)
+		return value, result.Err
+	case <-ctx.Done():
+		return value, ctx.Err()
+	}
+}
+
+// Get returns the value of the key when it is cached and not expired
+func (c *Cache[V]) Get(key string) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return value, false
+	}
+
+	cached := element.Value.(*entry[V])
+	if time.Now().After(cached.expires) {
+		c.remove(element)
+		return value, false
+	}
+
+	c.order.MoveToFront(element)
+	return cached.value, true
+}
+
+// Set stores the value of the key, it replaces the cached one
+func (c *Cache[V]) Set(key string, value V) {
+	if c.disabled() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.store(key, value)
+}
+
+// Delete drops the value of the key, a load of it already on its way is not stored
+func (c *Cache[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.group.Forget(key)
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// TTL is how long the values are kept
+func (c *Cache[V]) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *Cache[V]) disabled() bool {
+	return c.ttl <= 0 || c.size < 1 || c.loadTimeout <= 0
+}
+
+// store adds or replaces the value and drops the least recently used ones over the size, c.mu must be held
+func (c *Cache[V]) store(key string, value V) {
+	expires := time.Now().Add(c.ttl)
+
+	if element, ok := c.entries[key]; ok {
+		cached := element.Value.(*entry[V])
+		cached.value, cached.expires = value, expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops the element, c.mu must be held
+func (c *Cache[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[V]).key)
+}
@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoadOutlivesTheCallerThatStartedIt(t *testing.T) {
+	c := New[string](time.Minute, 10, time.Second)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		close(started)
+		select {
+		case <-release:
+			return "value", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.Load(first, "key", load)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan string, 1)
+	go func() {
+		value, _ := c.Load(context.Background(), "key", func(context.Context) (string, error) {
+			return "", errors.New("the shared load was not joined")
+		})
+		second <- value
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("the cancelled caller got %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	if value := <-second; value != "value" {
+		t.Fatalf("the waiting caller got %q, want %q", value, "value")
+	}
+	if value, ok := c.Get("key"); !ok || value != "value" {
+		t.Fatalf("cached %q %v, want %q", value, ok, "value")
+	}
+}
+
+func TestLoadTimeout(t *testing.T) {
+	c := New[string](time.Minute, 10, 10*time.Millisecond)
+
+	_, err := c.Load(context.Background(), "key", func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("a failed load was cached")
+	}
+}
+
+func TestEviction(t *testing.T) {
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		size   int
+		steps  func(c *Cache[string])
+		cached []string
+		gone   []string
+	}{
+		{
+			name:   "least recently used dropped",
+			ttl:    time.Minute,
+			size:   2,
+			steps:  func(c *Cache[string]) { c.Set("a", "1"); c.Set("b", "2"); c.Get("a"); c.Set("c", "3") },
+			cached: []string{"a", "c"},
+			gone:   []string{"b"},
+		},
+		{
+			name:  "expired",
+			ttl:   time.Millisecond,
+			size:  2,
+			steps: func(c *Cache[string]) { c.Set("a", "1"); time.Sleep(5 * time.Millisecond) },
+			gone:  []string{"a"},
+		},
+		{
+			name:   "deleted",
+			ttl:    time.Minute,
+			size:   2,
+			steps:  func(c *Cache[string]) { c.Set("a", "1"); c.Set("b", "2"); c.Delete("a") },
+			cached: []string{"b"},
+			gone:   []string{"a"},
+		},
+		{
+			name:  "disabled by the ttl",
+			size:  2,
+			steps: func(c *Cache[string]) { c.Set("a", "1") },
+			gone:  []string{"a"},
+		},
+		{
+			name:  "disabled by the size",
+			ttl:   time.Minute,
+			steps: func(c *Cache[string]) { c.Set("a", "1") },
+			gone:  []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string](tt.ttl, tt.size, time.Second)
+			tt.steps(c)
+
+			for _, key := range tt.cached {
+				if _, ok := c.Get(key); !ok {
+					t.Fatalf("%s is not cached", key)
+				}
+			}
+			for _, key := range tt.gone {
+				if _, ok := c.Get(key); ok {
+					t.Fatalf("%s is still cached", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	failed := errors.New("backend down")
+
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		err    error
+		loads  int
+		cached bool
+	}{
+		{name: "loaded once", ttl: time.Minute, loads: 1, cached: true},
+		{name: "errors are not cached", ttl: time.Minute, err: failed, loads: 2},
+		{name: "disabled", loads: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string](tt.ttl, 10, time.Second)
+			loads := 0
+			load := func(context.Context) (string, error) {
+				loads++
+				return "value", tt.err
+			}
+
+			for i := 0; i < 2; i++ {
+				if _, err := c.Load(context.Background(), "key", load); !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+			}
+			if loads != tt.loads {
+				t.Fatalf("loaded %d times, want %d", loads, tt.loads)
+			}
+			if _, ok := c.Get("key"); ok != tt.cached {
+				t.Fatalf("cached %v, want %v", ok, tt.cached)
+			}
+		})
+	}
+}
+
+func TestDeleteDuringLoad(t *testing.T) {
+	c := New[string](time.Minute, 10, time.Second)
+
+	value, err := c.Load(context.Background(), "key", func(context.Context) (string, error) {
+		// the value changed while it was loaded, the loaded one is stale
+		c.Delete("key")
+		return "stale", nil
+	})
+	if err != nil || value != "stale" {
+		t.Fatalf("Load = %q, %v", value, err)
+	}
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("a load that started before the delete was cached")
+	}
+}
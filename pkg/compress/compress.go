@@ -0,0 +1,257 @@
+package compress
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// The content codings the gateway writes
+const (
+	Brotli = "br"
+	Gzip   = "gzip"
+)
+
+// preferred are the codings in the order they are chosen when the client accepts several equally,
+// brotli is smaller for JSON at a similar cost
+var preferred = []string{Brotli, Gzip}
+
+// encoder is the interface of the gzip and brotli writers
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+var pools = map[string]*sync.Pool{
+	Brotli: {New: func() interface{} { return brotli.NewWriterLevel(nil, brotli.DefaultCompression) }},
+	Gzip:   {New: func() interface{} { return gzip.NewWriter(nil) }},
+}
+
+// Negotiate returns the coding the Accept-Encoding header prefers, an empty string when the client
+// accepts none of them
+func Negotiate(acceptEncoding string) string {
+	var (
+		best        string
+		bestQuality float64
+	)
+	for _, coding := range preferred {
+		if quality := acceptQuality(acceptEncoding, coding); quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+
+	return best
+}
+
+// acceptQuality is the quality the Accept-Encoding header gives the coding, a named coding wins over *
+func acceptQuality(acceptEncoding, coding string) float64 {
+	var (
+		quality  float64
+		wildcard = true
+	)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != coding && (name != "*" || !wildcard) {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		quality = q
+		if name == coding {
+			wildcard = false
+		}
+	}
+
+	return quality
+}
+
+// Writer compresses the response once it reaches the minimum size, smaller responses are written as
+// they are. Until then the status and body are held back so the headers can still be changed
+type Writer struct {
+	gin.ResponseWriter
+
+	coding  string
+	minSize int
+
+	status    int
+	headerNow bool
+	buffer    []byte
+	decided   bool
+	hijacked  bool
+	encoder   encoder
+}
+
+// NewWriter wraps the writer of the response with the coding
+func NewWriter(w gin.ResponseWriter, coding string, minSize int) *Writer {
+	return &Writer{ResponseWriter: w, coding: coding, minSize: minSize}
+}
+
+func (w *Writer) WriteHeader(code int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *Writer) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.headerNow = true
+}
+
+func (w *Writer) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if len(w.buffer) < w.minSize {
+			return len(data), nil
+		}
+		return len(data), w.decide(true)
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *Writer) WriteString(data string) (int, error) {
+	return w.Write([]byte(data))
+}
+
+func (w *Writer) Status() int {
+	if !w.decided && w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *Writer) Written() bool {
+	if !w.decided {
+		return w.headerNow || len(w.buffer) > 0
+	}
+	return w.ResponseWriter.Written()
+}
+
+// Flush sends what was written so far, a stream flushed before it reached the minimum size is not compressed
+func (w *Writer) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *Writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.decided {
+		return nil, nil, errors.New("the response was already written")
+	}
+
+	w.decided, w.hijacked = true, true
+	return w.ResponseWriter.Hijack()
+}
+
+// Close writes what was held back and finishes the compressed stream, it must be called after the handlers
+func (w *Writer) Close() error {
+	if w.hijacked {
+		return nil
+	}
+	if !w.decided {
+		if !w.headerNow && w.status == 0 && len(w.buffer) == 0 {
+			// nothing was written, the router still answers
+			return nil
+		}
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	w.encoder.Reset(nil)
+	pools[w.coding].Put(w.encoder)
+	w.encoder = nil
+
+	return err
+}
+
+// decide writes the headers and what was held back, compressed when it is worth it
+func (w *Writer) decide(compress bool) error {
+	w.decided = true
+
+	header := w.Header()
+	header.Add("Vary", "Accept-Encoding")
+	if compress && compressible(header, w.status) {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.coding)
+		w.encoder = pools[w.coding].Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	if len(w.buffer) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return nil
+	}
+
+	buffer := w.buffer
+	w.buffer = nil
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buffer)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffer)
+	return err
+}
+
+// compressible reports whether the response may be compressed: it has a body, is not encoded yet
+// and is not a stream or an already compressed format
+func compressible(header http.Header, status int) bool {
+	if status == http.StatusNoContent || status == http.StatusNotModified || (status != 0 && status < 200) {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch {
+	case mediaType == "text/event-stream",
+		strings.HasPrefix(mediaType, "image/") && mediaType != "image/svg+xml",
+		strings.HasPrefix(mediaType, "audio/"),
+		strings.HasPrefix(mediaType, "video/"),
+		mediaType == "application/zip",
+		mediaType == "application/gzip":
+		return false
+	}
+
+	return true
+}
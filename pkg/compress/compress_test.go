@@ -0,0 +1,96 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "none", acceptEncoding: "", want: ""},
+		{name: "identity only", acceptEncoding: "identity", want: ""},
+		{name: "gzip", acceptEncoding: "gzip", want: Gzip},
+		{name: "brotli preferred when equal", acceptEncoding: "gzip, deflate, br", want: Brotli},
+		{name: "higher quality wins", acceptEncoding: "br;q=0.5, gzip;q=0.8", want: Gzip},
+		{name: "wildcard", acceptEncoding: "*", want: Brotli},
+		{name: "named coding wins over the wildcard", acceptEncoding: "*;q=0.9, br;q=0", want: Gzip},
+		{name: "refused", acceptEncoding: "gzip;q=0", want: ""},
+		{name: "case and spaces", acceptEncoding: " GZIP ; q=1 ", want: Gzip},
+		{name: "invalid quality skipped", acceptEncoding: "br;q=x, gzip", want: Gzip},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.acceptEncoding); got != tt.want {
+				t.Fatalf("Negotiate(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		status      int
+		body        string
+		compressed  bool
+	}{
+		{name: "large JSON", contentType: "application/json", status: http.StatusOK, body: strings.Repeat("a", 64), compressed: true},
+		{name: "below the minimum size", contentType: "application/json", status: http.StatusOK, body: "small"},
+		{name: "event stream", contentType: "text/event-stream", status: http.StatusOK, body: strings.Repeat("a", 64)},
+		{name: "image", contentType: "image/png", status: http.StatusOK, body: strings.Repeat("a", 64)},
+		{name: "svg", contentType: "image/svg+xml", status: http.StatusOK, body: strings.Repeat("a", 64), compressed: true},
+		{name: "no content", contentType: "application/json", status: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			writer := NewWriter(c.Writer, Gzip, 32)
+
+			writer.Header().Set("Content-Type", tt.contentType)
+			writer.WriteHeader(tt.status)
+			if _, err := writer.WriteString(tt.body); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status %d, want %d", recorder.Code, tt.status)
+			}
+			if got := recorder.Header().Get("Content-Encoding") == Gzip; got != tt.compressed {
+				t.Fatalf("compressed %v, want %v", got, tt.compressed)
+			}
+
+			body := io.Reader(recorder.Body)
+			if tt.compressed {
+				reader, err := gzip.NewReader(recorder.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = reader
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.body {
+				t.Fatalf("body %q, want %q", data, tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package contract
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Backend is a fake of the gRPC services: every rpc of the registered protos answers with a sample
+// of its result, the fixture decides the values that matter for the route being checked
+type Backend struct {
+	server *grpc.Server
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewBackend creates a fake of every service in the proto registry
+func NewBackend() *Backend {
+	b := &Backend{}
+	b.server = grpc.NewServer(grpc.UnknownServiceHandler(b.handle))
+	return b
+}
+
+// Serve answers the rpcs sent to the listener until Stop
+func (b *Backend) Serve(listener net.Listener) error {
+	return b.server.Serve(listener)
+}
+
+// Stop closes the listener and the open connections
+func (b *Backend) Stop() {
+	b.server.Stop()
+}
+
+// Use makes the following answers follow the fixture
+func (b *Backend) Use(fixture Fixture) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fixture = fixture
+}
+
+func (b *Backend) handle(_ interface{}, stream grpc.ServerStream) error {
+	name, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "no method in the stream")
+	}
+
+	// /order_service.OrderService/GetByID
+	name = strings.Replace(strings.TrimPrefix(name, "/"), "/", ".", 1)
+
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return status.Error(codes.Unimplemented, fmt.Sprintf("unknown method %s", name))
+	}
+	method, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok {
+		return status.Error(codes.Unimplemented, fmt.Sprintf("%s is not a method", name))
+	}
+
+	request := dynamicpb.NewMessage(method.Input())
+	if err := stream.RecvMsg(request); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	fixture := b.fixture
+	b.mu.Unlock()
+
+	response := dynamicpb.NewMessage(method.Output())
+	Message(response, fixture)
+
+	return stream.SendMsg(response)
+}
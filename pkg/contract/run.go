@@ -0,0 +1,114 @@
+package contract
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// variablePattern matches the {id} segments of a path template
+var variablePattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// Result is the outcome of calling one operation of the document
+type Result struct {
+	// Route is the method and path template, like "GET /order/{id}"
+	Route  string
+	Status int
+	// Violations are the ways the request or the response differ from the document
+	Violations []string
+	// Skipped tells why the success response could not be checked, like a rule of the gateway
+	// refusing the sample data
+	Skipped string
+}
+
+// Runner calls every operation of the document through the handler with sample requests, the
+// backend answers the rpcs with samples shaped by the fixture of the route
+type Runner struct {
+	Doc     *openapi.Document
+	Handler http.Handler
+	Backend *Backend
+	// Fixtures are keyed like Result.Route, they shape both the request body and the backend answers
+	Fixtures map[string]Fixture
+	// Header is sent with every request, like the Authorization of the caller
+	Header http.Header
+}
+
+// Run checks the operations in the order of their paths
+func (r *Runner) Run() []Result {
+	var (
+		results []Result
+		paths   = make([]string, 0, len(r.Doc.Paths))
+	)
+
+	for path := range r.Doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		methods := make([]string, 0, len(r.Doc.Paths[path]))
+		for method := range r.Doc.Paths[path] {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			results = append(results, r.check(strings.ToUpper(method), path, r.Doc.Paths[path][method]))
+		}
+	}
+
+	return results
+}
+
+func (r *Runner) check(method, path string, operation *openapi.Operation) Result {
+	var (
+		result  = Result{Route: method + " " + path}
+		fixture = r.Fixtures[result.Route]
+		query   = url.Values{}
+		body    []byte
+	)
+
+	for _, parameter := range operation.Parameters {
+		if parameter.In == "query" && parameter.Required {
+			query.Set(parameter.Name, fmt.Sprint(example(r.Doc, parameter.Name, parameter.Schema, fixture, 0)))
+		}
+	}
+
+	if operation.RequestBody != nil {
+		if media := operation.RequestBody.Content["application/json"]; media != nil {
+			body, _ = json.Marshal(Example(r.Doc, media.Schema, fixture))
+		}
+	}
+
+	target := variablePattern.ReplaceAllString(path, SampleID)
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	request := httptest.NewRequest(method, target, bytes.NewReader(body))
+	for key, values := range r.Header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	result.Violations = r.Doc.CheckRequest(operation, query, request.Header.Get, body)
+
+	r.Backend.Use(fixture)
+	recorder := httptest.NewRecorder()
+	r.Handler.ServeHTTP(recorder, request)
+
+	result.Status = recorder.Code
+	result.Violations = append(result.Violations, r.Doc.CheckResponse(operation, recorder.Code, recorder.Body.Bytes())...)
+	if recorder.Code >= 400 {
+		result.Skipped = strings.TrimSpace(recorder.Body.String())
+	}
+
+	return result
+}
@@ -0,0 +1,187 @@
+package contract
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"Projects/Car24/car24_api_gateway/pkg/openapi"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SampleID is the id of every sample resource
+const SampleID = "6f1c2f4e-3d6b-4c8a-9f5e-1a2b3c4d5e6f"
+
+// maxDepth stops the samples of messages that contain themselves
+const maxDepth = 4
+
+// Fixture overrides the sample values of the fields with the name at any depth, like "status": "draft"
+type Fixture map[string]interface{}
+
+// sampleText is a plausible value for a text field with the name
+func sampleText(name string) string {
+	switch {
+	case name == "id" || strings.HasSuffix(name, "_id"):
+		return SampleID
+	case strings.Contains(name, "expire"):
+		return time.Now().AddDate(10, 0, 0).Format(helper.DateTimeLayout)
+	case strings.HasSuffix(name, "_date") || strings.HasSuffix(name, "_at") || strings.HasSuffix(name, "_time"):
+		return time.Now().AddDate(0, 0, -1).Format(helper.DateTimeLayout)
+	case strings.Contains(name, "phone"):
+		return "+998901234567"
+	case strings.Contains(name, "email"):
+		return "sample@car24.uz"
+	}
+	return "sample"
+}
+
+// Message fills every field of the message with a sample, the fixture replaces the values of its fields
+func Message(message protoreflect.Message, fixture Fixture) {
+	fill(message, fixture, 0)
+}
+
+func fill(message protoreflect.Message, fixture Fixture, depth int) {
+	fields := message.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		if field.IsMap() || field.ContainingOneof() != nil {
+			continue
+		}
+
+		if field.Kind() == protoreflect.MessageKind {
+			switch field.Message().FullName() {
+			case "google.protobuf.Struct", "google.protobuf.FieldMask", "google.protobuf.Empty":
+				continue
+			}
+			if depth >= maxDepth {
+				continue
+			}
+
+			if field.IsList() {
+				fill(message.Mutable(field).List().AppendMutable().Message(), fixture, depth+1)
+			} else {
+				fill(message.Mutable(field).Message(), fixture, depth+1)
+			}
+			continue
+		}
+
+		value, ok := scalar(field, fixture)
+		if !ok {
+			continue
+		}
+		if field.IsList() {
+			message.Mutable(field).List().Append(value)
+		} else {
+			message.Set(field, value)
+		}
+	}
+}
+
+func scalar(field protoreflect.FieldDescriptor, fixture Fixture) (protoreflect.Value, bool) {
+	name := string(field.Name())
+	override, overridden := fixture[name]
+
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		if text, ok := override.(string); overridden && ok {
+			return protoreflect.ValueOfString(text), true
+		}
+		return protoreflect.ValueOfString(sampleText(name)), true
+	case protoreflect.BoolKind:
+		flag, _ := override.(bool)
+		return protoreflect.ValueOfBool(flag), true
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		if values.Len() == 0 {
+			return protoreflect.Value{}, false
+		}
+		return protoreflect.ValueOfEnum(values.Get(values.Len() - 1).Number()), true
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(sampleText(name))), true
+	}
+
+	number := 1.0
+	if value, ok := override.(float64); overridden && ok {
+		number = value
+	} else if value, ok := override.(int); overridden && ok {
+		number = float64(value)
+	}
+
+	switch field.Kind() {
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(number)), true
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(number), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(number)), true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(int64(number)), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(number)), true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(number)), true
+	}
+
+	return protoreflect.Value{}, false
+}
+
+// Example builds a JSON value that matches the schema, the fixture replaces the values of its properties
+func Example(doc *openapi.Document, schema *openapi.Schema, fixture Fixture) interface{} {
+	return example(doc, "", schema, fixture, 0)
+}
+
+func example(doc *openapi.Document, name string, schema *openapi.Schema, fixture Fixture, depth int) interface{} {
+	if value, ok := fixture[name]; ok && name != "" {
+		return value
+	}
+	if schema == nil {
+		return nil
+	}
+
+	if schema.Ref != "" {
+		if depth >= maxDepth {
+			return nil
+		}
+		return example(doc, name, doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], fixture, depth+1)
+	}
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	kind := ""
+	switch types := schema.Type.(type) {
+	case string:
+		kind = types
+	case []string:
+		kind = types[0]
+	}
+
+	switch kind {
+	case "object":
+		object := map[string]interface{}{}
+		for property, item := range schema.Properties {
+			object[property] = example(doc, property, item, fixture, depth+1)
+		}
+		return object
+	case "array":
+		return []interface{}{example(doc, name, schema.Items, fixture, depth+1)}
+	case "string":
+		if schema.Format == "uuid" {
+			return SampleID
+		}
+		return sampleText(name)
+	case "integer", "number":
+		number := 1.0
+		if schema.Minimum != nil && *schema.Minimum > number {
+			number = *schema.Minimum
+		}
+		return number
+	case "boolean":
+		return false
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// ErrInvalid is returned for tokens that were not issued by the gateway or were altered
+	ErrInvalid = errors.New("cursor is invalid")
+	// ErrQueryChanged is returned when a cursor is used with other filters or sort than it was issued for
+	ErrQueryChanged = errors.New("cursor was issued for a different query")
+)
+
+// paging are the parameters a cursor replaces, they are not part of the query fingerprint
+var paging = map[string]bool{
+	"cursor": true,
+	"offset": true,
+	"limit":  true,
+}
+
+// Position is where the page of a cursor starts: next to a row of the previous page, known by the
+// values of its sort fields and its id, so rows added or removed meanwhile do not shift the pages
+type Position struct {
+	// Values are the sort values of the row and ID its id, a position without an id is the first page
+	Values []string `json:"v,omitempty"`
+	ID     string   `json:"i,omitempty"`
+	// Before is set for the page that ends before the row, the previous page
+	Before bool   `json:"b,omitempty"`
+	Limit  int64  `json:"l"`
+	Query  string `json:"q"`
+}
+
+// Encode signs the position into an opaque token
+func Encode(secret string, position Position) string {
+	payload, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(secret, payload))
+}
+
+// Decode verifies the token signature and returns the position it points to
+func Decode(secret, token string) (Position, error) {
+	var position Position
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return position, ErrInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return position, ErrInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, sign(secret, payload)) {
+		return position, ErrInvalid
+	}
+
+	err = json.Unmarshal(payload, &position)
+	if err != nil || position.Limit < 1 || (position.ID == "" && (len(position.Values) > 0 || position.Before)) {
+		return position, ErrInvalid
+	}
+
+	return position, nil
+}
+
+// First reports whether the position is the first page
+func (p Position) First() bool {
+	return p.ID == ""
+}
+
+// Keyset returns the values of the sort fields and the id of a row, the fields are proto field names
+func Keyset(row proto.Message, fields []string) (values []string, id string) {
+	message := row.ProtoReflect()
+	descriptor := message.Descriptor().Fields()
+
+	for _, name := range fields {
+		values = append(values, value(message, descriptor.ByName(protoreflect.Name(name))))
+	}
+
+	return values, value(message, descriptor.ByName("id"))
+}
+
+// value formats a scalar field like the backend compares it, an unknown field is empty
+func value(message protoreflect.Message, field protoreflect.FieldDescriptor) string {
+	if field == nil {
+		return ""
+	}
+
+	v := message.Get(field)
+	switch field.Kind() {
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case protoreflect.EnumKind:
+		return strconv.Itoa(int(v.Enum()))
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// Fingerprint identifies the filters, search and sort of a list request so a cursor cannot be replayed against another query
+func Fingerprint(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !paging[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		items := append([]string(nil), values[key]...)
+		sort.Strings(items)
+		hash.Write([]byte(key + "=" + strings.Join(items, "\x00") + "&"))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(hash.Sum(nil)[:12])
+}
+
+func sign(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
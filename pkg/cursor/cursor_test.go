@@ -0,0 +1,97 @@
+package cursor
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const secret = "secret"
+
+func TestDecode(t *testing.T) {
+	valid := Position{Values: []string{"2026-05-01 10:00:00"}, ID: "o1", Limit: 10, Query: "q"}
+	token := Encode(secret, valid)
+	payload, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		want  Position
+		err   error
+	}{
+		{name: "round trip", token: token, want: valid},
+		{name: "previous page", token: Encode(secret, Position{Values: []string{"1"}, ID: "o1", Before: true, Limit: 5}), want: Position{Values: []string{"1"}, ID: "o1", Before: true, Limit: 5}},
+		{name: "first page", token: Encode(secret, Position{Limit: 5}), want: Position{Limit: 5}},
+		{name: "other secret", token: Encode("other", valid), err: ErrInvalid},
+		{name: "altered payload", token: payload + "x." + signature, err: ErrInvalid},
+		{name: "no signature", token: payload, err: ErrInvalid},
+		{name: "not base64", token: "!." + signature, err: ErrInvalid},
+		{name: "no limit", token: Encode(secret, Position{ID: "o1"}), err: ErrInvalid},
+		{name: "values without id", token: Encode(secret, Position{Values: []string{"1"}, Limit: 5}), err: ErrInvalid},
+		{name: "before without id", token: Encode(secret, Position{Before: true, Limit: 5}), err: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(secret, tt.token)
+			if err != tt.err {
+				t.Fatalf("error %v, want %v", err, tt.err)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	order := &order_service.Order{Id: "o1", CreatedAt: "2026-05-01 10:00:00", TotalPrice: 1500.5, DayCount: 3}
+
+	tests := []struct {
+		name   string
+		fields []string
+		values []string
+	}{
+		{name: "no sort fields", fields: nil, values: nil},
+		{name: "string", fields: []string{"created_at"}, values: []string{"2026-05-01 10:00:00"}},
+		{name: "numbers", fields: []string{"total_price", "day_count"}, values: []string{"1500.5", "3"}},
+		{name: "unknown field", fields: []string{"nope"}, values: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, id := Keyset(order, tt.fields)
+			if id != "o1" {
+				t.Fatalf("id %q, want o1", id)
+			}
+			if !reflect.DeepEqual(values, tt.values) {
+				t.Fatalf("values %q, want %q", values, tt.values)
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint(url.Values{"status": {"active", "returned"}, "sort": {"created_at:desc"}})
+
+	tests := []struct {
+		name   string
+		values url.Values
+		same   bool
+	}{
+		{name: "paging ignored", values: url.Values{"status": {"active", "returned"}, "sort": {"created_at:desc"}, "limit": {"5"}, "cursor": {"x"}, "offset": {"1"}}, same: true},
+		{name: "value order ignored", values: url.Values{"status": {"returned", "active"}, "sort": {"created_at:desc"}}, same: true},
+		{name: "other filter", values: url.Values{"status": {"active"}, "sort": {"created_at:desc"}}},
+		{name: "other sort", values: url.Values{"status": {"active", "returned"}, "sort": {"created_at:asc"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := Fingerprint(tt.values) == base; same != tt.same {
+				t.Fatalf("same fingerprint = %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package dateparse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Range is the period a date expression covers, both bounds are inclusive
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// IsPoint reports whether the expression named an exact moment instead of a day or a period
+func (r Range) IsPoint() bool {
+	return r.From.Equal(r.To)
+}
+
+// exactLayouts name a moment, dayLayouts a whole day
+var (
+	exactLayouts = []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		"02.01.2006 15:04",
+	}
+	dayLayouts = []string{
+		"2006-01-02",
+		"02.01.2006",
+		"2.1.2006",
+		"02/01/2006",
+	}
+)
+
+var (
+	dayRangePattern = regexp.MustCompile(`(^|\s)(\d{1,2})\s*-\s*(\d{1,2})(\s|$)`)
+	dayMonthPattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?$`)
+)
+
+// Parse reads an ISO date, a date written with Russian, Uzbek or English month names ("15 марта 2024",
+// "15-mart", "march 15th"), a relative day ("сегодня", "ertaga", "через 3 дня") or a range of them
+// ("с 1 по 5 мая", "1 maydan 5 maygacha", "1-5 may"), the year defaults to the year of now
+func Parse(text string, now time.Time) (Range, error) {
+	text = normalize(text)
+	if text == "" {
+		return Range{}, errors.New("date is empty")
+	}
+
+	if r, ok := parseExact(text, now.Location()); ok {
+		return r, nil
+	}
+	if r, ok := parseRelative(text, now); ok {
+		return r, nil
+	}
+
+	tokens := tokenize(text)
+	if left, right, ok := splitRange(tokens); ok {
+		return parseRange(left, right, now)
+	}
+
+	date, err := parseSide(tokens, now)
+	if err != nil {
+		return Range{}, err
+	}
+	return date.complete(now.Year(), now.Location())
+}
+
+// partial is a date that may miss its year, or its day when it names a whole month
+type partial struct {
+	day, year int
+	month     time.Month
+	exact     *Range
+}
+
+func (p partial) complete(defaultYear int, location *time.Location) (Range, error) {
+	if p.exact != nil {
+		return *p.exact, nil
+	}
+	if p.month == 0 {
+		return Range{}, errors.New("date has no month")
+	}
+
+	year := p.year
+	if year == 0 {
+		year = defaultYear
+	}
+
+	if p.day == 0 {
+		from := time.Date(year, p.month, 1, 0, 0, 0, 0, location)
+		return Range{From: from, To: from.AddDate(0, 1, 0).Add(-time.Second)}, nil
+	}
+
+	from := time.Date(year, p.month, p.day, 0, 0, 0, 0, location)
+	if from.Day() != p.day || from.Month() != p.month {
+		return Range{}, fmt.Errorf("%s has no day %d", p.month, p.day)
+	}
+	return wholeDay(from), nil
+}
+
+func parseRange(left, right []string, now time.Time) (Range, error) {
+	from, err := parseSide(left, now)
+	if err != nil {
+		return Range{}, err
+	}
+	to, err := parseSide(right, now)
+	if err != nil {
+		return Range{}, err
+	}
+
+	// "с 30 декабря по 2 января" crosses a year, "с 30 по 2 мая" or "с 5 по 1 мая" are mistakes and
+	// not ranges of a year
+	crossesYear := from.month != 0 && to.month != 0 && from.month > to.month
+	if from.exact == nil && to.exact == nil {
+		if from.month == 0 {
+			from.month = to.month
+		}
+		if to.month == 0 {
+			to.month = from.month
+		}
+	}
+
+	var fromInferred, toInferred = from.year == 0, to.year == 0
+	if to.year == 0 {
+		to.year = from.year
+	}
+	if to.year == 0 {
+		to.year = now.Year()
+	}
+	if from.year == 0 {
+		from.year = to.year
+	}
+
+	start, err := from.complete(now.Year(), now.Location())
+	if err != nil {
+		return Range{}, err
+	}
+	end, err := to.complete(now.Year(), now.Location())
+	if err != nil {
+		return Range{}, err
+	}
+
+	if start.From.After(end.To) {
+		switch {
+		case !crossesYear:
+			err = errors.New("range starts after it ends")
+		case toInferred && to.exact == nil:
+			to.year++
+			end, err = to.complete(now.Year(), now.Location())
+		case fromInferred && from.exact == nil:
+			from.year--
+			start, err = from.complete(now.Year(), now.Location())
+		default:
+			err = errors.New("range starts after it ends")
+		}
+		if err != nil {
+			return Range{}, err
+		}
+	}
+
+	return Range{From: start.From, To: end.To}, nil
+}
+
+// parseSide reads one bound of a range or a whole single date
+func parseSide(tokens []string, now time.Time) (partial, error) {
+	var result partial
+
+	if len(tokens) == 0 {
+		return result, errors.New("range bound is empty")
+	}
+
+	joined := strings.Join(tokens, " ")
+	if r, ok := parseExact(joined, now.Location()); ok {
+		result.exact = &r
+		return result, nil
+	}
+	if r, ok := parseRelative(joined, now); ok {
+		result.exact = &r
+		return result, nil
+	}
+
+	for _, token := range tokens {
+		if fillers[token] {
+			continue
+		}
+		token = trimNumberSuffix(token)
+
+		if isDigits(token) {
+			number, _ := strconv.Atoi(token)
+			switch {
+			case len(token) == 4 && result.year == 0:
+				result.year = number
+			case len(token) <= 2 && result.day == 0:
+				result.day = number
+			default:
+				return result, fmt.Errorf("unexpected number %s", token)
+			}
+			continue
+		}
+
+		if match := dayMonthPattern.FindStringSubmatch(token); match != nil {
+			result.day, _ = strconv.Atoi(match[1])
+			month, _ := strconv.Atoi(match[2])
+			result.month = time.Month(month)
+			if match[3] != "" {
+				result.year, _ = strconv.Atoi(match[3])
+			}
+			continue
+		}
+
+		month, ok := lookupMonth(token)
+		if !ok {
+			return result, fmt.Errorf("unknown word %q", token)
+		}
+		if result.month != 0 {
+			return result, errors.New("date has two months")
+		}
+		result.month = month
+	}
+
+	if result.month < 0 || result.month > 12 {
+		return result, fmt.Errorf("month %d does not exist", result.month)
+	}
+
+	return result, nil
+}
+
+// splitRange finds the words or suffixes separating the bounds of a range
+func splitRange(tokens []string) (left, right []string, ok bool) {
+	var side int
+
+	for i, token := range tokens {
+		if i == 0 && rangeStarts[token] {
+			continue
+		}
+
+		if rangeEnds[token] {
+			if side == 1 || len(left) == 0 {
+				return nil, nil, false
+			}
+			side = 1
+			continue
+		}
+
+		if side == 0 && i < len(tokens)-1 {
+			if stripped, found := trimSuffix(token, fromSuffixes); found {
+				left = append(left, stripped)
+				side = 1
+				continue
+			}
+		}
+
+		if stripped, found := trimSuffix(token, untilSuffixes); found {
+			token = stripped
+			if word, ok := untilStems[token]; ok {
+				token = word
+			}
+		}
+
+		if side == 0 {
+			left = append(left, token)
+		} else {
+			right = append(right, token)
+		}
+	}
+
+	return left, right, side == 1 && len(right) > 0
+}
+
+func parseExact(text string, location *time.Location) (Range, bool) {
+	for _, layout := range exactLayouts {
+		t, err := time.ParseInLocation(layout, text, location)
+		if err == nil {
+			return Range{From: t, To: t}, true
+		}
+	}
+
+	for _, layout := range dayLayouts {
+		t, err := time.ParseInLocation(layout, text, location)
+		if err == nil {
+			return wholeDay(t), true
+		}
+	}
+
+	return Range{}, false
+}
+
+func parseRelative(text string, now time.Time) (Range, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if days, ok := relativeDays[text]; ok {
+		return wholeDay(today.AddDate(0, 0, days)), true
+	}
+
+	words := strings.Fields(text)
+	if len(words) != 3 {
+		return Range{}, false
+	}
+
+	switch {
+	case afterIn[words[0]] && isDigits(words[1]) && isDayUnit(words[2]):
+		days, _ := strconv.Atoi(words[1])
+		return wholeDay(today.AddDate(0, 0, days)), true
+	case isDigits(words[0]) && isDayUnit(words[1]) && afterOut[words[2]]:
+		days, _ := strconv.Atoi(words[0])
+		return wholeDay(today.AddDate(0, 0, days)), true
+	case isDigits(words[0]) && isDayUnit(words[1]) && agoOut[words[2]]:
+		days, _ := strconv.Atoi(words[0])
+		return wholeDay(today.AddDate(0, 0, -days)), true
+	}
+
+	return Range{}, false
+}
+
+func normalize(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	text = strings.NewReplacer("–", "-", "—", "-", ",", " ").Replace(text)
+	text = dayRangePattern.ReplaceAllString(text, "$1$2 - $3$4")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// tokenize splits words like "15-mart" or "2024-yil" that join a number and a word with a hyphen
+func tokenize(text string) []string {
+	var tokens []string
+
+	for _, word := range strings.Fields(text) {
+		word = strings.TrimSuffix(word, ".")
+		if word != "-" && strings.Contains(word, "-") && strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			for _, part := range strings.Split(word, "-") {
+				if part != "" {
+					tokens = append(tokens, part)
+				}
+			}
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
+
+// trimNumberSuffix drops "г" from "2024г" and English ordinal endings from "15th"
+func trimNumberSuffix(token string) string {
+	for _, suffix := range []string{"г", "st", "nd", "rd", "th"} {
+		if trimmed := strings.TrimSuffix(token, suffix); trimmed != token && isDigits(trimmed) {
+			return trimmed
+		}
+	}
+	return token
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func wholeDay(t time.Time) Range {
+	from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return Range{From: from, To: from.AddDate(0, 0, 1).Add(-time.Second)}
+}
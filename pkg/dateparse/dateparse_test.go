@@ -0,0 +1,76 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		text     string
+		from, to string
+		point    bool
+		err      bool
+	}{
+		// exact and day formats
+		{text: "2026-05-01", from: "2026-05-01 00:00:00", to: "2026-05-01 23:59:59"},
+		{text: "2026-05-01 10:15:00", from: "2026-05-01 10:15:00", to: "2026-05-01 10:15:00", point: true},
+		{text: "01.05.2026", from: "2026-05-01 00:00:00", to: "2026-05-01 23:59:59"},
+		{text: "1.5", from: "2026-05-01 00:00:00", to: "2026-05-01 23:59:59"},
+
+		// month names
+		{text: "15 марта 2024", from: "2024-03-15 00:00:00", to: "2024-03-15 23:59:59"},
+		{text: "15 марта", from: "2026-03-15 00:00:00", to: "2026-03-15 23:59:59"},
+		{text: "15-mart", from: "2026-03-15 00:00:00", to: "2026-03-15 23:59:59"},
+		{text: "15 март", from: "2026-03-15 00:00:00", to: "2026-03-15 23:59:59"},
+		{text: "march 15th", from: "2026-03-15 00:00:00", to: "2026-03-15 23:59:59"},
+		{text: "май", from: "2026-05-01 00:00:00", to: "2026-05-31 23:59:59"},
+		{text: "31 февраля", err: true},
+		{text: "15 марта мая", err: true},
+		{text: "15 чего-то", err: true},
+		{text: "", err: true},
+
+		// relative days
+		{text: "сегодня", from: "2026-10-19 00:00:00", to: "2026-10-19 23:59:59"},
+		{text: "Завтра", from: "2026-10-20 00:00:00", to: "2026-10-20 23:59:59"},
+		{text: "ertaga", from: "2026-10-20 00:00:00", to: "2026-10-20 23:59:59"},
+		{text: "через 3 дня", from: "2026-10-22 00:00:00", to: "2026-10-22 23:59:59"},
+
+		// ranges
+		{text: "с 1 по 5 мая", from: "2026-05-01 00:00:00", to: "2026-05-05 23:59:59"},
+		{text: "1-5 may", from: "2026-05-01 00:00:00", to: "2026-05-05 23:59:59"},
+		{text: "1 maydan 5 maygacha", from: "2026-05-01 00:00:00", to: "2026-05-05 23:59:59"},
+		{text: "с 30 апреля по 2 мая", from: "2026-04-30 00:00:00", to: "2026-05-02 23:59:59"},
+		{text: "с 30 декабря по 2 января", from: "2026-12-30 00:00:00", to: "2027-01-02 23:59:59"},
+		{text: "с 30 декабря по 2 января 2027", from: "2026-12-30 00:00:00", to: "2027-01-02 23:59:59"},
+		{text: "с 30 по 2 мая", err: true},
+		{text: "30-2 may", err: true},
+		{text: "с 5 мая по 1 мая", err: true},
+		{text: "с 28 по 3 января", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := Parse(tt.text, now)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("parsed as %s - %s, want an error", got.From, got.To)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			from, to := got.From.Format("2006-01-02 15:04:05"), got.To.Format("2006-01-02 15:04:05")
+			if from != tt.from || to != tt.to {
+				t.Fatalf("got %s - %s, want %s - %s", from, to, tt.from, tt.to)
+			}
+			if got.IsPoint() != tt.point {
+				t.Fatalf("point = %v, want %v", got.IsPoint(), tt.point)
+			}
+		})
+	}
+}
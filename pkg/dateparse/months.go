@@ -0,0 +1,138 @@
+package dateparse
+
+import (
+	"strings"
+	"time"
+)
+
+// monthNames are the Russian and Uzbek (Latin and Cyrillic) stems of month names, followed by
+// one of monthEndings, and the English names and abbreviations, which must match as a whole word
+var monthNames = []struct {
+	month time.Month
+	stems []string
+	words []string
+}{
+	{time.January, []string{"январ", "yanvar"}, []string{"january", "jan"}},
+	{time.February, []string{"феврал", "fevral"}, []string{"february", "feb"}},
+	{time.March, []string{"март", "mart"}, []string{"march", "mar"}},
+	{time.April, []string{"апрел", "aprel"}, []string{"april", "apr"}},
+	{time.May, []string{"май", "may"}, []string{"мая", "мае"}},
+	{time.June, []string{"июн", "iyun"}, []string{"june", "jun"}},
+	{time.July, []string{"июл", "iyul"}, []string{"july", "jul"}},
+	{time.August, []string{"август", "avgust"}, []string{"august", "aug"}},
+	{time.September, []string{"сентябр", "sentyabr"}, []string{"september", "sept", "sep"}},
+	{time.October, []string{"октябр", "oktyabr"}, []string{"october", "oct"}},
+	{time.November, []string{"ноябр", "noyabr"}, []string{"november", "nov"}},
+	{time.December, []string{"декабр", "dekabr"}, []string{"december", "dec"}},
+}
+
+// monthEndings are the Russian case endings and Uzbek suffixes a month stem may take, as in "марта" or "maydan"
+var monthEndings = []string{
+	"", "ь", "я", "е", "а", "ю",
+	"da", "dan", "ga", "gacha", "ning",
+	"да", "дан", "га", "гача", "нинг",
+}
+
+// relativeDays are the words for days counted from today
+var relativeDays = map[string]int{
+	"позавчера":          -2,
+	"вчера":              -1,
+	"kecha":              -1,
+	"кеча":               -1,
+	"yesterday":          -1,
+	"сегодня":            0,
+	"bugun":              0,
+	"бугун":              0,
+	"today":              0,
+	"завтра":             1,
+	"ertaga":             1,
+	"эртага":             1,
+	"tomorrow":           1,
+	"послезавтра":        2,
+	"indinga":            2,
+	"индинга":            2,
+	"day after tomorrow": 2,
+}
+
+// fillers are words that carry no date information
+var fillers = map[string]bool{
+	"г":       true,
+	"год":     true,
+	"года":    true,
+	"yil":     true,
+	"yilning": true,
+	"йил":     true,
+	"йилнинг": true,
+	"of":      true,
+	"the":     true,
+	"on":      true,
+}
+
+// Range words
+var (
+	rangeStarts = map[string]bool{"с": true, "со": true, "от": true, "from": true, "between": true}
+	rangeEnds   = map[string]bool{"по": true, "до": true, "to": true, "until": true, "till": true, "and": true, "-": true}
+
+	// Uzbek marks the range bounds with the suffixes -dan (from) and -gacha (until)
+	fromSuffixes  = []string{"dan", "дан"}
+	untilSuffixes = []string{"gacha", "гача"}
+
+	// untilStems restores the words whose -ga ending is replaced by -gacha, as in "ertagacha"
+	untilStems = map[string]string{
+		"erta":  "ertaga",
+		"эрта":  "эртага",
+		"indin": "indinga",
+		"индин": "индинга",
+	}
+)
+
+// Relative offsets written as "через 3 дня", "3 kundan keyin", "in 3 days", "3 дня назад", "3 kun oldin" or "3 days ago"
+var (
+	dayUnits = []string{"день", "дня", "дней", "kun", "kundan", "кун", "кундан", "day", "days"}
+	afterIn  = map[string]bool{"через": true, "in": true}
+	afterOut = map[string]bool{"keyin": true, "кейин": true}
+	agoOut   = map[string]bool{"назад": true, "oldin": true, "олдин": true, "ago": true}
+)
+
+func lookupMonth(word string) (time.Month, bool) {
+	word = strings.TrimSuffix(word, ".")
+
+	for _, month := range monthNames {
+		for _, name := range month.words {
+			if word == name {
+				return month.month, true
+			}
+		}
+
+		for _, stem := range month.stems {
+			if !strings.HasPrefix(word, stem) {
+				continue
+			}
+			rest := strings.TrimPrefix(word, stem)
+			for _, ending := range monthEndings {
+				if rest == ending {
+					return month.month, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+func isDayUnit(word string) bool {
+	for _, unit := range dayUnits {
+		if word == unit {
+			return true
+		}
+	}
+	return false
+}
+
+func trimSuffix(word string, suffixes []string) (string, bool) {
+	for _, suffix := range suffixes {
+		if len(word) > len(suffix) && strings.HasSuffix(word, suffix) {
+			return strings.TrimSuffix(strings.TrimSuffix(word, suffix), "-"), true
+		}
+	}
+	return word, false
+}
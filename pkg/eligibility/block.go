@@ -0,0 +1,43 @@
+package eligibility
+
+// Block reason categories
+const (
+	BlockUnpaidBalance     = "unpaid_balance"
+	BlockDamage            = "damage"
+	BlockFraud             = "fraud"
+	BlockTrafficViolations = "traffic_violations"
+	BlockOther             = "other"
+)
+
+// Unblock reason categories
+const (
+	UnblockDebtSettled = "debt_settled"
+	UnblockAppeal      = "appeal"
+	UnblockMistake     = "mistake"
+	UnblockOther       = "other"
+)
+
+var (
+	blockReasons   = []string{BlockUnpaidBalance, BlockDamage, BlockFraud, BlockTrafficViolations, BlockOther}
+	unblockReasons = []string{UnblockDebtSettled, UnblockAppeal, UnblockMistake, UnblockOther}
+)
+
+// BlockReasons returns the categories accepted when blocking a client
+func BlockReasons() []string {
+	return blockReasons
+}
+
+// UnblockReasons returns the categories accepted when unblocking a client
+func UnblockReasons() []string {
+	return unblockReasons
+}
+
+// IsValidReason reports whether reason is one of the given categories
+func IsValidReason(reason string, reasons []string) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
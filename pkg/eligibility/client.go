@@ -0,0 +1,92 @@
+package eligibility
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"strings"
+	"time"
+)
+
+// Rules
+const (
+	RuleClientBlocked            = "client_blocked"
+	RulePassportMissing          = "passport_missing"
+	RulePassportPinflMissing     = "passport_pinfl_missing"
+	RuleDrivingLicenseMissing    = "driving_license_missing"
+	RuleDrivingLicenseNotStarted = "driving_license_not_started"
+	RuleDrivingLicenseExpiry     = "driving_license_expiry_unknown"
+	RuleDrivingLicenseExpired    = "driving_license_expired"
+)
+
+// Failure is a rule the client does not satisfy
+type Failure struct {
+	Rule    string `json:"rule"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// CheckClient returns every rule that prevents the client from renting a car from start until end
+func CheckClient(client *client_service.Client, start, end time.Time) []Failure {
+	var failures []Failure
+
+	if client.IsBlocked {
+		failures = append(failures, Failure{
+			Rule:    RuleClientBlocked,
+			Field:   "is_blocked",
+			Message: "client is blocked",
+		})
+	}
+
+	if strings.TrimSpace(client.PassportNumber) == "" {
+		failures = append(failures, Failure{
+			Rule:    RulePassportMissing,
+			Field:   "passport_number",
+			Message: "passport number is missing",
+		})
+	}
+
+	if strings.TrimSpace(client.PassportPinfl) == "" {
+		failures = append(failures, Failure{
+			Rule:    RulePassportPinflMissing,
+			Field:   "passport_pinfl",
+			Message: "passport PINFL is missing",
+		})
+	}
+
+	if strings.TrimSpace(client.DrivingLicenseNumber) == "" {
+		failures = append(failures, Failure{
+			Rule:    RuleDrivingLicenseMissing,
+			Field:   "driving_license_number",
+			Message: "driving license number is missing",
+		})
+		return failures
+	}
+
+	if client.DrivingNumberGivenDate != "" {
+		given, err := helper.ParseDateTime(client.DrivingNumberGivenDate)
+		if err == nil && given.After(start) {
+			failures = append(failures, Failure{
+				Rule:    RuleDrivingLicenseNotStarted,
+				Field:   "driving_number_given_date",
+				Message: "driving license is issued after the rental starts",
+			})
+		}
+	}
+
+	expires, err := helper.ParseDateTime(client.DrivingNumberExpired)
+	if err != nil {
+		failures = append(failures, Failure{
+			Rule:    RuleDrivingLicenseExpiry,
+			Field:   "driving_number_expired",
+			Message: "driving license expiry date is missing or invalid",
+		})
+	} else if expires.Before(end) {
+		failures = append(failures, Failure{
+			Rule:    RuleDrivingLicenseExpired,
+			Field:   "driving_number_expired",
+			Message: "driving license expires on " + expires.Format("2006-01-02") + " before the rental ends on " + end.Format("2006-01-02"),
+		})
+	}
+
+	return failures
+}
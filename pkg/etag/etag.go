@@ -0,0 +1,74 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Of returns the entity tag of a resource, it is derived from the id and updated_at fields when the
+// message has them and from the message content otherwise. derived names the fields that are not
+// stored with the resource, like the ones the gateway computes, they change without updated_at and
+// are hashed along with it
+func Of(msg proto.Message, derived ...protoreflect.Name) string {
+	var (
+		hash   = sha256.New()
+		fields = msg.ProtoReflect()
+	)
+
+	id := stringField(fields, "id")
+	updatedAt := stringField(fields, "updated_at")
+	if updatedAt != "" {
+		hash.Write([]byte(id + "|" + updatedAt))
+		for _, name := range derived {
+			if fd := fields.Descriptor().Fields().ByName(name); fd != nil {
+				fmt.Fprintf(hash, "|%s=%v", name, fields.Get(fd).Interface())
+			}
+		}
+	} else {
+		content, _ := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		hash.Write(content)
+	}
+
+	return `"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+}
+
+// Match reports whether the If-None-Match header value lists the tag, it is the weak comparison,
+// weak tags are compared by their opaque part
+func Match(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchStrong reports whether the If-Match header value lists the tag, it is the strong comparison
+// of RFC 9110, a weak tag never matches
+func MatchStrong(header, tag string) bool {
+	weak := strings.HasPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || (!weak && candidate == tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringField(msg protoreflect.Message, name protoreflect.Name) string {
+	fd := msg.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return msg.Get(fd).String()
+}
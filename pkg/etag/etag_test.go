@@ -0,0 +1,77 @@
+package etag
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestOf(t *testing.T) {
+	var (
+		derived = []protoreflect.Name{"balance", "is_overdue"}
+		base    = &order_service.Order{Id: "o1", UpdatedAt: "2026-05-01 10:00:00", Balance: 100}
+		tag     = Of(base, derived...)
+	)
+
+	tests := []struct {
+		name  string
+		order *order_service.Order
+		same  bool
+	}{
+		{name: "same version", order: &order_service.Order{Id: "o1", UpdatedAt: "2026-05-01 10:00:00", Balance: 100}, same: true},
+		{name: "stored field without updated_at", order: &order_service.Order{Id: "o1", UpdatedAt: "2026-05-01 10:00:00", Balance: 100, CarId: "c2"}, same: true},
+		{name: "updated", order: &order_service.Order{Id: "o1", UpdatedAt: "2026-05-02 10:00:00", Balance: 100}},
+		{name: "derived balance", order: &order_service.Order{Id: "o1", UpdatedAt: "2026-05-01 10:00:00", Balance: 50}},
+		{name: "derived overdue", order: &order_service.Order{Id: "o1", UpdatedAt: "2026-05-01 10:00:00", Balance: 100, IsOverdue: true}},
+		{name: "other resource", order: &order_service.Order{Id: "o2", UpdatedAt: "2026-05-01 10:00:00", Balance: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := Of(tt.order, derived...) == tag; same != tt.same {
+				t.Fatalf("same tag = %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
+
+func TestOfContent(t *testing.T) {
+	// without updated_at the content is the version
+	first := Of(&order_service.Tarif{Id: "t1", PricePerDay: "10"})
+	if first == Of(&order_service.Tarif{Id: "t1", PricePerDay: "20"}) {
+		t.Fatal("a changed resource without updated_at kept its tag")
+	}
+	if first != Of(&order_service.Tarif{Id: "t1", PricePerDay: "10"}) {
+		t.Fatal("the same content got another tag")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		tag    string
+		weak   bool
+		strong bool
+	}{
+		{name: "same", header: `"abc"`, tag: `"abc"`, weak: true, strong: true},
+		{name: "in a list", header: `"x", "abc"`, tag: `"abc"`, weak: true, strong: true},
+		{name: "any", header: `*`, tag: `"abc"`, weak: true, strong: true},
+		{name: "weak header", header: `W/"abc"`, tag: `"abc"`, weak: true},
+		{name: "weak tag", header: `"abc"`, tag: `W/"abc"`, weak: true},
+		{name: "other", header: `"abd"`, tag: `"abc"`},
+		{name: "empty", header: ``, tag: `"abc"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.header, tt.tag); got != tt.weak {
+				t.Fatalf("Match = %v, want %v", got, tt.weak)
+			}
+			if got := MatchStrong(tt.header, tt.tag); got != tt.strong {
+				t.Fatalf("MatchStrong = %v, want %v", got, tt.strong)
+			}
+		})
+	}
+}
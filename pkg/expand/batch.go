@@ -0,0 +1,104 @@
+package expand
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Batch collects the ids asked for while a response is being built and loads all of them together,
+// with Fetch, the first time one of the results is needed, a resource is loaded once per batch. The
+// lock only guards the ids and results, the fetch runs without it so ids can be queued meanwhile
+type Batch struct {
+	ctx     context.Context
+	loaders map[string]Loader
+	limit   int
+
+	mu      sync.Mutex
+	pending map[string][]string
+	results Results
+	errs    map[string]map[string]string
+	// fetching is closed when the running fetch published its results, nil when none runs
+	fetching chan struct{}
+}
+
+func NewBatch(ctx context.Context, loaders map[string]Loader, limit int) *Batch {
+	return &Batch{
+		ctx:     ctx,
+		loaders: loaders,
+		limit:   limit,
+		pending: map[string][]string{},
+		results: Results{},
+		errs:    map[string]map[string]string{},
+	}
+}
+
+// Load queues the id and returns a function that waits for the batch it belongs to
+func (b *Batch) Load(relation, id string) func() (interface{}, error) {
+	b.mu.Lock()
+	if !b.loaded(relation, id) {
+		b.pending[relation] = append(b.pending[relation], id)
+	}
+	b.mu.Unlock()
+
+	return func() (interface{}, error) {
+		b.mu.Lock()
+		for !b.loaded(relation, id) {
+			// the running fetch may have taken the id, the ids queued after it need another one
+			if fetching := b.fetching; fetching != nil {
+				b.mu.Unlock()
+				<-fetching
+				b.mu.Lock()
+				continue
+			}
+			b.dispatch()
+		}
+		defer b.mu.Unlock()
+
+		if message, ok := b.errs[relation][id]; ok {
+			return nil, errors.New(message)
+		}
+		return b.results.Get(relation, id), nil
+	}
+}
+
+func (b *Batch) loaded(relation, id string) bool {
+	if _, ok := b.results[relation][id]; ok {
+		return true
+	}
+	_, ok := b.errs[relation][id]
+	return ok
+}
+
+// dispatch loads every pending id, the caller holds the lock and holds it again when dispatch returns,
+// it is released while the ids are fetched
+func (b *Batch) dispatch() {
+	pending := b.pending
+	b.pending = map[string][]string{}
+	fetching := make(chan struct{})
+	b.fetching = fetching
+	b.mu.Unlock()
+
+	results, errs := Fetch(b.ctx, b.loaders, pending, b.limit)
+
+	b.mu.Lock()
+	for relation, ids := range pending {
+		if b.results[relation] == nil {
+			b.results[relation] = map[string]interface{}{}
+		}
+		// the ids without a resource are loaded as nil, they are not fetched again
+		for _, id := range ids {
+			b.results[relation][id] = results.Get(relation, id)
+		}
+	}
+
+	for _, err := range errs {
+		if b.errs[err.Relation] == nil {
+			b.errs[err.Relation] = map[string]string{}
+		}
+		b.errs[err.Relation][err.ID] = err.Message
+	}
+
+	b.fetching = nil
+	close(fetching)
+}
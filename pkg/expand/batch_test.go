@@ -0,0 +1,110 @@
+package expand
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchLoad(t *testing.T) {
+	var calls int32
+	loaders := map[string]Loader{
+		"cars": func(ctx context.Context, id string) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			switch id {
+			case "gone":
+				return nil, nil
+			case "broken":
+				return nil, errors.New("car service down")
+			}
+			return "car " + id, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		want interface{}
+		err  string
+	}{
+		{name: "loaded", id: "c1", want: "car c1"},
+		{name: "missing", id: "gone"},
+		{name: "failed", id: "broken", err: "car service down"},
+	}
+
+	b := NewBatch(context.Background(), loaders, 2)
+	thunks := make([]func() (interface{}, error), len(tests))
+	for i, tt := range tests {
+		thunks[i] = b.Load("cars", tt.id)
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for attempt := 0; attempt < 2; attempt++ {
+				got, err := thunks[i]()
+				if tt.err != "" {
+					if err == nil || err.Error() != tt.err {
+						t.Fatalf("err = %v, want %q", err, tt.err)
+					}
+					continue
+				}
+				if err != nil || got != tt.want {
+					t.Fatalf("got %v, %v, want %v", got, err, tt.want)
+				}
+			}
+		})
+	}
+
+	// the ids are fetched in one batch and a second wait does not fetch them again
+	if got := atomic.LoadInt32(&calls); got != int32(len(tests)) {
+		t.Fatalf("loaded %d times, want %d", got, len(tests))
+	}
+}
+
+func TestBatchQueuesDuringAFetch(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	loaders := map[string]Loader{
+		"cars": func(ctx context.Context, id string) (interface{}, error) {
+			if id == "c1" {
+				close(started)
+				<-release
+			}
+			return "car " + id, nil
+		},
+	}
+
+	b := NewBatch(context.Background(), loaders, 2)
+	first := b.Load("cars", "c1")
+	firstDone := make(chan interface{}, 1)
+	go func() {
+		value, _ := first()
+		firstDone <- value
+	}()
+	<-started
+
+	// the fetch of c1 runs, queueing and waiting for another id must not wait for the lock it held
+	queued := make(chan func() (interface{}, error), 1)
+	go func() { queued <- b.Load("cars", "c2") }()
+	var second func() (interface{}, error)
+	select {
+	case second = <-queued:
+	case <-time.After(time.Second):
+		t.Fatal("Load blocked while a fetch ran")
+	}
+
+	secondDone := make(chan interface{}, 1)
+	go func() {
+		value, _ := second()
+		secondDone <- value
+	}()
+
+	close(release)
+	if value := <-firstDone; value != "car c1" {
+		t.Fatalf("first got %v, want %q", value, "car c1")
+	}
+	if value := <-secondDone; value != "car c2" {
+		t.Fatalf("second got %v, want %q", value, "car c2")
+	}
+}
@@ -0,0 +1,123 @@
+package expand
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Loader fetches one related resource by its id
+type Loader func(ctx context.Context, id string) (interface{}, error)
+
+// Error reports a related resource that could not be loaded
+type Error struct {
+	Relation string `json:"relation"`
+	ID       string `json:"id"`
+	Message  string `json:"message"`
+}
+
+// Results holds the loaded resources by relation and id
+type Results map[string]map[string]interface{}
+
+// Get returns the loaded resource or nil when it was not requested or failed to load
+func (r Results) Get(relation, id string) interface{} {
+	return r[relation][id]
+}
+
+// Parse splits the comma separated expand parameter and checks every relation is allowed
+func Parse(value string, allowed []string) ([]string, error) {
+	var (
+		relations []string
+		seen      = map[string]bool{}
+	)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, relation := range strings.Split(value, ",") {
+		relation = strings.TrimSpace(relation)
+		if seen[relation] {
+			continue
+		}
+
+		ok := false
+		for _, name := range allowed {
+			if relation == name {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot expand %q, allowed: %s", relation, strings.Join(allowed, ", "))
+		}
+
+		seen[relation] = true
+		relations = append(relations, relation)
+	}
+
+	return relations, nil
+}
+
+// Fetch loads the distinct ids of every relation concurrently running at most limit calls at a time,
+// a failed lookup is reported and leaves the other results intact
+func Fetch(ctx context.Context, loaders map[string]Loader, ids map[string][]string, limit int) (Results, []Error) {
+	type job struct {
+		relation, id string
+	}
+
+	var (
+		jobs    []job
+		results = Results{}
+		errs    []Error
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, limit)
+	)
+
+	for relation, list := range ids {
+		results[relation] = map[string]interface{}{}
+		seen := map[string]bool{}
+		for _, id := range list {
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			jobs = append(jobs, job{relation: relation, id: id})
+		}
+	}
+
+	for _, j := range jobs {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func(j job) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			resource, err := loaders[j.relation](ctx, j.id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, Error{Relation: j.relation, ID: j.id, Message: err.Error()})
+				return
+			}
+			results[j.relation][j.id] = resource
+		}(j)
+	}
+	wg.Wait()
+
+	sort.Slice(errs, func(i, k int) bool {
+		if errs[i].Relation != errs[k].Relation {
+			return errs[i].Relation < errs[k].Relation
+		}
+		return errs[i].ID < errs[k].ID
+	})
+
+	return results, errs
+}
@@ -0,0 +1,161 @@
+package fieldmask
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// node is a level of the mask, a nil child selects the whole field
+type node map[string]node
+
+// Mask is a validated set of dotted field paths like "id", "client_id" or "checklist.name"
+type Mask struct {
+	paths []string
+	proto []string
+	tree  node
+}
+
+// Parse validates the comma separated paths against the message, paths starting with one of the
+// extension prefixes, like "expanded.client", are validated against the extension message instead
+func Parse(value string, message protoreflect.MessageDescriptor, extensions map[string]protoreflect.MessageDescriptor) (*Mask, error) {
+	mask := &Mask{tree: node{}}
+
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		isProto, err := validate(path, message, extensions)
+		if err != nil {
+			return nil, err
+		}
+
+		mask.paths = append(mask.paths, path)
+		if isProto {
+			mask.proto = append(mask.proto, path)
+		}
+		mask.tree.add(strings.Split(path, "."))
+	}
+
+	if len(mask.paths) == 0 {
+		return nil, fmt.Errorf("fields must list at least one field")
+	}
+
+	return mask, nil
+}
+
+// Paths returns the requested paths
+func (m *Mask) Paths() []string {
+	return m.paths
+}
+
+// FieldMask returns the requested paths of the message together with the fields the caller needs itself
+func (m *Mask) FieldMask(required ...string) *fieldmaskpb.FieldMask {
+	seen := map[string]bool{}
+	paths := make([]string, 0, len(m.proto)+len(required))
+
+	for _, path := range append(append([]string(nil), m.proto...), required...) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+// Project keeps only the masked fields of a decoded JSON value, lists are projected item by item
+func (m *Mask) Project(value interface{}) interface{} {
+	return m.tree.project(value)
+}
+
+func (n node) add(segments []string) {
+	child, ok := n[segments[0]]
+	if ok && child == nil {
+		// the whole field is already selected
+		return
+	}
+
+	if len(segments) == 1 {
+		n[segments[0]] = nil
+		return
+	}
+
+	if child == nil {
+		child = node{}
+		n[segments[0]] = child
+	}
+	child.add(segments[1:])
+}
+
+func (n node) project(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		projected := make(map[string]interface{}, len(n))
+		for key, child := range n {
+			field, ok := v[key]
+			if !ok {
+				continue
+			}
+			if child == nil {
+				projected[key] = field
+			} else {
+				projected[key] = child.project(field)
+			}
+		}
+		return projected
+	case []interface{}:
+		projected := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			projected = append(projected, n.project(item))
+		}
+		return projected
+	default:
+		return value
+	}
+}
+
+// validate walks the path through the message fields and reports whether it belongs to the message itself
+func validate(path string, message protoreflect.MessageDescriptor, extensions map[string]protoreflect.MessageDescriptor) (bool, error) {
+	for prefix, extension := range extensions {
+		switch {
+		case path == prefix || strings.HasPrefix(prefix, path+"."):
+			return false, nil
+		case strings.HasPrefix(path, prefix+"."):
+			return false, walk(strings.TrimPrefix(path, prefix+"."), extension, path)
+		}
+	}
+
+	return true, walk(path, message, path)
+}
+
+func walk(path string, message protoreflect.MessageDescriptor, full string) error {
+	segments := strings.Split(path, ".")
+
+	for i, segment := range segments {
+		field := message.Fields().ByName(protoreflect.Name(segment))
+		if field == nil && segment == full {
+			return fmt.Errorf("unknown field %q", segment)
+		}
+		if field == nil {
+			return fmt.Errorf("unknown field %q in %s", segment, full)
+		}
+
+		if i == len(segments)-1 {
+			return nil
+		}
+
+		if field.Kind() != protoreflect.MessageKind || field.IsMap() {
+			return fmt.Errorf("field %q in %s has no nested fields", segment, full)
+		}
+		message = field.Message()
+	}
+
+	return nil
+}
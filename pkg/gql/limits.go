@@ -0,0 +1,172 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bound the cost of a query before it runs
+type Limits struct {
+	// MaxDepth is the deepest nesting of selections, the fields of the root query are at depth 1
+	MaxDepth int
+	// MaxComplexity is the highest number of fields the query may resolve, the fields under a list
+	// count once per item
+	MaxComplexity int
+	// ListSize is the number of items a list is expected to have when the query sets no limit argument
+	ListSize int
+}
+
+// Check measures the operation of the validated document against the limits
+func (l Limits) Check(schema graphql.Schema, document *ast.Document, operationName string, variables map[string]interface{}) error {
+	var (
+		operation *ast.OperationDefinition
+		fragments = map[string]*ast.FragmentDefinition{}
+	)
+
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		}
+	}
+	if operation == nil {
+		return fmt.Errorf("operation %q is not in the query", operationName)
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	m := measure{schema: schema, fragments: fragments, variables: variables}
+	depth, complexity := m.selections(root, operation.SelectionSet, l.ListSize)
+
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity)
+	}
+
+	return nil
+}
+
+type measure struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selections returns the depth and the complexity of the selection set on the parent type,
+// listSize is the page size of the closest field that sets a limit
+func (m measure) selections(parent graphql.Type, set *ast.SelectionSet, listSize int) (int, int) {
+	var depth, complexity int
+
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c = m.field(parent, selection, listSize)
+		case *ast.InlineFragment:
+			d, c = m.selections(m.condition(parent, selection.TypeCondition), selection.SelectionSet, listSize)
+		case *ast.FragmentSpread:
+			if fragment, ok := m.fragments[selection.Name.Value]; ok {
+				d, c = m.selections(m.condition(parent, fragment.TypeCondition), fragment.SelectionSet, listSize)
+			}
+		}
+
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+func (m measure) field(parent graphql.Type, field *ast.Field, listSize int) (int, int) {
+	object, ok := parent.(*graphql.Object)
+	if !ok || field.SelectionSet == nil {
+		return 1, 1
+	}
+
+	definition, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	if limit, ok := m.limit(field); ok {
+		listSize = limit
+	}
+
+	output, isList := unwrap(definition.Type)
+	depth, complexity := m.selections(output, field.SelectionSet, listSize)
+	if isList {
+		complexity *= listSize
+	}
+
+	return depth + 1, complexity + 1
+}
+
+// limit reads the limit argument of the field, given inline or as a variable
+func (m measure) limit(field *ast.Field) (int, bool) {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			limit, err := strconv.Atoi(value.Value)
+			return limit, err == nil && limit > 0
+		case *ast.Variable:
+			switch limit := m.variables[value.Name.Value].(type) {
+			case float64:
+				return int(limit), limit > 0
+			case int:
+				return limit, limit > 0
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func (m measure) condition(parent graphql.Type, condition *ast.Named) graphql.Type {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if named := m.schema.Type(condition.Name.Value); named != nil {
+		return named
+	}
+	return parent
+}
+
+// unwrap strips non null wrappers and reports whether the type is a list
+func unwrap(output graphql.Type) (graphql.Type, bool) {
+	var isList bool
+
+	for {
+		switch wrapped := output.(type) {
+		case *graphql.NonNull:
+			output = wrapped.OfType
+		case *graphql.List:
+			isList = true
+			output = wrapped.OfType
+		default:
+			return output, isList
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package gql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Builder turns proto messages into GraphQL object types, the fields keep their proto names
+// so a query selects the same keys the REST responses have
+type Builder struct {
+	objects   map[protoreflect.FullName]*graphql.Object
+	names     map[string]protoreflect.FullName
+	relations map[protoreflect.FullName]graphql.Fields
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		objects:   map[protoreflect.FullName]*graphql.Object{},
+		names:     map[string]protoreflect.FullName{},
+		relations: map[protoreflect.FullName]graphql.Fields{},
+	}
+}
+
+// Relate adds a field that is not part of the message, like the client of an order, it may be called
+// after the object was built as long as the schema is not created yet
+func (b *Builder) Relate(message protoreflect.MessageDescriptor, name string, field *graphql.Field) {
+	if b.relations[message.FullName()] == nil {
+		b.relations[message.FullName()] = graphql.Fields{}
+	}
+	b.relations[message.FullName()][name] = field
+}
+
+// Object returns the GraphQL type of the message, nested messages get their own types
+func (b *Builder) Object(message protoreflect.MessageDescriptor) *graphql.Object {
+	if object, ok := b.objects[message.FullName()]; ok {
+		return object
+	}
+
+	object := graphql.NewObject(graphql.ObjectConfig{
+		Name: b.typeName(message),
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := graphql.Fields{}
+
+			list := message.Fields()
+			for i := 0; i < list.Len(); i++ {
+				field := list.Get(i)
+				fields[string(field.Name())] = &graphql.Field{
+					Type:    b.fieldType(field),
+					Resolve: resolveField(field),
+				}
+			}
+
+			for name, field := range b.relations[message.FullName()] {
+				fields[name] = field
+			}
+
+			return fields
+		}),
+	})
+	b.objects[message.FullName()] = object
+
+	return object
+}
+
+// typeName is the message name, prefixed with its package when another package has a message with that name
+func (b *Builder) typeName(message protoreflect.MessageDescriptor) string {
+	name := string(message.Name())
+	if owner, ok := b.names[name]; ok && owner != message.FullName() {
+		name = fmt.Sprintf("%s_%s", message.ParentFile().Package(), name)
+	}
+	b.names[name] = message.FullName()
+	return name
+}
+
+func (b *Builder) fieldType(field protoreflect.FieldDescriptor) graphql.Output {
+	var output graphql.Output
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		output = graphql.Boolean
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		output = graphql.Int
+	case protoreflect.FloatKind, protoreflect.DoubleKind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		output = graphql.Float
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if field.IsMap() {
+			// maps have no GraphQL counterpart, they are returned as a list of "key=value" strings
+			output = graphql.String
+		} else {
+			output = b.Object(field.Message())
+		}
+	default:
+		// strings, bytes and enum names
+		output = graphql.String
+	}
+
+	if field.IsList() || field.IsMap() {
+		return graphql.NewList(output)
+	}
+	return output
+}
+
+// resolveField reads the field from the proto message the parent resolver returned
+func resolveField(field protoreflect.FieldDescriptor) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		message, ok := p.Source.(proto.Message)
+		if !ok || message == nil {
+			return nil, nil
+		}
+
+		reflected := message.ProtoReflect()
+		if !reflected.IsValid() {
+			return nil, nil
+		}
+
+		value := reflected.Get(field)
+
+		switch {
+		case field.IsMap():
+			var entries []interface{}
+			value.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				entries = append(entries, fmt.Sprintf("%v=%v", key.Interface(), value.Interface()))
+				return true
+			})
+			return entries, nil
+		case field.IsList():
+			list := value.List()
+			items := make([]interface{}, 0, list.Len())
+			for i := 0; i < list.Len(); i++ {
+				items = append(items, convert(field, list.Get(i)))
+			}
+			return items, nil
+		case field.Kind() == protoreflect.MessageKind && !reflected.Has(field):
+			return nil, nil
+		}
+
+		return convert(field, value), nil
+	}
+}
+
+func convert(field protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		if enum := field.Enum().Values().ByNumber(value.Enum()); enum != nil {
+			return string(enum.Name())
+		}
+		return fmt.Sprint(value.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return value.Message().Interface()
+	case protoreflect.BytesKind:
+		return string(value.Bytes())
+	}
+	return value.Interface()
+}
@@ -1,11 +1,9 @@
 package helper
 
 import (
+	"Projects/Car24/car24_api_gateway/pkg/dateparse"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"strconv"
-	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -22,63 +20,14 @@ func ConvertMapToStruct(inputMap map[string]interface{}) (*structpb.Struct, erro
 	return outputStruct, err
 }
 
+// ConvertStringToDate converts a date written with month names, like "15 марта 2023" or "15-mart", to dd.mm.yyyy
 func ConvertStringToDate(text string) (date string, err error) {
-	months := map[string]string{
-		"январ":   "01",
-		"феврал":  "02",
-		"март":    "03",
-		"апрел":   "04",
-		"май":     "05",
-		"июн":     "06",
-		"июл":     "07",
-		"август":  "08",
-		"сентябр": "09",
-		"октябр":  "10",
-		"ноябр":   "11",
-		"декабр":  "12",
-	}
-
-	texts := strings.Split(text, " ")
-
-	for key, val := range months {
-		if strings.Contains(text, key) {
-			var (
-				year int = 2023
-				day  int
-			)
-
-			for _, word := range texts {
-				if _, err := strconv.Atoi(word); err != nil {
-					continue
-				}
-
-				if len(word) == 4 {
-					year, err = strconv.Atoi(word)
-					if err != nil {
-						return "", err
-					}
-				} else if len(word) <= 2 {
-					day, err = strconv.Atoi(word)
-					if err != nil {
-						return "", err
-					}
-				}
-			}
-
-			date = fmt.Sprintf("%d.%s.%d", day, val, year)
-			if day < 10 {
-				date = fmt.Sprintf("0%d.%s.%d", day, val, year)
-			}
-
-			break
-		}
-	}
-
-	if date == "" {
-		return date, errors.New("Not found months")
+	r, err := dateparse.Parse(text, time.Now())
+	if err != nil {
+		return "", err
 	}
 
-	return date, nil
+	return r.From.Format("02.01.2006"), nil
 }
 
 func StructToProto(p interface{}, s interface{}) error {
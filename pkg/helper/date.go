@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateTimeLayout is the layout the backend services use for timestamps
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+var dateLayouts = []string{
+	DateTimeLayout,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04",
+	"02.01.2006",
+}
+
+// ParseDateTime parses the date formats stored by the backend services
+func ParseDateTime(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format %q", value)
+}
@@ -0,0 +1,65 @@
+package lifecycle
+
+import "fmt"
+
+// Order statuses
+const (
+	StatusDraft     = "draft"
+	StatusConfirmed = "confirmed"
+	StatusActive    = "active"
+	StatusReturned  = "returned"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusOverdue   = "overdue"
+)
+
+// transitions lists the statuses an order may move to from the given one
+var transitions = map[string][]string{
+	StatusDraft:     {StatusConfirmed, StatusCancelled},
+	StatusConfirmed: {StatusActive, StatusCancelled},
+	StatusActive:    {StatusReturned, StatusOverdue},
+	StatusOverdue:   {StatusReturned},
+	StatusReturned:  {StatusCompleted},
+	StatusCompleted: {},
+	StatusCancelled: {},
+}
+
+// IsValidStatus ...
+func IsValidStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// Normalize treats an empty status of orders created before the lifecycle existed as draft
+func Normalize(status string) string {
+	if status == "" {
+		return StatusDraft
+	}
+	return status
+}
+
+// CanTransition reports whether an order may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[Normalize(from)] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedTransitions returns the statuses reachable from the given one
+func AllowedTransitions(from string) []string {
+	return transitions[Normalize(from)]
+}
+
+// ValidateTransition returns an error describing why the transition is not allowed
+func ValidateTransition(from, to string) error {
+	if !IsValidStatus(to) {
+		return fmt.Errorf("unknown order status %q", to)
+	}
+	if !CanTransition(from, to) {
+		return fmt.Errorf("order cannot move from %q to %q, allowed: %v", Normalize(from), to, AllowedTransitions(from))
+	}
+	return nil
+}
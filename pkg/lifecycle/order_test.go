@@ -0,0 +1,93 @@
+package lifecycle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  string
+		to    string
+		valid bool
+	}{
+		{name: "confirm a draft", from: StatusDraft, to: StatusConfirmed, valid: true},
+		{name: "cancel a draft", from: StatusDraft, to: StatusCancelled, valid: true},
+		{name: "confirm an order without a status", from: "", to: StatusConfirmed, valid: true},
+		{name: "pick up a confirmed order", from: StatusConfirmed, to: StatusActive, valid: true},
+		{name: "cancel a confirmed order", from: StatusConfirmed, to: StatusCancelled, valid: true},
+		{name: "return an active order", from: StatusActive, to: StatusReturned, valid: true},
+		{name: "an active order becomes overdue", from: StatusActive, to: StatusOverdue, valid: true},
+		{name: "return an overdue order", from: StatusOverdue, to: StatusReturned, valid: true},
+		{name: "complete a returned order", from: StatusReturned, to: StatusCompleted, valid: true},
+		{name: "pick up a draft", from: StatusDraft, to: StatusActive},
+		{name: "return a confirmed order", from: StatusConfirmed, to: StatusReturned},
+		{name: "cancel an active order", from: StatusActive, to: StatusCancelled},
+		{name: "overdue back to active", from: StatusOverdue, to: StatusActive},
+		{name: "complete an active order", from: StatusActive, to: StatusCompleted},
+		{name: "to the same status", from: StatusConfirmed, to: StatusConfirmed},
+		{name: "back to draft", from: StatusConfirmed, to: StatusDraft},
+		{name: "from completed", from: StatusCompleted, to: StatusActive},
+		{name: "reopen a cancelled order", from: StatusCancelled, to: StatusDraft},
+		{name: "cancel a completed order", from: StatusCompleted, to: StatusCancelled},
+		{name: "unknown target", from: StatusDraft, to: "archived"},
+		{name: "unknown origin", from: "archived", to: StatusConfirmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.valid {
+				t.Fatalf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.valid)
+			}
+			if err := ValidateTransition(tt.from, tt.to); (err == nil) != tt.valid {
+				t.Fatalf("ValidateTransition(%q, %q) = %v, want valid %v", tt.from, tt.to, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestAllowedTransitions(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		want []string
+	}{
+		{name: "draft", from: StatusDraft, want: []string{StatusConfirmed, StatusCancelled}},
+		{name: "no status is draft", from: "", want: []string{StatusConfirmed, StatusCancelled}},
+		{name: "active", from: StatusActive, want: []string{StatusReturned, StatusOverdue}},
+		{name: "completed is terminal", from: StatusCompleted, want: []string{}},
+		{name: "cancelled is terminal", from: StatusCancelled, want: []string{}},
+		{name: "unknown", from: "archived"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AllowedTransitions(tt.from); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("AllowedTransitions(%q) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		valid  bool
+	}{
+		{status: StatusDraft, valid: true},
+		{status: StatusOverdue, valid: true},
+		{status: StatusCancelled, valid: true},
+		{status: ""},
+		{status: "Active"},
+		{status: "archived"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := IsValidStatus(tt.status); got != tt.valid {
+				t.Fatalf("IsValidStatus(%q) = %v, want %v", tt.status, got, tt.valid)
+			}
+		})
+	}
+}
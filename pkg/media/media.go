@@ -0,0 +1,222 @@
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The media types the gateway reads and writes
+const (
+	JSON     = "application/json"
+	Protobuf = "application/x-protobuf"
+	MsgPack  = "application/msgpack"
+)
+
+// ErrUnsupported is returned for a media type the gateway does not read, or for data that has no
+// encoding in the media type, like a gateway model as protobuf
+var ErrUnsupported = errors.New("unsupported media type")
+
+// supported are the media types in the order they are preferred when the client accepts several equally
+var supported = []string{JSON, Protobuf, MsgPack}
+
+// aliases are the names the clients use for the media types
+var aliases = map[string]string{
+	"application/json":                JSON,
+	"application/x-protobuf":          Protobuf,
+	"application/protobuf":            Protobuf,
+	"application/vnd.google.protobuf": Protobuf,
+	"application/msgpack":             MsgPack,
+	"application/x-msgpack":           MsgPack,
+	"application/vnd.msgpack":         MsgPack,
+}
+
+var msgpackHandle = func() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.RawToString = true
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return handle
+}()
+
+// Type returns the media type of the Content-Type header, a request without it is JSON
+func Type(contentType string) (string, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return JSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrUnsupported
+	}
+
+	if name, ok := aliases[mediaType]; ok {
+		return name, nil
+	}
+	// application/merge-patch+json and the like
+	if strings.HasSuffix(mediaType, "+json") {
+		return JSON, nil
+	}
+
+	return "", ErrUnsupported
+}
+
+// Negotiate returns the media type the Accept header prefers, JSON when it is missing or accepts none
+// of the supported ones. An exact media type wins over a wildcard of the same quality
+func Negotiate(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return JSON
+	}
+
+	var (
+		best        = JSON
+		bestQuality = -1.0
+		bestExact   = false
+	)
+	for _, mediaType := range supported {
+		quality, exact := acceptQuality(accept, mediaType)
+		if quality <= 0 {
+			continue
+		}
+		if quality > bestQuality || (quality == bestQuality && exact && !bestExact) {
+			best, bestQuality, bestExact = mediaType, quality, exact
+		}
+	}
+
+	return best
+}
+
+// acceptQuality is the quality the Accept header gives the media type, taken from its most specific
+// range, and whether that range names the media type
+func acceptQuality(accept, mediaType string) (quality float64, exact bool) {
+	specificity := -1
+
+	for _, part := range strings.Split(accept, ",") {
+		name, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if alias, ok := aliases[name]; ok {
+			name = alias
+		}
+
+		var level int
+		switch {
+		case name == mediaType:
+			level = 2
+		case name == "application/*":
+			level = 1
+		case name == "*/*":
+			level = 0
+		default:
+			continue
+		}
+		if level < specificity {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		specificity, quality, exact = level, q, level == 2
+	}
+
+	return quality, exact
+}
+
+// Marshal encodes the data in the media type. Protobuf only has the protobuf messages and strings,
+// the messages of the errors, for other data it returns ErrUnsupported
+func Marshal(mediaType string, data interface{}) ([]byte, error) {
+	switch mediaType {
+	case JSON:
+		return json.Marshal(data)
+	case Protobuf:
+		switch value := data.(type) {
+		case nil:
+			return nil, nil
+		case proto.Message:
+			return proto.Marshal(value)
+		case string:
+			return proto.Marshal(wrapperspb.String(value))
+		}
+		return nil, ErrUnsupported
+	case MsgPack:
+		var body []byte
+		err := codec.NewEncoderBytes(&body, msgpackHandle).Encode(numbers(data))
+		return body, err
+	}
+
+	return nil, ErrUnsupported
+}
+
+// Unmarshal decodes the body of the media type into v, protobuf needs v to be a protobuf message
+func Unmarshal(mediaType string, body []byte, v interface{}) error {
+	switch mediaType {
+	case JSON:
+		return json.Unmarshal(body, v)
+	case Protobuf:
+		message, ok := v.(proto.Message)
+		if !ok {
+			return ErrUnsupported
+		}
+		return proto.Unmarshal(body, message)
+	case MsgPack:
+		return codec.NewDecoderBytes(body, msgpackHandle).Decode(v)
+	}
+
+	return ErrUnsupported
+}
+
+// ToJSON converts a MessagePack or JSON body to JSON for the decoders that only read JSON
+func ToJSON(mediaType string, body []byte) ([]byte, error) {
+	if mediaType == JSON {
+		return body, nil
+	}
+	if mediaType != MsgPack {
+		return nil, ErrUnsupported
+	}
+
+	var value interface{}
+	err := Unmarshal(MsgPack, body, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// numbers turns the json.Number of data decoded from JSON, like the projected fields, into numbers,
+// MessagePack would write them as strings
+func numbers(data interface{}) interface{} {
+	switch value := data.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+		return value.String()
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = numbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = numbers(item)
+		}
+	}
+
+	return data
+}
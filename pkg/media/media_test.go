@@ -0,0 +1,66 @@
+package media
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "missing", accept: "", want: JSON},
+		{name: "json", accept: "application/json", want: JSON},
+		{name: "protobuf", accept: "application/x-protobuf", want: Protobuf},
+		{name: "protobuf alias", accept: "application/vnd.google.protobuf", want: Protobuf},
+		{name: "msgpack alias", accept: "application/x-msgpack", want: MsgPack},
+		{name: "wildcard", accept: "*/*", want: JSON},
+		{name: "exact wins over the wildcard", accept: "*/*, application/msgpack", want: MsgPack},
+		{name: "exact wins over the application wildcard", accept: "application/*, application/x-protobuf", want: Protobuf},
+		{name: "higher quality wins", accept: "application/json;q=0.5, application/msgpack;q=0.9", want: MsgPack},
+		{name: "specific range overrides the wildcard", accept: "*/*;q=1, application/json;q=0.1", want: Protobuf},
+		{name: "none supported", accept: "text/html", want: JSON},
+		{name: "refused", accept: "application/json;q=0, application/x-protobuf", want: Protobuf},
+		{name: "invalid quality skipped", accept: "application/msgpack;q=x, application/json", want: JSON},
+		{name: "browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: JSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.accept); got != tt.want {
+				t.Fatalf("Negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+		err         error
+	}{
+		{name: "missing", contentType: "", want: JSON},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", want: JSON},
+		{name: "merge patch", contentType: "application/merge-patch+json", want: JSON},
+		{name: "protobuf", contentType: "application/protobuf", want: Protobuf},
+		{name: "msgpack", contentType: "application/vnd.msgpack", want: MsgPack},
+		{name: "form", contentType: "application/x-www-form-urlencoded", err: ErrUnsupported},
+		{name: "malformed", contentType: "application/", err: ErrUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Type(tt.contentType)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Fatalf("Type(%q) = %q, want %q", tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/query"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Version is the OpenAPI version of the generated documents
+const Version = "3.1.0"
+
+// Document is an OpenAPI 3.1 document
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security,omitempty"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the schemas the operations refer to
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is the way callers authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Operation is an operation of the document, keyed by path and lower case method
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the JSON body of an operation
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is the answer of an operation with a status code
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header is a response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Endpoint documents a route, it is what the handlers declare about themselves
+type Endpoint struct {
+	// ID is the operationId, unique in the document
+	ID          string
+	Summary     string
+	Description string
+	Tag         string
+	// Hidden routes, like the documentation pages, are not part of the API
+	Hidden bool
+	// Params are the query and header parameters, the path parameters come from the route
+	Params []Parameter
+	// Body is a proto message, a message descriptor, a *Schema or a Go value of the type the JSON
+	// body is decoded into, nil when the route reads no body
+	Body         interface{}
+	BodyOptional bool
+	// Status is the success code, 200 when not set
+	Status int
+	// Result is the success body like Body, nil when the route answers without one
+	Result interface{}
+	// Headers are the headers of the success response
+	Headers map[string]string
+}
+
+// Key is the key of a route in the endpoints, like "GET /order/:id"
+func Key(method, path string) string {
+	return method + " " + path
+}
+
+// Build documents the routes with their endpoints, the routes without an endpoint are left out
+func Build(info Info, routes gin.RoutesInfo, endpoints map[string]Endpoint) *Document {
+	var (
+		components = schemas{}
+		doc        = &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]map[string]*Operation{},
+			Components: Components{
+				SecuritySchemes: map[string]*SecurityScheme{
+					"bearer": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				},
+			},
+			// the token is optional, requests without one act with the default role
+			Security: []map[string][]string{{}, {"bearer": {}}},
+		}
+	)
+
+	for _, route := range routes {
+		endpoint, ok := endpoints[Key(route.Method, route.Path)]
+		if !ok || endpoint.Hidden {
+			continue
+		}
+
+		template, variables := Path(route.Path)
+		if doc.Paths[template] == nil {
+			doc.Paths[template] = map[string]*Operation{}
+		}
+		doc.Paths[template][strings.ToLower(route.Method)] = endpoint.operation(components, variables)
+	}
+
+	doc.Components.Schemas = components
+	return doc
+}
+
+// Missing returns the routes that are not hidden and have no operation in the document, or one
+// without an operationId
+func Missing(doc *Document, routes gin.RoutesInfo, endpoints map[string]Endpoint) []string {
+	var missing []string
+
+	for _, route := range routes {
+		if endpoints[Key(route.Method, route.Path)].Hidden {
+			continue
+		}
+
+		template, _ := Path(route.Path)
+		operation := doc.Paths[template][strings.ToLower(route.Method)]
+		if operation == nil || operation.OperationID == "" {
+			missing = append(missing, Key(route.Method, route.Path))
+		}
+	}
+
+	sort.Strings(missing)
+	return missing
+}
+
+// Path turns a gin path into a path template, /order/:id becomes /order/{id}
+func Path(route string) (string, []string) {
+	var (
+		segments  = strings.Split(route, "/")
+		variables []string
+	)
+
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			variables = append(variables, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), variables
+}
+
+func (e Endpoint) operation(components schemas, variables []string) *Operation {
+	operation := &Operation{
+		OperationID: e.ID,
+		Summary:     e.Summary,
+		Description: e.Description,
+		Responses:   map[string]*Response{},
+	}
+	if e.Tag != "" {
+		operation.Tags = []string{e.Tag}
+	}
+
+	for _, name := range variables {
+		parameter := Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}}
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			parameter.Schema.Format = "uuid"
+		}
+		operation.Parameters = append(operation.Parameters, parameter)
+	}
+	operation.Parameters = append(operation.Parameters, e.Params...)
+
+	if e.Body != nil {
+		operation.RequestBody = &RequestBody{
+			Required: !e.BodyOptional,
+			Content:  map[string]*MediaType{"application/json": {Schema: components.of(e.Body)}},
+		}
+	}
+
+	status := e.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	success := &Response{Description: http.StatusText(status)}
+	if e.Result != nil {
+		success.Content = map[string]*MediaType{"application/json": {Schema: components.of(e.Result)}}
+	}
+	for name, description := range e.Headers {
+		if success.Headers == nil {
+			success.Headers = map[string]*Header{}
+		}
+		success.Headers[name] = &Header{Description: description, Schema: &Schema{Type: "string"}}
+	}
+	operation.Responses[fmt.Sprint(status)] = success
+
+	// failures answer with the error message, or an object like the rejected parameters
+	operation.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     map[string]*MediaType{"application/json": {Schema: &Schema{}}},
+	}
+
+	return operation
+}
+
+// Field is the schema of a scalar proto field, for the parameters read from the url
+func Field(field protoreflect.FieldDescriptor) *Schema {
+	return schemas{}.field(field)
+}
+
+// Query is a query parameter
+func Query(name string, schema *Schema, description string) Parameter {
+	return Parameter{Name: name, In: "query", Schema: schema, Description: description}
+}
+
+// HeaderParam is an optional request header
+func HeaderParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "header", Schema: &Schema{Type: "string"}, Description: description}
+}
+
+// String is the schema of a text parameter
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+// Integer is the schema of a whole number parameter
+func Integer() *Schema {
+	return &Schema{Type: "integer"}
+}
+
+// Boolean is the schema of a true or false parameter
+func Boolean() *Schema {
+	return &Schema{Type: "boolean"}
+}
+
+// Filters are the filter and sort parameters of a list with the query schema, field=value and
+// field[op]=value for every operator the field accepts
+func Filters(schema query.Schema) []Parameter {
+	var parameters []Parameter
+
+	for _, name := range schema.Fields() {
+		field := schema[name]
+
+		for _, op := range field.Ops() {
+			parameter := Query(name, filterSchema(field), fmt.Sprintf("%s %s", name, op))
+			if op != query.OpEq {
+				parameter.Name = fmt.Sprintf("%s[%s]", name, op)
+			}
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	if sortable := schema.Sortable(); len(sortable) > 0 {
+		parameters = append(parameters, Query("sort", String(),
+			fmt.Sprintf("comma separated field:asc or field:desc, by %s, the newest first when not given", strings.Join(sortable, ", "))))
+	}
+
+	return parameters
+}
+
+func filterSchema(field query.Field) *Schema {
+	switch field.Type {
+	case query.TypeUUID:
+		return &Schema{Type: "string", Format: "uuid"}
+	case query.TypeBool:
+		return Boolean()
+	case query.TypeNumber:
+		return &Schema{Type: "number"}
+	case query.TypeDate:
+		return &Schema{Type: "string", Description: "a date like 2024-05-01, 15 марта or ertaga"}
+	case query.TypeEnum:
+		return &Schema{Type: "string", Description: "one or comma separated: " + strings.Join(field.Values, ", ")}
+	}
+	return String()
+}
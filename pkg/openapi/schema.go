@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Schema is a JSON Schema 2020-12 object as OpenAPI 3.1 uses it, only the keywords the gateway needs
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawType       = reflect.TypeOf(json.RawMessage{})
+	protoType     = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemas collects the named schemas of the document while the operations are added
+type schemas map[string]*Schema
+
+// of returns the schema of a body: a proto message, a message descriptor or a Go value that is
+// encoded with encoding/json, structs and messages become references to components
+func (s schemas) of(value interface{}) *Schema {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case *Schema:
+		return value
+	case protoreflect.MessageDescriptor:
+		return s.message(value)
+	}
+	return s.goType(reflect.TypeOf(value))
+}
+
+// message returns the reference to the message schema, the fields keep their proto names like the
+// generated json tags and protojson with original names do
+func (s schemas) message(message protoreflect.MessageDescriptor) *Schema {
+	switch message.FullName() {
+	case "google.protobuf.Struct":
+		return &Schema{Type: "object"}
+	case "google.protobuf.Value":
+		return &Schema{}
+	case "google.protobuf.FieldMask":
+		return &Schema{Type: "string", Description: "comma separated field paths"}
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	name := string(message.FullName())
+	if _, ok := s[name]; !ok {
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		s[name] = schema
+
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			schema.Properties[string(fields.Get(i).Name())] = s.field(fields.Get(i))
+		}
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (s schemas) field(field protoreflect.FieldDescriptor) *Schema {
+	if field.IsMap() {
+		return &Schema{Type: "object", AdditionalProperties: s.field(field.MapValue())}
+	}
+
+	var item *Schema
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		item = s.message(field.Message())
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		item = &Schema{Type: "string"}
+		for i := 0; i < values.Len(); i++ {
+			item.Enum = append(item.Enum, string(values.Get(i).Name()))
+		}
+	case protoreflect.BoolKind:
+		item = &Schema{Type: "boolean"}
+	case protoreflect.FloatKind:
+		item = &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		item = &Schema{Type: "number", Format: "double"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		item = &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		item = &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.BytesKind:
+		item = &Schema{Type: "string", Format: "byte"}
+	default:
+		item = &Schema{Type: "string"}
+	}
+
+	if field.IsList() {
+		return &Schema{Type: "array", Items: item}
+	}
+	return item
+}
+
+// goType describes the JSON encoding/json produces for the type, a field is required when it has a
+// binding:"required" tag, nil slices, maps and pointers to scalars may be null
+func (s schemas) goType(t reflect.Type) *Schema {
+	if t.Implements(protoType) {
+		return s.message(reflect.Zero(t).Interface().(proto.Message).ProtoReflect().Descriptor())
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawType:
+		return &Schema{}
+	}
+
+	if t.Implements(marshalerType) {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.goType(t.Elem())
+		if schema.Ref == "" {
+			schema.Type = nullable(schema.Type)
+		}
+		return schema
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: nullable("array"), Items: s.goType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: nullable("object"), AdditionalProperties: s.goType(t.Elem())}
+	case reflect.Struct:
+		return s.goStruct(t)
+	}
+
+	panic(fmt.Sprintf("openapi: %s cannot be described", t))
+}
+
+func (s schemas) goStruct(t reflect.Type) *Schema {
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	if t.Name() == "" {
+		return s.properties(t, &Schema{Type: "object", Properties: map[string]*Schema{}})
+	}
+
+	if _, ok := s[name]; !ok {
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		s[name] = schema
+		s.properties(t, schema)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// properties adds the fields of the struct, the fields of embedded structs and messages are promoted
+func (s schemas) properties(t reflect.Type, schema *Schema) *Schema {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := s.goType(field.Type)
+			if embedded.Ref != "" {
+				embedded = s[strings.TrimPrefix(embedded.Ref, "#/components/schemas/")]
+			}
+			for key, property := range embedded.Properties {
+				schema.Properties[key] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.goType(field.Type)
+		if binding := field.Tag.Get("binding"); binding != "" {
+			limits(property, binding)
+			if strings.Contains(","+binding+",", ",required,") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// limits copies the min and max of the binding tag to the schema of a number
+func limits(schema *Schema, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		var value float64
+
+		switch {
+		case strings.HasPrefix(rule, "min="):
+			if _, err := fmt.Sscan(strings.TrimPrefix(rule, "min="), &value); err == nil {
+				schema.Minimum = &value
+			}
+		case strings.HasPrefix(rule, "max="):
+			if _, err := fmt.Sscan(strings.TrimPrefix(rule, "max="), &value); err == nil {
+				schema.Maximum = &value
+			}
+		}
+	}
+}
+
+func nullable(kind interface{}) interface{} {
+	switch kind := kind.(type) {
+	case string:
+		return []string{kind, "null"}
+	case []string:
+		return kind
+	}
+	return nil
+}
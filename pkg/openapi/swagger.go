@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// swagger is the part of a swagger 2.0 document, like the one swag generates, that names the operations
+type swagger struct {
+	Paths map[string]map[string]struct {
+		OperationID string   `json:"operationId"`
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+	} `json:"paths"`
+}
+
+// Describe fills the id, summary, description and tag of the endpoints from the operations of the
+// swagger 2.0 document, so they are written once in the handler annotations. What an endpoint already
+// has is kept, the endpoints the document has no operation for are left as they are
+func Describe(endpoints map[string]Endpoint, swaggerJSON []byte) error {
+	var doc swagger
+	err := json.Unmarshal(swaggerJSON, &doc)
+	if err != nil {
+		return fmt.Errorf("read swagger document: %w", err)
+	}
+
+	for key, endpoint := range endpoints {
+		method, path, _ := strings.Cut(key, " ")
+		template, _ := Path(path)
+
+		operation, ok := doc.Paths[template][strings.ToLower(method)]
+		if !ok {
+			continue
+		}
+
+		if endpoint.ID == "" {
+			endpoint.ID = operation.OperationID
+		}
+		if endpoint.Summary == "" {
+			endpoint.Summary = operation.Summary
+		}
+		if endpoint.Description == "" && operation.Description != operation.Summary {
+			endpoint.Description = operation.Description
+		}
+		if endpoint.Tag == "" && len(operation.Tags) > 0 {
+			endpoint.Tag = operation.Tags[0]
+		}
+		endpoints[key] = endpoint
+	}
+
+	return nil
+}
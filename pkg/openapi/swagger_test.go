@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	"testing"
+)
+
+const swaggerDoc = `{
+	"paths": {
+		"/order/{id}": {
+			"get": {"operationId": "get_order_by_id", "summary": "Get Order", "description": "Get Order", "tags": ["Order"]},
+			"post": {"operationId": "confirm_order", "summary": "Confirm Order", "description": "Move a draft order to confirmed"}
+		}
+	}
+}`
+
+func TestDescribe(t *testing.T) {
+	endpoints := map[string]Endpoint{
+		"GET /order/:id":  {},
+		"POST /order/:id": {Summary: "Confirm"},
+		"GET /car/:id":    {ID: "get_car"},
+	}
+
+	if err := Describe(endpoints, []byte(swaggerDoc)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key  string
+		want Endpoint
+	}{
+		// a description repeating the summary says nothing more
+		{key: "GET /order/:id", want: Endpoint{ID: "get_order_by_id", Summary: "Get Order", Tag: "Order"}},
+		// what the endpoint has wins
+		{key: "POST /order/:id", want: Endpoint{ID: "confirm_order", Summary: "Confirm", Description: "Move a draft order to confirmed"}},
+		{key: "GET /car/:id", want: Endpoint{ID: "get_car"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := endpoints[tt.key]
+			if got.ID != tt.want.ID || got.Summary != tt.want.Summary || got.Description != tt.want.Description || got.Tag != tt.want.Tag {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeInvalidDocument(t *testing.T) {
+	if err := Describe(map[string]Endpoint{}, []byte("{")); err == nil {
+		t.Fatal("an invalid document was read")
+	}
+}
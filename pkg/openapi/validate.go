@@ -0,0 +1,226 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Operation returns the operation of the route registered with the gin path, nil when it is not documented
+func (d *Document) Operation(method, path string) *Operation {
+	template, _ := Path(path)
+	return d.Paths[template][strings.ToLower(method)]
+}
+
+// CheckRequest returns how the request breaks the operation: missing required parameters or a body
+// that does not match the schema
+func (d *Document) CheckRequest(operation *Operation, query url.Values, header func(string) string, body []byte) []string {
+	var violations []string
+
+	for _, parameter := range operation.Parameters {
+		if !parameter.Required {
+			continue
+		}
+		switch parameter.In {
+		case "query":
+			if _, ok := query[parameter.Name]; !ok {
+				violations = append(violations, fmt.Sprintf("query parameter %s is required", parameter.Name))
+			}
+		case "header":
+			if header(parameter.Name) == "" {
+				violations = append(violations, fmt.Sprintf("header %s is required", parameter.Name))
+			}
+		}
+	}
+
+	if operation.RequestBody == nil {
+		return violations
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if operation.RequestBody.Required {
+			violations = append(violations, "request body is required")
+		}
+		return violations
+	}
+
+	if media := operation.RequestBody.Content["application/json"]; media != nil {
+		violations = append(violations, d.check("request body", media.Schema, body)...)
+	}
+
+	return violations
+}
+
+// CheckResponse returns how the response breaks the operation: a success status that is not
+// documented or a body that does not match the schema of the status
+func (d *Document) CheckResponse(operation *Operation, status int, body []byte) []string {
+	response, ok := operation.Responses[fmt.Sprint(status)]
+	if !ok {
+		if status < 400 {
+			return []string{fmt.Sprintf("status %d is not documented", status)}
+		}
+		response = operation.Responses["default"]
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	media := response.Content["application/json"]
+	if media == nil {
+		if len(bytes.TrimSpace(body)) > 0 && status != 204 {
+			return []string{fmt.Sprintf("status %d is documented without a body", status)}
+		}
+		return nil
+	}
+
+	return d.check("response body", media.Schema, body)
+}
+
+func (d *Document) check(name string, schema *Schema, body []byte) []string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []string{fmt.Sprintf("%s is not JSON: %v", name, err)}
+	}
+
+	violations := d.Validate(schema, value)
+	for i, violation := range violations {
+		violations[i] = name + " " + violation
+	}
+	return violations
+}
+
+// Validate returns where the decoded JSON value does not match the schema, numbers are json.Number or float64
+func (d *Document) Validate(schema *Schema, value interface{}) []string {
+	var violations []string
+	d.validate("$", schema, value, &violations)
+	return violations
+}
+
+func (d *Document) validate(at string, schema *Schema, value interface{}, violations *[]string) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Ref != "" {
+		referenced, ok := d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: unknown schema %s", at, schema.Ref))
+			return
+		}
+		d.validate(at, referenced, value, violations)
+		return
+	}
+
+	kind := kindOf(value)
+	if types := schemaTypes(schema.Type); len(types) > 0 && !allowed(types, kind) {
+		*violations = append(*violations, fmt.Sprintf("%s: %s is not %s", at, kind, strings.Join(types, " or ")))
+		return
+	}
+
+	if len(schema.Enum) > 0 {
+		text, _ := value.(string)
+		if !contains(schema.Enum, text) {
+			*violations = append(*violations, fmt.Sprintf("%s: %q is not one of %s", at, text, strings.Join(schema.Enum, ", ")))
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				*violations = append(*violations, fmt.Sprintf("%s: %s is required", at, name))
+			}
+		}
+
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if property, ok := schema.Properties[key]; ok {
+				d.validate(at+"."+key, property, value[key], violations)
+			} else if schema.AdditionalProperties != nil {
+				d.validate(at+"."+key, schema.AdditionalProperties, value[key], violations)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			d.validate(fmt.Sprintf("%s[%d]", at, i), schema.Items, item, violations)
+		}
+	case json.Number, float64:
+		number := toFloat(value)
+		if schema.Minimum != nil && number < *schema.Minimum {
+			*violations = append(*violations, fmt.Sprintf("%s: %v is less than %v", at, number, *schema.Minimum))
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			*violations = append(*violations, fmt.Sprintf("%s: %v is more than %v", at, number, *schema.Maximum))
+		}
+	}
+}
+
+// kindOf is the JSON Schema type of the decoded value, whole numbers are integers
+func kindOf(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number, float64:
+		if number := toFloat(value); number == math.Trunc(number) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func toFloat(value interface{}) float64 {
+	switch value := value.(type) {
+	case json.Number:
+		number, _ := value.Float64()
+		return number
+	case float64:
+		return value
+	}
+	return 0
+}
+
+func schemaTypes(kind interface{}) []string {
+	switch kind := kind.(type) {
+	case string:
+		return []string{kind}
+	case []string:
+		return kind
+	}
+	return nil
+}
+
+// allowed checks the kind against the types, an integer is also a number
+func allowed(types []string, kind string) bool {
+	return contains(types, kind) || (kind == "integer" && contains(types, "number"))
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package patch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rejection explains why a patched field was refused
+type Rejection struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Policy lists the fields of a resource each role may patch
+type Policy struct {
+	// Message is the resource the patched fields belong to
+	Message protoreflect.MessageDescriptor
+	// Fields are the patchable fields per role
+	Fields map[string][]string
+	// Hints tell where read-only fields are changed instead
+	Hints map[string]string
+}
+
+// Check returns every field of data the role may not patch or whose value does not match the field type
+func (p Policy) Check(role string, data map[string]interface{}) []Rejection {
+	var (
+		rejected []Rejection
+		allowed  = map[string]bool{}
+		fields   = make([]string, 0, len(data))
+	)
+
+	for _, field := range p.Fields[role] {
+		allowed[field] = true
+	}
+
+	for field := range data {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fd := p.Message.Fields().ByName(protoreflect.Name(field))
+		if fd == nil {
+			rejected = append(rejected, Rejection{Field: field, Reason: "unknown field"})
+			continue
+		}
+
+		if !allowed[field] {
+			reason := "field is not patchable by " + role
+			if hint, ok := p.Hints[field]; ok {
+				reason += ", " + hint
+			}
+			rejected = append(rejected, Rejection{Field: field, Reason: reason})
+			continue
+		}
+
+		err := checkValue(fd, data[field])
+		if err != nil {
+			rejected = append(rejected, Rejection{Field: field, Reason: err.Error()})
+		}
+	}
+
+	return rejected
+}
+
+// CheckUpdate returns every field a full update changes that the role may not patch, the fields it
+// leaves at their current value pass. The fields are matched to the resource by name
+func (p Policy) CheckUpdate(role string, current, update proto.Message) []Rejection {
+	var (
+		rejected []Rejection
+		allowed  = map[string]bool{}
+		resource = current.ProtoReflect()
+		changed  = update.ProtoReflect()
+		fields   = changed.Descriptor().Fields()
+	)
+
+	for _, field := range p.Fields[role] {
+		allowed[field] = true
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		// the id and the version the update is conditional on select the resource, they change nothing
+		if name == "id" || name == "expected_updated_at" || allowed[name] {
+			continue
+		}
+
+		if rd := p.Message.Fields().ByName(fd.Name()); rd != nil {
+			if fmt.Sprint(changed.Get(fd).Interface()) == fmt.Sprint(resource.Get(rd).Interface()) {
+				continue
+			}
+		} else if !changed.Has(fd) {
+			continue
+		}
+
+		reason := "field may not be changed by " + role
+		if hint, ok := p.Hints[name]; ok {
+			reason += ", " + hint
+		}
+		rejected = append(rejected, Rejection{Field: name, Reason: reason})
+	}
+
+	return rejected
+}
+
+// checkValue matches a decoded JSON value against the proto field, null resets the field as in JSON Merge Patch
+func checkValue(fd protoreflect.FieldDescriptor, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	if fd.IsList() {
+		list, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("must be an array")
+		}
+		for i, item := range list {
+			err := checkScalar(fd, item)
+			if err != nil {
+				return fmt.Errorf("item %d %s", i, err.Error())
+			}
+		}
+		return nil
+	}
+
+	if fd.IsMap() {
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("must be an object")
+		}
+		return nil
+	}
+
+	return checkScalar(fd, value)
+}
+
+func checkScalar(fd protoreflect.FieldDescriptor, value interface{}) error {
+	switch fd.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("must be a string")
+		}
+	case protoreflect.BoolKind:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("must be a number")
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return checkInteger(value, math.MinInt32, math.MaxInt32)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return checkInteger(value, 0, math.MaxUint32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return checkInteger(value, math.MinInt64, math.MaxInt64)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return checkInteger(value, 0, math.MaxUint64)
+	case protoreflect.EnumKind:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+		return checkInteger(value, math.MinInt32, math.MaxInt32)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return fmt.Errorf("must be an object")
+		}
+	}
+	return nil
+}
+
+func checkInteger(value interface{}, min, max float64) error {
+	number, ok := value.(float64)
+	if !ok || number != math.Trunc(number) {
+		return fmt.Errorf("must be an integer")
+	}
+	if number < min || number > max {
+		return fmt.Errorf("must be between %.0f and %.0f", min, max)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package patch
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"reflect"
+	"testing"
+)
+
+var orderPolicy = Policy{
+	Message: (&order_service.Order{}).ProtoReflect().Descriptor(),
+	Fields: map[string][]string{
+		"operator": {"car_id", "day_count"},
+		"admin":    {"car_id", "day_count", "total_price"},
+	},
+	Hints: map[string]string{
+		"paid_price": "use the order payments endpoint",
+	},
+}
+
+func fields(rejected []Rejection) []string {
+	var names []string
+	for _, rejection := range rejected {
+		names = append(names, rejection.Field)
+	}
+	return names
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		data     map[string]interface{}
+		rejected []string
+	}{
+		{name: "allowed", role: "operator", data: map[string]interface{}{"car_id": "c", "day_count": float64(2)}},
+		{name: "null resets", role: "operator", data: map[string]interface{}{"car_id": nil}},
+		{name: "not allowed for the role", role: "operator", data: map[string]interface{}{"total_price": float64(1)}, rejected: []string{"total_price"}},
+		{name: "allowed for admin", role: "admin", data: map[string]interface{}{"total_price": float64(1)}},
+		{name: "unknown role", role: "client", data: map[string]interface{}{"car_id": "c"}, rejected: []string{"car_id"}},
+		{name: "unknown field", role: "admin", data: map[string]interface{}{"nope": 1}, rejected: []string{"nope"}},
+		{name: "wrong type", role: "operator", data: map[string]interface{}{"day_count": "2"}, rejected: []string{"day_count"}},
+		{name: "not an integer", role: "operator", data: map[string]interface{}{"day_count": 1.5}, rejected: []string{"day_count"}},
+		{name: "sorted", role: "operator", data: map[string]interface{}{"total_price": float64(1), "paid_price": float64(1)}, rejected: []string{"paid_price", "total_price"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fields(orderPolicy.Check(tt.role, tt.data))
+			if !reflect.DeepEqual(got, tt.rejected) {
+				t.Fatalf("rejected %v, want %v", got, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestCheckUpdate(t *testing.T) {
+	current := &order_service.Order{
+		Id:          "o1",
+		CarId:       "c1",
+		DayCount:    2,
+		TotalPrice:  100,
+		OrderNumber: "A-1",
+	}
+	unchanged := func() *order_service.UpdateOrder {
+		return &order_service.UpdateOrder{Id: "o1", CarId: "c1", DayCount: 2, TotalPrice: 100, OrderNumber: "A-1"}
+	}
+
+	tests := []struct {
+		name     string
+		role     string
+		change   func(*order_service.UpdateOrder)
+		rejected []string
+	}{
+		{name: "nothing changed", role: "client", change: func(*order_service.UpdateOrder) {}},
+		{name: "allowed change", role: "operator", change: func(u *order_service.UpdateOrder) { u.CarId = "c2" }},
+		{name: "price by operator", role: "operator", change: func(u *order_service.UpdateOrder) { u.TotalPrice = 1 }, rejected: []string{"total_price"}},
+		{name: "price by admin", role: "admin", change: func(u *order_service.UpdateOrder) { u.TotalPrice = 1 }},
+		{name: "cleared field", role: "operator", change: func(u *order_service.UpdateOrder) { u.OrderNumber = "" }, rejected: []string{"order_number"}},
+		{name: "client changes anything", role: "client", change: func(u *order_service.UpdateOrder) { u.CarId = "c2" }, rejected: []string{"car_id"}},
+		{name: "expected version", role: "client", change: func(u *order_service.UpdateOrder) { u.ExpectedUpdatedAt = "2026-05-01 10:00:00" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := unchanged()
+			tt.change(update)
+
+			got := fields(orderPolicy.CheckUpdate(tt.role, current, update))
+			if !reflect.DeepEqual(got, tt.rejected) {
+				t.Fatalf("rejected %v, want %v", got, tt.rejected)
+			}
+		})
+	}
+}
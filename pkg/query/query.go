@@ -0,0 +1,303 @@
+package query
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/dateparse"
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter operators
+const (
+	OpEq   = "eq"
+	OpNe   = "ne"
+	OpIn   = "in"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpLike = "like"
+)
+
+// Field types
+const (
+	TypeString = "string"
+	TypeUUID   = "uuid"
+	TypeDate   = "date"
+	TypeBool   = "bool"
+	TypeNumber = "number"
+	TypeEnum   = "enum"
+)
+
+// reserved are the list parameters that are not filters
+var reserved = map[string]bool{
+	"offset": true,
+	"limit":  true,
+	"search": true,
+	"sort":   true,
+	"cursor": true,
+	"expand": true,
+	"fields": true,
+}
+
+// Field describes a filterable or sortable field of a resource
+type Field struct {
+	Type     string
+	Values   []string
+	Sortable bool
+	// NoFilter marks fields that can only be sorted by
+	NoFilter bool
+}
+
+// Schema is the allow-list of query fields of a resource
+type Schema map[string]Field
+
+// Filter is a validated filter, date values are normalized to helper.DateTimeLayout
+type Filter struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+// Sort is a validated sort key
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Query is the parsed list query
+type Query struct {
+	Filters []Filter
+	Sort    []Sort
+}
+
+// Rejection explains why a query parameter was refused
+type Rejection struct {
+	Param  string `json:"param"`
+	Reason string `json:"reason"`
+}
+
+// Parse reads filters written as field=value, field=a,b or field[op]=value and the
+// sort=field:asc,field:desc parameter, every parameter is checked against the schema.
+// Date values may be written the way people say them, see dateparse.Parse
+func (s Schema) Parse(values url.Values) (*Query, []Rejection) {
+	var (
+		result   = &Query{}
+		rejected []Rejection
+		params   = make([]string, 0, len(values))
+		now      = time.Now()
+	)
+
+	for param := range values {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		if reserved[param] {
+			continue
+		}
+
+		for _, value := range values[param] {
+			filters, err := s.parseFilter(param, value, now)
+			if err != nil {
+				rejected = append(rejected, Rejection{Param: param, Reason: err.Error()})
+				continue
+			}
+			result.Filters = append(result.Filters, filters...)
+		}
+	}
+
+	for _, value := range values["sort"] {
+		sorts, err := s.parseSort(value)
+		if err != nil {
+			rejected = append(rejected, Rejection{Param: "sort", Reason: err.Error()})
+			continue
+		}
+		result.Sort = append(result.Sort, sorts...)
+	}
+
+	return result, rejected
+}
+
+// Fields returns the names of the filterable fields
+func (s Schema) Fields() []string {
+	fields := make([]string, 0, len(s))
+	for name, field := range s {
+		if !field.NoFilter {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Ops returns the operators the field can be filtered with, eq is the one used without [op]
+func (f Field) Ops() []string {
+	ops := make([]string, 0, 8)
+	for _, op := range []string{OpEq, OpNe, OpIn, OpGt, OpGte, OpLt, OpLte, OpLike} {
+		if allowedOps(f.Type)[op] {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// Sortable returns the names of the fields that can be sorted by
+func (s Schema) Sortable() []string {
+	fields := make([]string, 0, len(s))
+	for name, field := range s {
+		if field.Sortable {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func (s Schema) parseFilter(param, value string, now time.Time) ([]Filter, error) {
+	name, op := param, ""
+	if i := strings.IndexByte(param, '['); i > 0 && strings.HasSuffix(param, "]") {
+		name, op = param[:i], param[i+1:len(param)-1]
+	}
+
+	field, ok := s[name]
+	if !ok || field.NoFilter {
+		return nil, fmt.Errorf("unknown filter, allowed: %s", strings.Join(s.Fields(), ", "))
+	}
+
+	if field.Type == TypeDate {
+		if op == "" {
+			op = OpEq
+		}
+		if !allowedOps(field.Type)[op] {
+			return nil, fmt.Errorf("operator %q is not supported for %s fields", op, field.Type)
+		}
+		return DateFilters(name, op, value, now)
+	}
+
+	values := strings.Split(value, ",")
+	if op == "" {
+		op = OpEq
+		if len(values) > 1 {
+			op = OpIn
+		}
+	}
+
+	if !allowedOps(field.Type)[op] {
+		return nil, fmt.Errorf("operator %q is not supported for %s fields", op, field.Type)
+	}
+	if op != OpIn && len(values) > 1 {
+		return nil, fmt.Errorf("operator %q takes a single value", op)
+	}
+
+	for i, item := range values {
+		normalized, err := normalize(field, strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = normalized
+	}
+
+	return []Filter{{Field: name, Op: op, Values: values}}, nil
+}
+
+// DateFilters turns a date expression into filters on the field, a day or a range compared
+// with eq becomes a gte and lte pair and the other operators compare with its nearest bound
+func DateFilters(field, op, value string, now time.Time) ([]Filter, error) {
+	r, err := dateparse.Parse(value, now)
+	if err != nil {
+		return nil, err
+	}
+
+	from := r.From.Format(helper.DateTimeLayout)
+	to := r.To.Format(helper.DateTimeLayout)
+
+	switch op {
+	case OpEq:
+		if r.IsPoint() {
+			return []Filter{{Field: field, Op: OpEq, Values: []string{from}}}, nil
+		}
+		return []Filter{
+			{Field: field, Op: OpGte, Values: []string{from}},
+			{Field: field, Op: OpLte, Values: []string{to}},
+		}, nil
+	case OpNe:
+		if !r.IsPoint() {
+			return nil, fmt.Errorf("operator %q needs an exact date and time", op)
+		}
+		return []Filter{{Field: field, Op: OpNe, Values: []string{from}}}, nil
+	case OpGt, OpLte:
+		return []Filter{{Field: field, Op: op, Values: []string{to}}}, nil
+	default:
+		return []Filter{{Field: field, Op: op, Values: []string{from}}}, nil
+	}
+}
+
+func (s Schema) parseSort(value string) ([]Sort, error) {
+	var sorts []Sort
+
+	for _, key := range strings.Split(value, ",") {
+		name, direction := strings.TrimSpace(key), "asc"
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			name, direction = name[:i], strings.ToLower(name[i+1:])
+		}
+
+		field, ok := s[name]
+		if !ok || !field.Sortable {
+			return nil, fmt.Errorf("cannot sort by %q", name)
+		}
+		if direction != "asc" && direction != "desc" {
+			return nil, fmt.Errorf("sort direction of %s must be asc or desc", name)
+		}
+
+		sorts = append(sorts, Sort{Field: name, Desc: direction == "desc"})
+	}
+
+	return sorts, nil
+}
+
+func allowedOps(fieldType string) map[string]bool {
+	switch fieldType {
+	case TypeString:
+		return map[string]bool{OpEq: true, OpNe: true, OpIn: true, OpLike: true}
+	case TypeDate, TypeNumber:
+		return map[string]bool{OpEq: true, OpNe: true, OpGt: true, OpGte: true, OpLt: true, OpLte: true}
+	case TypeBool:
+		return map[string]bool{OpEq: true}
+	default:
+		return map[string]bool{OpEq: true, OpNe: true, OpIn: true}
+	}
+}
+
+func normalize(field Field, value string) (string, error) {
+	switch field.Type {
+	case TypeUUID:
+		if !util.IsValidUUID(value) {
+			return "", fmt.Errorf("%q is an invalid uuid", value)
+		}
+	case TypeBool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a boolean", value)
+		}
+		return strconv.FormatBool(flag), nil
+	case TypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+	case TypeEnum:
+		for _, allowed := range field.Values {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%q must be one of %s", value, strings.Join(field.Values, ", "))
+	}
+	return value, nil
+}
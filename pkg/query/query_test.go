@@ -0,0 +1,159 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testSchema = Schema{
+	"client_id":   {Type: TypeUUID},
+	"state":       {Type: TypeString, Sortable: true},
+	"status":      {Type: TypeEnum, Values: []string{"draft", "active"}},
+	"is_blocked":  {Type: TypeBool},
+	"total_price": {Type: TypeNumber, Sortable: true},
+	"start_date":  {Type: TypeDate, Sortable: true},
+	"created_at":  {Type: TypeDate, Sortable: true, NoFilter: true},
+}
+
+const testUUID = "7a1c2f4e-3d6b-4c8a-9f5e-1a2b3c4d5e6f"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		filters  []Filter
+		sort     []Sort
+		rejected []string
+	}{
+		{name: "empty"},
+		{name: "reserved parameters", query: "offset=10&limit=5&search=x&cursor=c&expand=car&fields=id"},
+		{
+			name:    "eq without an operator",
+			query:   "client_id=" + testUUID,
+			filters: []Filter{{Field: "client_id", Op: OpEq, Values: []string{testUUID}}},
+		},
+		{
+			name:    "a list is an in-list",
+			query:   "status=draft,active",
+			filters: []Filter{{Field: "status", Op: OpIn, Values: []string{"draft", "active"}}},
+		},
+		{
+			name:    "explicit in with spaces",
+			query:   "state[in]=" + url.QueryEscape("a, b"),
+			filters: []Filter{{Field: "state", Op: OpIn, Values: []string{"a", "b"}}},
+		},
+		{
+			name:    "operators",
+			query:   "total_price[gte]=100&total_price[lt]=200.5&state[like]=tash&state[ne]=x",
+			filters: []Filter{{Field: "state", Op: OpLike, Values: []string{"tash"}}, {Field: "state", Op: OpNe, Values: []string{"x"}}, {Field: "total_price", Op: OpGte, Values: []string{"100"}}, {Field: "total_price", Op: OpLt, Values: []string{"200.5"}}},
+		},
+		{
+			name:    "bool normalized",
+			query:   "is_blocked=1",
+			filters: []Filter{{Field: "is_blocked", Op: OpEq, Values: []string{"true"}}},
+		},
+		{
+			name:    "a day is a range",
+			query:   "start_date=2026-10-10",
+			filters: []Filter{{Field: "start_date", Op: OpGte, Values: []string{"2026-10-10 00:00:00"}}, {Field: "start_date", Op: OpLte, Values: []string{"2026-10-10 23:59:59"}}},
+		},
+		{
+			name:    "after a day compares with its end",
+			query:   "start_date[gt]=2026-10-10",
+			filters: []Filter{{Field: "start_date", Op: OpGt, Values: []string{"2026-10-10 23:59:59"}}},
+		},
+		{
+			name:  "sort directions",
+			query: "sort=" + url.QueryEscape("total_price:desc,state,start_date:ASC"),
+			sort:  []Sort{{Field: "total_price", Desc: true}, {Field: "state"}, {Field: "start_date"}},
+		},
+		{
+			name:  "sort only field",
+			query: "sort=created_at:desc",
+			sort:  []Sort{{Field: "created_at", Desc: true}},
+		},
+		{name: "unknown field", query: "color=red", rejected: []string{"color"}},
+		{name: "sort only field filtered", query: "created_at=2026-10-10", rejected: []string{"created_at"}},
+		{name: "unknown operator", query: "state[between]=a", rejected: []string{"state[between]"}},
+		{name: "operator of another type", query: "is_blocked[ne]=true&state[gt]=a&start_date[like]=2026", rejected: []string{"is_blocked[ne]", "start_date[like]", "state[gt]"}},
+		{name: "list with a single value operator", query: "state[ne]=a,b", rejected: []string{"state[ne]"}},
+		{name: "malformed uuid", query: "client_id=42", rejected: []string{"client_id"}},
+		{name: "malformed number", query: "total_price[gt]=cheap", rejected: []string{"total_price[gt]"}},
+		{name: "malformed bool", query: "is_blocked=maybe", rejected: []string{"is_blocked"}},
+		{name: "value outside the enum", query: "status=archived", rejected: []string{"status"}},
+		{name: "malformed date", query: "start_date=someday", rejected: []string{"start_date"}},
+		{name: "ne needs an exact time", query: "start_date[ne]=2026-10-10", rejected: []string{"start_date[ne]"}},
+		{name: "unsortable field", query: "sort=status", rejected: []string{"sort"}},
+		{name: "unknown sort direction", query: "sort=state:up", rejected: []string{"sort"}},
+		{
+			name:     "valid ones kept beside the rejected",
+			query:    "color=red&state=a",
+			filters:  []Filter{{Field: "state", Op: OpEq, Values: []string{"a"}}},
+			rejected: []string{"color"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, rejections := testSchema.Parse(values)
+
+			var rejected []string
+			for _, rejection := range rejections {
+				if rejection.Reason == "" {
+					t.Fatalf("%s rejected without a reason", rejection.Param)
+				}
+				rejected = append(rejected, rejection.Param)
+			}
+			if !reflect.DeepEqual(rejected, tt.rejected) {
+				t.Fatalf("rejected %v, want %v", rejections, tt.rejected)
+			}
+			if !reflect.DeepEqual(got.Filters, tt.filters) {
+				t.Fatalf("filters %+v, want %+v", got.Filters, tt.filters)
+			}
+			if !reflect.DeepEqual(got.Sort, tt.sort) {
+				t.Fatalf("sort %+v, want %+v", got.Sort, tt.sort)
+			}
+		})
+	}
+}
+
+func TestUnknownFilterListsTheFields(t *testing.T) {
+	_, rejections := testSchema.Parse(url.Values{"color": {"red"}})
+	if len(rejections) != 1 {
+		t.Fatalf("rejections %+v, want one", rejections)
+	}
+
+	want := "allowed: client_id, is_blocked, start_date, state, status, total_price"
+	if !strings.Contains(rejections[0].Reason, want) {
+		t.Fatalf("reason %q, want one listing %q", rejections[0].Reason, want)
+	}
+}
+
+func TestOps(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		want      []string
+	}{
+		{fieldType: TypeString, want: []string{OpEq, OpNe, OpIn, OpLike}},
+		{fieldType: TypeNumber, want: []string{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte}},
+		{fieldType: TypeDate, want: []string{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte}},
+		{fieldType: TypeBool, want: []string{OpEq}},
+		{fieldType: TypeUUID, want: []string{OpEq, OpNe, OpIn}},
+		{fieldType: TypeEnum, want: []string{OpEq, OpNe, OpIn}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldType, func(t *testing.T) {
+			if got := (Field{Type: tt.fieldType}).Ops(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Ops = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
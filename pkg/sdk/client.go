@@ -0,0 +1,207 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the REST API of the gateway, the resources are its fields like Orders and Cars
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	tokens     TokenSource
+	maxRetries int
+	backoff    time.Duration
+
+	services
+}
+
+// Option configures the client
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with the client instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokenSource authenticates the requests with the tokens of the source, see RefreshingToken
+func WithTokenSource(tokens TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = tokens
+	}
+}
+
+// WithRetries sets how many times a GET, PUT or DELETE answered with 503 is sent again and the wait
+// before the first retry, the wait doubles with every retry unless the gateway sends Retry-After.
+// POST and PATCH are not retried, the gateway may have applied them before it answered
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New creates a client of the gateway at the base url, like http://localhost:9090
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		maxRetries: 3,
+		backoff:    500 * time.Millisecond,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	c.services = newServices(c)
+	return c
+}
+
+// do sends the request and decodes the JSON answer into out, out may be nil for answers without a body
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, target, header, payload)
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusServiceUnavailable && idempotent(method) && attempt < c.maxRetries:
+			err = c.wait(ctx, attempt, resp.Header.Get("Retry-After"))
+			if err != nil {
+				return err
+			}
+			continue
+		case resp.StatusCode == http.StatusUnauthorized && !refreshed && c.tokens != nil:
+			// the token may have been revoked or expired early, a fresh one is tried once
+			if invalidator, ok := c.tokens.(interface{ Invalidate() }); ok {
+				invalidator.Invalidate()
+				refreshed = true
+				continue
+			}
+		}
+
+		if resp.StatusCode >= 300 {
+			return newError(resp.StatusCode, data)
+		}
+
+		if out == nil || len(bytes.TrimSpace(data)) == 0 {
+			return nil
+		}
+		return json.Unmarshal(data, out)
+	}
+}
+
+// idempotent reports whether sending the request twice has the effect of sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func (c *Client) send(ctx context.Context, method, target string, header http.Header, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.tokens != nil {
+		token, err := c.tokens.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// wait sleeps before the retry for Retry-After seconds or the doubled backoff
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	delay := c.backoff << attempt
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// setString adds the parameter when the caller set it, as do setInt, setBool and setFloat
+func setString(values map[string][]string, name, value string) {
+	if value != "" {
+		values[name] = []string{value}
+	}
+}
+
+func setInt(values map[string][]string, name string, value int) {
+	if value != 0 {
+		values[name] = []string{strconv.Itoa(value)}
+	}
+}
+
+func setBool(values map[string][]string, name string, value *bool) {
+	if value != nil {
+		values[name] = []string{strconv.FormatBool(*value)}
+	}
+}
+
+func setFloat(values map[string][]string, name string, value *float64) {
+	if value != nil {
+		values[name] = []string{strconv.FormatFloat(*value, 'f', -1, 64)}
+	}
+}
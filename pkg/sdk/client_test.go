@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRetriesOnlyIdempotentRequests(t *testing.T) {
+	tests := []struct {
+		method   string
+		requests int
+	}{
+		{method: http.MethodGet, requests: 3},
+		{method: http.MethodPut, requests: 3},
+		{method: http.MethodDelete, requests: 3},
+		{method: http.MethodPost, requests: 1},
+		{method: http.MethodPatch, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client := New(server.URL, WithRetries(2, 0))
+			err := client.do(context.Background(), tt.method, "/order", nil, nil, nil, nil)
+			if err == nil {
+				t.Fatal("a 503 answer did not fail")
+			}
+			if requests != tt.requests {
+				t.Fatalf("sent %d requests, want %d", requests, tt.requests)
+			}
+		})
+	}
+}
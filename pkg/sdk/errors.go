@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"Projects/Car24/car24_api_gateway/api/http"
+	"encoding/json"
+	"fmt"
+)
+
+// The errors of the http.Status catalogue, compare with errors.Is, the statuses that share a code
+// like BAD_REQUEST and INVALID_ARGUMENT cannot be told apart from the answer
+var (
+	ErrNotModified          = &Error{Status: http.NotModified}
+	ErrBadRequest           = &Error{Status: http.BadRequest}
+	ErrUnauthorized         = &Error{Status: http.Unauthorized}
+	ErrForbidden            = &Error{Status: http.Forbidden}
+	ErrNotFound             = &Error{Status: http.NotFound}
+	ErrConflict             = &Error{Status: http.Conflict}
+	ErrPreconditionFailed   = &Error{Status: http.PreconditionFailed}
+	ErrUnprocessableEntity  = &Error{Status: http.UnprocessableEntity}
+	ErrFailedDependency     = &Error{Status: http.FailedDependency}
+	ErrPreconditionRequired = &Error{Status: http.PreconditionRequired}
+	ErrTooManyRequests      = &Error{Status: http.TooManyRequests}
+	ErrInternal             = &Error{Status: http.InternalServerError}
+	ErrServiceUnavailable   = &Error{Status: http.Status{Code: 503, Status: "SERVICE_UNAVAILABLE", Description: "The gateway cannot handle the request right now"}}
+)
+
+var errorsByCode = map[int]*Error{}
+
+func init() {
+	for _, err := range []*Error{
+		ErrNotModified, ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict,
+		ErrPreconditionFailed, ErrUnprocessableEntity, ErrFailedDependency, ErrPreconditionRequired,
+		ErrTooManyRequests, ErrInternal, ErrServiceUnavailable,
+	} {
+		errorsByCode[err.Status.Code] = err
+	}
+}
+
+// Error is an answer of the gateway that is not a success
+type Error struct {
+	Status http.Status
+	// Message is the error text the gateway answered with, empty when it answered with an object
+	Message string
+	// Body is the whole answer, like the rejected parameters or the failed eligibility checks
+	Body json.RawMessage
+}
+
+func newError(code int, body []byte) *Error {
+	err := &Error{Status: http.Status{Code: code}, Body: body}
+	if known, ok := errorsByCode[code]; ok {
+		err.Status = known.Status
+	}
+
+	if json.Unmarshal(body, &err.Message) != nil {
+		err.Message = ""
+	}
+
+	return err
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%d %s: %s", e.Status.Code, e.Status.Status, e.Message)
+	}
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("%d %s: %s", e.Status.Code, e.Status.Status, e.Body)
+	}
+	return fmt.Sprintf("%d %s", e.Status.Code, e.Status.Status)
+}
+
+// Is matches the errors with the same status code
+func (e *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	return ok && other.Status.Code == e.Status.Code
+}
+
+// Decode reads the body of the answer, like into models.ValidationFailed
+func (e *Error) Decode(out interface{}) error {
+	return json.Unmarshal(e.Body, out)
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import "context"
+
+// Page is a page of a list with the cursor of the next one, an empty cursor ends the list
+type Page[T any] struct {
+	Items []T
+	Next  string
+}
+
+// Pager walks every item of a list, loading a page at a time:
+//
+//	orders := client.Orders.ListAll(&sdk.OrdersListParams{Status: "active"})
+//	for orders.Next(ctx) {
+//		fmt.Println(orders.Item().Id)
+//	}
+//	if err := orders.Err(); err != nil { ... }
+type Pager[T any] struct {
+	load func(ctx context.Context, cursor string) (Page[T], error)
+
+	page   []T
+	index  int
+	cursor string
+	done   bool
+	err    error
+}
+
+func newPager[T any](load func(ctx context.Context, cursor string) (Page[T], error)) *Pager[T] {
+	return &Pager[T]{load: load, index: -1}
+}
+
+// Next moves to the next item, loading the next page when needed, false at the end or on an error
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	p.index++
+	for p.index >= len(p.page) {
+		if p.done {
+			return false
+		}
+
+		page, err := p.load(ctx, p.cursor)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.page, p.index, p.cursor = page.Items, 0, page.Next
+		p.done = page.Next == "" || len(page.Items) == 0
+		if len(page.Items) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Item is the current item
+func (p *Pager[T]) Item() T {
+	return p.page[p.index]
+}
+
+// Err is the error that stopped the pager
+func (p *Pager[T]) Err() error {
+	return p.err
+}
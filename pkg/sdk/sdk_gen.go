@@ -0,0 +1,1447 @@
+// Code generated by cmd/sdkgen from the OpenAPI document of the gateway. DO NOT EDIT.
+
+package sdk
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/genproto/order_service"
+	"Projects/Car24/car24_api_gateway/models"
+	"Projects/Car24/car24_api_gateway/pkg/webhook"
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type services struct {
+	Auth      *AuthService
+	Batch     *BatchService
+	Cars      *CarsService
+	Clients   *ClientsService
+	Discounts *DiscountsService
+	GraphQL   *GraphQLService
+	Mechanics *MechanicsService
+	Models    *ModelsService
+	Orders    *OrdersService
+	Tarifs    *TarifsService
+	Webhooks  *WebhooksService
+}
+
+func newServices(c *Client) services {
+	return services{
+		Auth:      &AuthService{client: c},
+		Batch:     &BatchService{client: c},
+		Cars:      &CarsService{client: c},
+		Clients:   &ClientsService{client: c},
+		Discounts: &DiscountsService{client: c},
+		GraphQL:   &GraphQLService{client: c},
+		Mechanics: &MechanicsService{client: c},
+		Models:    &ModelsService{client: c},
+		Orders:    &OrdersService{client: c},
+		Tarifs:    &TarifsService{client: c},
+		Webhooks:  &WebhooksService{client: c},
+	}
+}
+
+// AuthService calls the OTP routes
+type AuthService struct {
+	client *Client
+}
+
+// CreateOTP calls POST /check: Create OTP
+func (s *AuthService) CreateOTP(ctx context.Context, body *client_service.CreateOTP) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	err := s.client.do(ctx, "POST", "/check", nil, nil, body, &out)
+	return out, err
+}
+
+// AuthVerifyOTPParams are the parameters of Auth.VerifyOTP
+type AuthVerifyOTPParams struct {
+	// code sent to the phone
+	OTPCode string
+	// phone number
+	PhoneNumber string
+}
+
+func (p *AuthVerifyOTPParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "otp_code", p.OTPCode)
+	setString(query, "phone_number", p.PhoneNumber)
+	return query, header
+}
+
+// VerifyOTP calls GET /check: Verify OTP
+func (s *AuthService) VerifyOTP(ctx context.Context, params *AuthVerifyOTPParams) (string, error) {
+	query, header := params.encode()
+	var out string
+	err := s.client.do(ctx, "GET", "/check", query, header, nil, &out)
+	return out, err
+}
+
+// BatchService calls the Batch routes
+type BatchService struct {
+	client *Client
+}
+
+// BatchRunParams are the parameters of Batch.Run
+type BatchRunParams struct {
+	// stop at the first failed request
+	Atomic *bool
+}
+
+func (p *BatchRunParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setBool(query, "atomic", p.Atomic)
+	return query, header
+}
+
+// Run calls POST /batch: Batch
+func (s *BatchService) Run(ctx context.Context, body []*models.BatchRequest, params *BatchRunParams) ([]*models.BatchResponse, error) {
+	query, header := params.encode()
+	var out []*models.BatchResponse
+	err := s.client.do(ctx, "POST", "/batch", query, header, body, &out)
+	return out, err
+}
+
+// CarsService calls the Car routes
+type CarsService struct {
+	client *Client
+}
+
+// Create calls POST /car: Create Car
+func (s *CarsService) Create(ctx context.Context, body *order_service.CreateCar) (*order_service.Car, error) {
+	out := new(order_service.Car)
+	err := s.client.do(ctx, "POST", "/car", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CarsDeleteParams are the parameters of Cars.Delete
+type CarsDeleteParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *CarsDeleteParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Delete calls DELETE /car/{id}: Delete Car
+func (s *CarsService) Delete(ctx context.Context, id string, params *CarsDeleteParams) error {
+	query, header := params.encode()
+	return s.client.do(ctx, "DELETE", "/car/"+url.PathEscape(id), query, header, nil, nil)
+}
+
+// CarsGetByIDParams are the parameters of Cars.GetByID
+type CarsGetByIDParams struct {
+	// comma separated relations: model, tarif
+	Expand string
+	// comma separated fields to return, e.g. id,state_number or expanded.model.name
+	Fields string
+	// ETag of the version the client has, answered with 304 when it is current
+	IfNoneMatch string
+}
+
+func (p *CarsGetByIDParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "expand", p.Expand)
+	setString(query, "fields", p.Fields)
+	setString(header, "If-None-Match", p.IfNoneMatch)
+	return query, header
+}
+
+// GetByID calls GET /car/{id}: Get Car By ID
+func (s *CarsService) GetByID(ctx context.Context, id string, params *CarsGetByIDParams) (*order_service.Car, error) {
+	query, header := params.encode()
+	out := new(order_service.Car)
+	err := s.client.do(ctx, "GET", "/car/"+url.PathEscape(id), query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CarsListParams are the parameters of Cars.List
+type CarsListParams struct {
+	// offset
+	Offset int
+	// limit, at most the configured maximum page size
+	Limit int
+	// next_cursor or prev_cursor of a previous page, replaces offset
+	Cursor string
+	// search
+	Search string
+	// created_at eq
+	CreatedAt string
+	// created_at ne
+	CreatedAtNe string
+	// created_at gt
+	CreatedAtGt string
+	// created_at gte
+	CreatedAtGte string
+	// created_at lt
+	CreatedAtLt string
+	// created_at lte
+	CreatedAtLte string
+	// model_id eq
+	ModelID string
+	// model_id ne
+	ModelIDNe string
+	// model_id in
+	ModelIDIn string
+	// state_number eq
+	StateNumber string
+	// state_number ne
+	StateNumberNe string
+	// state_number in
+	StateNumberIn string
+	// state_number like
+	StateNumberLike string
+	// status eq
+	Status *bool
+	// tarif_id eq
+	TarifID string
+	// tarif_id ne
+	TarifIDNe string
+	// tarif_id in
+	TarifIDIn string
+	// comma separated field:asc or field:desc, by created_at, state_number, the newest first when not given
+	Sort string
+	// comma separated relations: model, tarif
+	Expand string
+	// comma separated fields to return, e.g. id,state_number or expanded.model.name
+	Fields string
+}
+
+func (p *CarsListParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setInt(query, "offset", p.Offset)
+	setInt(query, "limit", p.Limit)
+	setString(query, "cursor", p.Cursor)
+	setString(query, "search", p.Search)
+	setString(query, "created_at", p.CreatedAt)
+	setString(query, "created_at[ne]", p.CreatedAtNe)
+	setString(query, "created_at[gt]", p.CreatedAtGt)
+	setString(query, "created_at[gte]", p.CreatedAtGte)
+	setString(query, "created_at[lt]", p.CreatedAtLt)
+	setString(query, "created_at[lte]", p.CreatedAtLte)
+	setString(query, "model_id", p.ModelID)
+	setString(query, "model_id[ne]", p.ModelIDNe)
+	setString(query, "model_id[in]", p.ModelIDIn)
+	setString(query, "state_number", p.StateNumber)
+	setString(query, "state_number[ne]", p.StateNumberNe)
+	setString(query, "state_number[in]", p.StateNumberIn)
+	setString(query, "state_number[like]", p.StateNumberLike)
+	setBool(query, "status", p.Status)
+	setString(query, "tarif_id", p.TarifID)
+	setString(query, "tarif_id[ne]", p.TarifIDNe)
+	setString(query, "tarif_id[in]", p.TarifIDIn)
+	setString(query, "sort", p.Sort)
+	setString(query, "expand", p.Expand)
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// List calls GET /car: Get Car List
+func (s *CarsService) List(ctx context.Context, params *CarsListParams) (*order_service.GetListCarResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetListCarResponse)
+	err := s.client.do(ctx, "GET", "/car", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAll walks every item of Cars.List, following the cursors of the pages
+func (s *CarsService) ListAll(params *CarsListParams) *Pager[*order_service.Car] {
+	var current CarsListParams
+	if params != nil {
+		current = *params
+	}
+
+	return newPager(func(ctx context.Context, cursor string) (Page[*order_service.Car], error) {
+		if cursor != "" {
+			current.Cursor, current.Offset = cursor, 0
+		}
+
+		resp, err := s.List(ctx, &current)
+		if err != nil {
+			return Page[*order_service.Car]{}, err
+		}
+		return Page[*order_service.Car]{Items: resp.Cars, Next: resp.NextCursor}, nil
+	})
+}
+
+// CarsPatchParams are the parameters of Cars.Patch
+type CarsPatchParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *CarsPatchParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Patch calls PATCH /car/{id}: Patch Car
+func (s *CarsService) Patch(ctx context.Context, id string, body *models.UpdatePatch, params *CarsPatchParams) (*order_service.Car, error) {
+	query, header := params.encode()
+	out := new(order_service.Car)
+	err := s.client.do(ctx, "PATCH", "/car/"+url.PathEscape(id), query, header, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CarsUpdateParams are the parameters of Cars.Update
+type CarsUpdateParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *CarsUpdateParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Update calls PUT /car/{id}: Update Car
+func (s *CarsService) Update(ctx context.Context, id string, body *order_service.UpdateCar, params *CarsUpdateParams) (*order_service.Car, error) {
+	query, header := params.encode()
+	out := new(order_service.Car)
+	err := s.client.do(ctx, "PUT", "/car/"+url.PathEscape(id), query, header, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsService calls the Client routes
+type ClientsService struct {
+	client *Client
+}
+
+// Block calls POST /user/{id}/block: Block Client
+func (s *ClientsService) Block(ctx context.Context, id string, body *models.BlockClient) (*client_service.Client, error) {
+	out := new(client_service.Client)
+	err := s.client.do(ctx, "POST", "/user/"+url.PathEscape(id)+"/block", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Create calls POST /user: Create Client
+func (s *ClientsService) Create(ctx context.Context, body *client_service.CreateClient) (*client_service.Client, error) {
+	out := new(client_service.Client)
+	err := s.client.do(ctx, "POST", "/user", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsDeleteParams are the parameters of Clients.Delete
+type ClientsDeleteParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *ClientsDeleteParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Delete calls DELETE /user/{id}: Delete Client
+func (s *ClientsService) Delete(ctx context.Context, id string, params *ClientsDeleteParams) error {
+	query, header := params.encode()
+	return s.client.do(ctx, "DELETE", "/user/"+url.PathEscape(id), query, header, nil, nil)
+}
+
+// ClientsGetBlockHistoryParams are the parameters of Clients.GetBlockHistory
+type ClientsGetBlockHistoryParams struct {
+	// comma separated fields to return, e.g. action,reason
+	Fields string
+}
+
+func (p *ClientsGetBlockHistoryParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetBlockHistory calls GET /user/{id}/block-history: Get Client Block History
+func (s *ClientsService) GetBlockHistory(ctx context.Context, id string, params *ClientsGetBlockHistoryParams) (*client_service.GetClientBlockHistoryResponse, error) {
+	query, header := params.encode()
+	out := new(client_service.GetClientBlockHistoryResponse)
+	err := s.client.do(ctx, "GET", "/user/"+url.PathEscape(id)+"/block-history", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsGetBlockSuggestionsParams are the parameters of Clients.GetBlockSuggestions
+type ClientsGetBlockSuggestionsParams struct {
+	// offset
+	Offset int
+	// limit, at most the configured maximum page size
+	Limit int
+}
+
+func (p *ClientsGetBlockSuggestionsParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setInt(query, "offset", p.Offset)
+	setInt(query, "limit", p.Limit)
+	return query, header
+}
+
+// GetBlockSuggestions calls GET /user/block-suggestions: Get Block Suggestions
+func (s *ClientsService) GetBlockSuggestions(ctx context.Context, params *ClientsGetBlockSuggestionsParams) (*models.BlockSuggestions, error) {
+	query, header := params.encode()
+	out := new(models.BlockSuggestions)
+	err := s.client.do(ctx, "GET", "/user/block-suggestions", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsGetByIDParams are the parameters of Clients.GetByID
+type ClientsGetByIDParams struct {
+	// comma separated fields to return, e.g. id,first_name,phone_number
+	Fields string
+	// ETag of the version the client has, answered with 304 when it is current
+	IfNoneMatch string
+}
+
+func (p *ClientsGetByIDParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	setString(header, "If-None-Match", p.IfNoneMatch)
+	return query, header
+}
+
+// GetByID calls GET /user/{id}: Get Client By ID
+func (s *ClientsService) GetByID(ctx context.Context, id string, params *ClientsGetByIDParams) (*client_service.Client, error) {
+	query, header := params.encode()
+	out := new(client_service.Client)
+	err := s.client.do(ctx, "GET", "/user/"+url.PathEscape(id), query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsListParams are the parameters of Clients.List
+type ClientsListParams struct {
+	// offset
+	Offset int
+	// limit, at most the configured maximum page size
+	Limit int
+	// next_cursor or prev_cursor of a previous page, replaces offset
+	Cursor string
+	// search
+	Search string
+	// created_at eq
+	CreatedAt string
+	// created_at ne
+	CreatedAtNe string
+	// created_at gt
+	CreatedAtGt string
+	// created_at gte
+	CreatedAtGte string
+	// created_at lt
+	CreatedAtLt string
+	// created_at lte
+	CreatedAtLte string
+	// first_name eq
+	FirstName string
+	// first_name ne
+	FirstNameNe string
+	// first_name in
+	FirstNameIn string
+	// first_name like
+	FirstNameLike string
+	// is_blocked eq
+	IsBlocked *bool
+	// last_name eq
+	LastName string
+	// last_name ne
+	LastNameNe string
+	// last_name in
+	LastNameIn string
+	// last_name like
+	LastNameLike string
+	// phone_number eq
+	PhoneNumber string
+	// phone_number ne
+	PhoneNumberNe string
+	// phone_number in
+	PhoneNumberIn string
+	// phone_number like
+	PhoneNumberLike string
+	// comma separated field:asc or field:desc, by created_at, first_name, last_name, the newest first when not given
+	Sort string
+	// comma separated fields to return, e.g. id,first_name,phone_number
+	Fields string
+}
+
+func (p *ClientsListParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setInt(query, "offset", p.Offset)
+	setInt(query, "limit", p.Limit)
+	setString(query, "cursor", p.Cursor)
+	setString(query, "search", p.Search)
+	setString(query, "created_at", p.CreatedAt)
+	setString(query, "created_at[ne]", p.CreatedAtNe)
+	setString(query, "created_at[gt]", p.CreatedAtGt)
+	setString(query, "created_at[gte]", p.CreatedAtGte)
+	setString(query, "created_at[lt]", p.CreatedAtLt)
+	setString(query, "created_at[lte]", p.CreatedAtLte)
+	setString(query, "first_name", p.FirstName)
+	setString(query, "first_name[ne]", p.FirstNameNe)
+	setString(query, "first_name[in]", p.FirstNameIn)
+	setString(query, "first_name[like]", p.FirstNameLike)
+	setBool(query, "is_blocked", p.IsBlocked)
+	setString(query, "last_name", p.LastName)
+	setString(query, "last_name[ne]", p.LastNameNe)
+	setString(query, "last_name[in]", p.LastNameIn)
+	setString(query, "last_name[like]", p.LastNameLike)
+	setString(query, "phone_number", p.PhoneNumber)
+	setString(query, "phone_number[ne]", p.PhoneNumberNe)
+	setString(query, "phone_number[in]", p.PhoneNumberIn)
+	setString(query, "phone_number[like]", p.PhoneNumberLike)
+	setString(query, "sort", p.Sort)
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// List calls GET /user: Get Client List
+func (s *ClientsService) List(ctx context.Context, params *ClientsListParams) (*client_service.GetListClientResponse, error) {
+	query, header := params.encode()
+	out := new(client_service.GetListClientResponse)
+	err := s.client.do(ctx, "GET", "/user", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAll walks every item of Clients.List, following the cursors of the pages
+func (s *ClientsService) ListAll(params *ClientsListParams) *Pager[*client_service.Client] {
+	var current ClientsListParams
+	if params != nil {
+		current = *params
+	}
+
+	return newPager(func(ctx context.Context, cursor string) (Page[*client_service.Client], error) {
+		if cursor != "" {
+			current.Cursor, current.Offset = cursor, 0
+		}
+
+		resp, err := s.List(ctx, &current)
+		if err != nil {
+			return Page[*client_service.Client]{}, err
+		}
+		return Page[*client_service.Client]{Items: resp.Clients, Next: resp.NextCursor}, nil
+	})
+}
+
+// ClientsPatchParams are the parameters of Clients.Patch
+type ClientsPatchParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *ClientsPatchParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Patch calls PATCH /user/{id}: Patch Client
+func (s *ClientsService) Patch(ctx context.Context, id string, body *models.UpdatePatch, params *ClientsPatchParams) (*client_service.Client, error) {
+	query, header := params.encode()
+	out := new(client_service.Client)
+	err := s.client.do(ctx, "PATCH", "/user/"+url.PathEscape(id), query, header, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Unblock calls POST /user/{id}/unblock: Unblock Client
+func (s *ClientsService) Unblock(ctx context.Context, id string, body *models.BlockClient) (*client_service.Client, error) {
+	out := new(client_service.Client)
+	err := s.client.do(ctx, "POST", "/user/"+url.PathEscape(id)+"/unblock", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClientsUpdateParams are the parameters of Clients.Update
+type ClientsUpdateParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *ClientsUpdateParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Update calls PUT /user/{id}: Update Client
+func (s *ClientsService) Update(ctx context.Context, id string, body *client_service.UpdateClient, params *ClientsUpdateParams) (*client_service.Client, error) {
+	query, header := params.encode()
+	out := new(client_service.Client)
+	err := s.client.do(ctx, "PUT", "/user/"+url.PathEscape(id), query, header, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DiscountsService calls the Discount routes
+type DiscountsService struct {
+	client *Client
+}
+
+// Create calls POST /discount: Create Discount
+func (s *DiscountsService) Create(ctx context.Context, body *order_service.CreateDiscount) (*order_service.Discount, error) {
+	out := new(order_service.Discount)
+	err := s.client.do(ctx, "POST", "/discount", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Delete calls DELETE /discount/{id}: Delete Discount
+func (s *DiscountsService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, "DELETE", "/discount/"+url.PathEscape(id), nil, nil, nil, nil)
+}
+
+// GetByID calls GET /discount/{id}: GetByID Discount
+func (s *DiscountsService) GetByID(ctx context.Context, id string) (*order_service.Discount, error) {
+	out := new(order_service.Discount)
+	err := s.client.do(ctx, "GET", "/discount/"+url.PathEscape(id), nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphQLService calls the GraphQL routes
+type GraphQLService struct {
+	client *Client
+}
+
+// Query calls POST /graphql: GraphQL
+func (s *GraphQLService) Query(ctx context.Context, body *models.GraphQLRequest) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	err := s.client.do(ctx, "POST", "/graphql", nil, nil, body, &out)
+	return out, err
+}
+
+// MechanicsService calls the Mechanic routes
+type MechanicsService struct {
+	client *Client
+}
+
+// Create calls POST /mechanic: Create Mechanic
+func (s *MechanicsService) Create(ctx context.Context, body *order_service.CreateMechanic) (*order_service.Mechanic, error) {
+	out := new(order_service.Mechanic)
+	err := s.client.do(ctx, "POST", "/mechanic", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Delete calls DELETE /mechanic/{id}: Delete Mechanic
+func (s *MechanicsService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, "DELETE", "/mechanic/"+url.PathEscape(id), nil, nil, nil, nil)
+}
+
+// GetByID calls GET /mechanic/{id}: GetByID Mechanic
+func (s *MechanicsService) GetByID(ctx context.Context, id string) (*order_service.Mechanic, error) {
+	out := new(order_service.Mechanic)
+	err := s.client.do(ctx, "GET", "/mechanic/"+url.PathEscape(id), nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ModelsService calls the Model routes
+type ModelsService struct {
+	client *Client
+}
+
+// Create calls POST /model: Create Model
+func (s *ModelsService) Create(ctx context.Context, body *order_service.CreateModel) (*order_service.Model, error) {
+	out := new(order_service.Model)
+	err := s.client.do(ctx, "POST", "/model", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Delete calls DELETE /model/{id}: Delete Model
+func (s *ModelsService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, "DELETE", "/model/"+url.PathEscape(id), nil, nil, nil, nil)
+}
+
+// GetByID calls GET /model/{id}: GetByID Model
+func (s *ModelsService) GetByID(ctx context.Context, id string) (*order_service.Model, error) {
+	out := new(order_service.Model)
+	err := s.client.do(ctx, "GET", "/model/"+url.PathEscape(id), nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersService calls the Order routes
+type OrdersService struct {
+	client *Client
+}
+
+// Cancel calls POST /order/{id}/cancel: Cancel Order
+func (s *OrdersService) Cancel(ctx context.Context, id string, body *models.ChangeOrderStatus) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/cancel", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CaptureDeposit calls POST /order/{id}/deposit/capture: Capture Order Deposit
+func (s *OrdersService) CaptureDeposit(ctx context.Context, id string, body *models.DepositCapture) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/deposit/capture", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Complete calls POST /order/{id}/complete: Complete Order
+func (s *OrdersService) Complete(ctx context.Context, id string, body *models.ChangeOrderStatus) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/complete", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Confirm calls POST /order/{id}/confirm: Confirm Order
+func (s *OrdersService) Confirm(ctx context.Context, id string, body *models.ChangeOrderStatus) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/confirm", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Create calls POST /order: Create Order
+func (s *OrdersService) Create(ctx context.Context, body *order_service.CreateOrder) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreatePayment calls POST /order/{id}/payments: Create Order Payment
+func (s *OrdersService) CreatePayment(ctx context.Context, id string, body *models.CreatePayment) (*order_service.Payment, error) {
+	out := new(order_service.Payment)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/payments", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersDeleteParams are the parameters of Orders.Delete
+type OrdersDeleteParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *OrdersDeleteParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Delete calls DELETE /order/{id}: Delete Order
+func (s *OrdersService) Delete(ctx context.Context, id string, params *OrdersDeleteParams) error {
+	query, header := params.encode()
+	return s.client.do(ctx, "DELETE", "/order/"+url.PathEscape(id), query, header, nil, nil)
+}
+
+// OrdersGetByIDParams are the parameters of Orders.GetByID
+type OrdersGetByIDParams struct {
+	// comma separated relations: client, car, tarif, mechanic, discount
+	Expand string
+	// comma separated fields to return, e.g. id,status,total_price or expanded.client.first_name
+	Fields string
+	// ETag of the version the client has, answered with 304 when it is current
+	IfNoneMatch string
+}
+
+func (p *OrdersGetByIDParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "expand", p.Expand)
+	setString(query, "fields", p.Fields)
+	setString(header, "If-None-Match", p.IfNoneMatch)
+	return query, header
+}
+
+// GetByID calls GET /order/{id}: Get Order By ID
+func (s *OrdersService) GetByID(ctx context.Context, id string, params *OrdersGetByIDParams) (*order_service.Order, error) {
+	query, header := params.encode()
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "GET", "/order/"+url.PathEscape(id), query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersGetChargesParams are the parameters of Orders.GetCharges
+type OrdersGetChargesParams struct {
+	// comma separated fields to return, e.g. type,amount
+	Fields string
+}
+
+func (p *OrdersGetChargesParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetCharges calls GET /order/{id}/charges: Get Order Charges
+func (s *OrdersService) GetCharges(ctx context.Context, id string, params *OrdersGetChargesParams) (*order_service.GetOrderChargesResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetOrderChargesResponse)
+	err := s.client.do(ctx, "GET", "/order/"+url.PathEscape(id)+"/charges", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersGetDepositParams are the parameters of Orders.GetDeposit
+type OrdersGetDepositParams struct {
+	// comma separated fields to return, e.g. type,amount
+	Fields string
+}
+
+func (p *OrdersGetDepositParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetDeposit calls GET /order/{id}/deposit: Get Order Deposit
+func (s *OrdersService) GetDeposit(ctx context.Context, id string, params *OrdersGetDepositParams) (*order_service.GetDepositTransactionsResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetDepositTransactionsResponse)
+	err := s.client.do(ctx, "GET", "/order/"+url.PathEscape(id)+"/deposit", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersGetInspectionsParams are the parameters of Orders.GetInspections
+type OrdersGetInspectionsParams struct {
+	// comma separated fields to return, e.g. id,type,mileage
+	Fields string
+}
+
+func (p *OrdersGetInspectionsParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetInspections calls GET /order/{id}/inspections: Get Order Inspections
+func (s *OrdersService) GetInspections(ctx context.Context, id string, params *OrdersGetInspectionsParams) (*order_service.GetVehicleInspectionsResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetVehicleInspectionsResponse)
+	err := s.client.do(ctx, "GET", "/order/"+url.PathEscape(id)+"/inspections", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersGetOverdueParams are the parameters of Orders.GetOverdue
+type OrdersGetOverdueParams struct {
+	// offset
+	Offset int
+	// limit, at most the configured maximum page size
+	Limit int
+	// next_cursor or prev_cursor of a previous page, replaces offset
+	Cursor string
+	// comma separated fields to return, e.g. id,client_id,due_date
+	Fields string
+}
+
+func (p *OrdersGetOverdueParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setInt(query, "offset", p.Offset)
+	setInt(query, "limit", p.Limit)
+	setString(query, "cursor", p.Cursor)
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetOverdue calls GET /order/overdue: Get Overdue Orders
+func (s *OrdersService) GetOverdue(ctx context.Context, params *OrdersGetOverdueParams) (*order_service.GetListOrderResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetListOrderResponse)
+	err := s.client.do(ctx, "GET", "/order/overdue", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOverdueAll walks every item of Orders.GetOverdue, following the cursors of the pages
+func (s *OrdersService) GetOverdueAll(params *OrdersGetOverdueParams) *Pager[*order_service.Order] {
+	var current OrdersGetOverdueParams
+	if params != nil {
+		current = *params
+	}
+
+	return newPager(func(ctx context.Context, cursor string) (Page[*order_service.Order], error) {
+		if cursor != "" {
+			current.Cursor, current.Offset = cursor, 0
+		}
+
+		resp, err := s.GetOverdue(ctx, &current)
+		if err != nil {
+			return Page[*order_service.Order]{}, err
+		}
+		return Page[*order_service.Order]{Items: resp.Orders, Next: resp.NextCursor}, nil
+	})
+}
+
+// OrdersGetPaymentsParams are the parameters of Orders.GetPayments
+type OrdersGetPaymentsParams struct {
+	// comma separated fields to return, e.g. id,amount,method
+	Fields string
+}
+
+func (p *OrdersGetPaymentsParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetPayments calls GET /order/{id}/payments: Get Order Payments
+func (s *OrdersService) GetPayments(ctx context.Context, id string, params *OrdersGetPaymentsParams) (*order_service.GetListPaymentResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetListPaymentResponse)
+	err := s.client.do(ctx, "GET", "/order/"+url.PathEscape(id)+"/payments", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersGetStatusHistoryParams are the parameters of Orders.GetStatusHistory
+type OrdersGetStatusHistoryParams struct {
+	// comma separated fields to return, e.g. to_status,created_at
+	Fields string
+}
+
+func (p *OrdersGetStatusHistoryParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// GetStatusHistory calls GET /order/{id}/history: Get Order Status History
+func (s *OrdersService) GetStatusHistory(ctx context.Context, id string, params *OrdersGetStatusHistoryParams) (*order_service.GetOrderStatusHistoryResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetOrderStatusHistoryResponse)
+	err := s.client.do(ctx, "GET", "/order/"+url.PathEscape(id)+"/history", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HoldDeposit calls POST /order/{id}/deposit/hold: Hold Order Deposit
+func (s *OrdersService) HoldDeposit(ctx context.Context, id string, body *models.DepositHold) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/deposit/hold", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersListParams are the parameters of Orders.List
+type OrdersListParams struct {
+	// offset
+	Offset int
+	// limit, at most the configured maximum page size
+	Limit int
+	// next_cursor or prev_cursor of a previous page, replaces offset
+	Cursor string
+	// search
+	Search string
+	// car_id eq
+	CarID string
+	// car_id ne
+	CarIDNe string
+	// car_id in
+	CarIDIn string
+	// client_id eq
+	ClientID string
+	// client_id ne
+	ClientIDNe string
+	// client_id in
+	ClientIDIn string
+	// created_at eq
+	CreatedAt string
+	// created_at ne
+	CreatedAtNe string
+	// created_at gt
+	CreatedAtGt string
+	// created_at gte
+	CreatedAtGte string
+	// created_at lt
+	CreatedAtLt string
+	// created_at lte
+	CreatedAtLte string
+	// day_count eq
+	DayCount *float64
+	// day_count ne
+	DayCountNe *float64
+	// day_count gt
+	DayCountGt *float64
+	// day_count gte
+	DayCountGte *float64
+	// day_count lt
+	DayCountLt *float64
+	// day_count lte
+	DayCountLte *float64
+	// is_paid eq
+	IsPaid *bool
+	// mechanic_id eq
+	MechanicID string
+	// mechanic_id ne
+	MechanicIDNe string
+	// mechanic_id in
+	MechanicIDIn string
+	// order_number eq
+	OrderNumber string
+	// order_number ne
+	OrderNumberNe string
+	// order_number in
+	OrderNumberIn string
+	// order_number like
+	OrderNumberLike string
+	// start_date eq
+	StartDate string
+	// start_date ne
+	StartDateNe string
+	// start_date gt
+	StartDateGt string
+	// start_date gte
+	StartDateGte string
+	// start_date lt
+	StartDateLt string
+	// start_date lte
+	StartDateLte string
+	// status eq
+	Status string
+	// status ne
+	StatusNe string
+	// status in
+	StatusIn string
+	// tarif_id eq
+	TarifID string
+	// tarif_id ne
+	TarifIDNe string
+	// tarif_id in
+	TarifIDIn string
+	// total_price eq
+	TotalPrice *float64
+	// total_price ne
+	TotalPriceNe *float64
+	// total_price gt
+	TotalPriceGt *float64
+	// total_price gte
+	TotalPriceGte *float64
+	// total_price lt
+	TotalPriceLt *float64
+	// total_price lte
+	TotalPriceLte *float64
+	// comma separated field:asc or field:desc, by created_at, day_count, order_number, start_date, total_price, the newest first when not given
+	Sort string
+	// comma separated relations: client, car, tarif, mechanic, discount
+	Expand string
+	// comma separated fields to return, e.g. id,status,total_price or expanded.client.first_name
+	Fields string
+}
+
+func (p *OrdersListParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setInt(query, "offset", p.Offset)
+	setInt(query, "limit", p.Limit)
+	setString(query, "cursor", p.Cursor)
+	setString(query, "search", p.Search)
+	setString(query, "car_id", p.CarID)
+	setString(query, "car_id[ne]", p.CarIDNe)
+	setString(query, "car_id[in]", p.CarIDIn)
+	setString(query, "client_id", p.ClientID)
+	setString(query, "client_id[ne]", p.ClientIDNe)
+	setString(query, "client_id[in]", p.ClientIDIn)
+	setString(query, "created_at", p.CreatedAt)
+	setString(query, "created_at[ne]", p.CreatedAtNe)
+	setString(query, "created_at[gt]", p.CreatedAtGt)
+	setString(query, "created_at[gte]", p.CreatedAtGte)
+	setString(query, "created_at[lt]", p.CreatedAtLt)
+	setString(query, "created_at[lte]", p.CreatedAtLte)
+	setFloat(query, "day_count", p.DayCount)
+	setFloat(query, "day_count[ne]", p.DayCountNe)
+	setFloat(query, "day_count[gt]", p.DayCountGt)
+	setFloat(query, "day_count[gte]", p.DayCountGte)
+	setFloat(query, "day_count[lt]", p.DayCountLt)
+	setFloat(query, "day_count[lte]", p.DayCountLte)
+	setBool(query, "is_paid", p.IsPaid)
+	setString(query, "mechanic_id", p.MechanicID)
+	setString(query, "mechanic_id[ne]", p.MechanicIDNe)
+	setString(query, "mechanic_id[in]", p.MechanicIDIn)
+	setString(query, "order_number", p.OrderNumber)
+	setString(query, "order_number[ne]", p.OrderNumberNe)
+	setString(query, "order_number[in]", p.OrderNumberIn)
+	setString(query, "order_number[like]", p.OrderNumberLike)
+	setString(query, "start_date", p.StartDate)
+	setString(query, "start_date[ne]", p.StartDateNe)
+	setString(query, "start_date[gt]", p.StartDateGt)
+	setString(query, "start_date[gte]", p.StartDateGte)
+	setString(query, "start_date[lt]", p.StartDateLt)
+	setString(query, "start_date[lte]", p.StartDateLte)
+	setString(query, "status", p.Status)
+	setString(query, "status[ne]", p.StatusNe)
+	setString(query, "status[in]", p.StatusIn)
+	setString(query, "tarif_id", p.TarifID)
+	setString(query, "tarif_id[ne]", p.TarifIDNe)
+	setString(query, "tarif_id[in]", p.TarifIDIn)
+	setFloat(query, "total_price", p.TotalPrice)
+	setFloat(query, "total_price[ne]", p.TotalPriceNe)
+	setFloat(query, "total_price[gt]", p.TotalPriceGt)
+	setFloat(query, "total_price[gte]", p.TotalPriceGte)
+	setFloat(query, "total_price[lt]", p.TotalPriceLt)
+	setFloat(query, "total_price[lte]", p.TotalPriceLte)
+	setString(query, "sort", p.Sort)
+	setString(query, "expand", p.Expand)
+	setString(query, "fields", p.Fields)
+	return query, header
+}
+
+// List calls GET /order: Get Order List
+func (s *OrdersService) List(ctx context.Context, params *OrdersListParams) (*order_service.GetListOrderResponse, error) {
+	query, header := params.encode()
+	out := new(order_service.GetListOrderResponse)
+	err := s.client.do(ctx, "GET", "/order", query, header, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAll walks every item of Orders.List, following the cursors of the pages
+func (s *OrdersService) ListAll(params *OrdersListParams) *Pager[*order_service.Order] {
+	var current OrdersListParams
+	if params != nil {
+		current = *params
+	}
+
+	return newPager(func(ctx context.Context, cursor string) (Page[*order_service.Order], error) {
+		if cursor != "" {
+			current.Cursor, current.Offset = cursor, 0
+		}
+
+		resp, err := s.List(ctx, &current)
+		if err != nil {
+			return Page[*order_service.Order]{}, err
+		}
+		return Page[*order_service.Order]{Items: resp.Orders, Next: resp.NextCursor}, nil
+	})
+}
+
+// MarkOverdue calls POST /order/{id}/overdue: Mark Order Overdue
+func (s *OrdersService) MarkOverdue(ctx context.Context, id string, body *models.ChangeOrderStatus) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/overdue", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersPatchParams are the parameters of Orders.Patch
+type OrdersPatchParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *OrdersPatchParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Patch calls PATCH /order/{id}: Patch Order
+func (s *OrdersService) Patch(ctx context.Context, id string, body *models.UpdatePatch, params *OrdersPatchParams) (*order_service.Order, error) {
+	query, header := params.encode()
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "PATCH", "/order/"+url.PathEscape(id), query, header, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Pickup calls POST /order/{id}/pickup: Pickup Order
+func (s *OrdersService) Pickup(ctx context.Context, id string, body *models.VehicleHandover) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/pickup", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReleaseDeposit calls POST /order/{id}/deposit/release: Release Order Deposit
+func (s *OrdersService) ReleaseDeposit(ctx context.Context, id string, body *models.DepositRelease) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/deposit/release", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Return calls POST /order/{id}/return: Return Order
+func (s *OrdersService) Return(ctx context.Context, id string, body *models.VehicleHandover) (*order_service.Order, error) {
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "POST", "/order/"+url.PathEscape(id)+"/return", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrdersUpdateParams are the parameters of Orders.Update
+type OrdersUpdateParams struct {
+	// ETag of the version being changed
+	IfMatch string
+}
+
+func (p *OrdersUpdateParams) encode() (map[string][]string, http.Header) {
+	query, header := map[string][]string{}, http.Header{}
+	if p == nil {
+		return query, header
+	}
+	setString(header, "If-Match", p.IfMatch)
+	return query, header
+}
+
+// Update calls PUT /order/{id}: Update Order
+func (s *OrdersService) Update(ctx context.Context, id string, body *order_service.UpdateOrder, params *OrdersUpdateParams) (*order_service.Order, error) {
+	query, header := params.encode()
+	out := new(order_service.Order)
+	err := s.client.do(ctx, "PUT", "/order/"+url.PathEscape(id), query, header, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TarifsService calls the Tarif routes
+type TarifsService struct {
+	client *Client
+}
+
+// Create calls POST /tarif: Create Tarif
+func (s *TarifsService) Create(ctx context.Context, body *order_service.CreateTarif) (*order_service.Tarif, error) {
+	out := new(order_service.Tarif)
+	err := s.client.do(ctx, "POST", "/tarif", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Delete calls DELETE /tarif/{id}: Delete Tarif
+func (s *TarifsService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, "DELETE", "/tarif/"+url.PathEscape(id), nil, nil, nil, nil)
+}
+
+// GetByID calls GET /tarif/{id}: GetByID Tarif
+func (s *TarifsService) GetByID(ctx context.Context, id string) (*order_service.Tarif, error) {
+	out := new(order_service.Tarif)
+	err := s.client.do(ctx, "GET", "/tarif/"+url.PathEscape(id), nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhooksService calls the Webhook routes
+type WebhooksService struct {
+	client *Client
+}
+
+// Create calls POST /webhooks: Create Webhook
+func (s *WebhooksService) Create(ctx context.Context, body *models.CreateWebhook) (*webhook.Subscription, error) {
+	out := new(webhook.Subscription)
+	err := s.client.do(ctx, "POST", "/webhooks", nil, nil, body, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Delete calls DELETE /webhooks/{id}: Delete Webhook
+func (s *WebhooksService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, "DELETE", "/webhooks/"+url.PathEscape(id), nil, nil, nil, nil)
+}
+
+// GetDeadLetters calls GET /webhooks/dead-letters: Get Webhook Dead Letters
+func (s *WebhooksService) GetDeadLetters(ctx context.Context) (*models.WebhookDeliveryList, error) {
+	out := new(models.WebhookDeliveryList)
+	err := s.client.do(ctx, "GET", "/webhooks/dead-letters", nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// List calls GET /webhooks: Get Webhook List
+func (s *WebhooksService) List(ctx context.Context) (*models.WebhookList, error) {
+	out := new(models.WebhookList)
+	err := s.client.do(ctx, "GET", "/webhooks", nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplayDeadLetter calls POST /webhooks/dead-letters/{id}/replay: Replay Webhook Dead Letter
+func (s *WebhooksService) ReplayDeadLetter(ctx context.Context, id string) (*models.WebhookReplay, error) {
+	out := new(models.WebhookReplay)
+	err := s.client.do(ctx, "POST", "/webhooks/dead-letters/"+url.PathEscape(id)+"/replay", nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplayDeadLetters calls POST /webhooks/dead-letters/replay: Replay Webhook Dead Letters
+func (s *WebhooksService) ReplayDeadLetters(ctx context.Context) (*models.WebhookReplay, error) {
+	out := new(models.WebhookReplay)
+	err := s.client.do(ctx, "POST", "/webhooks/dead-letters/replay", nil, nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
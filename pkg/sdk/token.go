@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refreshBefore is how long before its expiry a token is replaced
+const refreshBefore = 30 * time.Second
+
+// TokenSource gives the bearer token of the requests
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a token that is never refreshed
+type StaticToken string
+
+// Token implements TokenSource
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// RefreshingToken keeps the token it got from refresh until shortly before the exp claim of the JWT,
+// or until the gateway answers 401, then asks for a new one
+func RefreshingToken(refresh func(ctx context.Context) (string, error)) TokenSource {
+	return &refreshingToken{refresh: refresh}
+}
+
+type refreshingToken struct {
+	refresh func(ctx context.Context) (string, error)
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (t *refreshingToken) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && (t.expires.IsZero() || time.Now().Add(refreshBefore).Before(t.expires)) {
+		return t.token, nil
+	}
+
+	token, err := t.refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	t.token, t.expires = token, expiry(token)
+	return t.token, nil
+}
+
+// Invalidate makes the next request refresh the token
+func (t *refreshingToken) Invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = ""
+}
+
+// expiry reads the exp claim without checking the signature, the zero time when there is none
+func expiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(claims.Exp), 0)
+}
@@ -0,0 +1,183 @@
+package stream
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The topics the events are published to
+const (
+	// TopicOrders has the new bookings and the status changes of the orders
+	TopicOrders = "orders"
+	// TopicCars has the created, changed and removed cars, their status is the availability
+	TopicCars = "cars"
+	// TopicAlerts has the orders that became overdue
+	TopicAlerts = "alerts"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it is dropped, it then
+// reconnects with Last-Event-ID and gets the missed events from the buffer
+const subscriberBuffer = 64
+
+// Event is a change pushed to the subscribers
+type Event struct {
+	// ID is the epoch of the hub and the sequence number of the event, like "lq3x9c2k-42"
+	ID    string          `json:"id"`
+	Topic string          `json:"topic"`
+	Type  string          `json:"type"`
+	Time  time.Time       `json:"time"`
+	Data  json.RawMessage `json:"data"`
+	// Owner is the client the event belongs to, clients only receive their own events of private topics
+	Owner string `json:"-"`
+
+	seq uint64
+}
+
+// Filter selects the events a subscriber receives
+type Filter func(Event) bool
+
+// Hub fans the published events out to the subscribers and keeps the latest ones so a subscriber
+// that reconnects gets the events it missed
+type Hub struct {
+	size int
+	// epoch tells the events of this process apart from the ones of an earlier run, their sequence
+	// numbers start over
+	epoch string
+
+	mu          sync.Mutex
+	lastID      uint64
+	buffer      []Event
+	subscribers map[*Subscription]struct{}
+}
+
+// NewHub creates a hub that keeps the last size events
+func NewHub(size int) *Hub {
+	if size < 1 {
+		size = 1
+	}
+
+	return &Hub{
+		size:        size,
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Subscription receives the events of a subscriber, C is closed when the subscriber falls too far behind
+type Subscription struct {
+	C <-chan Event
+	// Start is the id of the last event published before the subscription, a reset resumes from it
+	Start string
+
+	hub    *Hub
+	filter Filter
+	events chan Event
+	closed bool
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// Publish sends the event to the subscribers whose filter accepts it
+func (h *Hub) Publish(topic, eventType, owner string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := Event{
+		ID:    h.id(h.lastID),
+		Topic: topic,
+		Type:  eventType,
+		Time:  time.Now().UTC(),
+		Data:  payload,
+		Owner: owner,
+		seq:   h.lastID,
+	}
+
+	h.buffer = append(h.buffer, event)
+	if len(h.buffer) > h.size {
+		h.buffer = append([]Event(nil), h.buffer[len(h.buffer)-h.size:]...)
+	}
+
+	for subscription := range h.subscribers {
+		if !subscription.filter(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			h.remove(subscription)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe starts a subscription. With lastEventID it first returns the buffered events after it,
+// reset is true when those events are no longer buffered, or were never published by this hub, like
+// the ids of a run before a restart, and the subscriber has to load the current state again
+func (h *Hub) Subscribe(lastEventID string, filter Filter) (subscription *Subscription, missed []Event, reset bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if lastEventID != "" {
+		after, ok := h.parseID(lastEventID)
+		switch {
+		case !ok || after > h.lastID:
+			reset = true
+		case len(h.buffer) > 0 && after+1 < h.buffer[0].seq:
+			reset = true
+		default:
+			for _, event := range h.buffer {
+				if event.seq > after && filter(event) {
+					missed = append(missed, event)
+				}
+			}
+		}
+	}
+
+	events := make(chan Event, subscriberBuffer)
+	subscription = &Subscription{C: events, Start: h.id(h.lastID), hub: h, filter: filter, events: events}
+	h.subscribers[subscription] = struct{}{}
+
+	return subscription, missed, reset
+}
+
+func (h *Hub) id(seq uint64) string {
+	return h.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseID returns the sequence number of an id of this hub
+func (h *Hub) parseID(id string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(id, "-")
+	if !ok || epoch != h.epoch {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+// remove closes the subscription, h.mu must be held
+func (h *Hub) remove(subscription *Subscription) {
+	if subscription.closed {
+		return
+	}
+
+	subscription.closed = true
+	delete(h.subscribers, subscription)
+	close(subscription.events)
+}
@@ -0,0 +1,91 @@
+package stream
+
+import (
+	"testing"
+)
+
+func all(Event) bool { return true }
+
+func TestSubscribeResume(t *testing.T) {
+	hub := NewHub(3)
+	for i := 0; i < 5; i++ {
+		if err := hub.Publish(TopicOrders, "order.created", "", map[string]int{"n": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		missed      int
+		reset       bool
+	}{
+		{name: "no id", lastEventID: "", missed: 0, reset: false},
+		{name: "latest", lastEventID: hub.id(5), missed: 0, reset: false},
+		{name: "buffered", lastEventID: hub.id(3), missed: 2, reset: false},
+		{name: "just before the buffer", lastEventID: hub.id(2), missed: 3, reset: false},
+		{name: "dropped from the buffer", lastEventID: hub.id(1), reset: true},
+		{name: "ahead of the hub", lastEventID: hub.id(9), reset: true},
+		{name: "earlier run", lastEventID: "0-3", reset: true},
+		{name: "without epoch", lastEventID: "3", reset: true},
+		{name: "garbage", lastEventID: hub.epoch + "-x", reset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription, missed, reset := hub.Subscribe(tt.lastEventID, all)
+			defer subscription.Close()
+
+			if reset != tt.reset {
+				t.Fatalf("reset = %v, want %v", reset, tt.reset)
+			}
+			if len(missed) != tt.missed {
+				t.Fatalf("missed %d events, want %d", len(missed), tt.missed)
+			}
+			if subscription.Start != hub.id(5) {
+				t.Fatalf("start = %s, want %s", subscription.Start, hub.id(5))
+			}
+		})
+	}
+}
+
+func TestEpochsDiffer(t *testing.T) {
+	first, second := NewHub(1), NewHub(1)
+	if err := first.Publish(TopicCars, "car.created", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Publish(TopicCars, "car.created", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.epoch == second.epoch {
+		t.Skip("both hubs were created in the same clock tick")
+	}
+
+	_, _, reset := second.Subscribe(first.id(1), all)
+	if !reset {
+		t.Fatal("an id of another run resumed instead of resetting")
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	hub := NewHub(1)
+	subscription, _, _ := hub.Subscribe("", all)
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		if err := hub.Publish(TopicCars, "car.updated", "", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	received := 0
+	for range subscription.C {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Fatalf("received %d events before the channel closed, want %d", received, subscriberBuffer)
+	}
+
+	// closing twice is harmless
+	subscription.Close()
+}
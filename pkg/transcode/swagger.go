@@ -0,0 +1,204 @@
+package transcode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/swaggo/swag"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Swagger is the Swagger 2.0 document of the hand written handlers with the operations of the
+// transcoded routes added, their schemas are generated from the proto messages
+type Swagger struct {
+	Base   swag.Swagger
+	Routes []Route
+
+	once sync.Once
+	doc  string
+}
+
+// ReadDoc implements swag.Swagger
+func (s *Swagger) ReadDoc() string {
+	s.once.Do(func() {
+		s.doc = s.merge()
+	})
+	return s.doc
+}
+
+func (s *Swagger) merge() string {
+	base := s.Base.ReadDoc()
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(base), &doc); err != nil {
+		return base
+	}
+
+	paths := object(doc, "paths")
+	definitions := object(doc, "definitions")
+
+	for _, route := range s.Routes {
+		item := object(paths, route.Template)
+		method := strings.ToLower(route.Method)
+		if _, ok := item[method]; ok {
+			continue
+		}
+		item[method] = operation(route, definitions)
+	}
+
+	merged, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return base
+	}
+	return string(merged)
+}
+
+func operation(route Route, definitions map[string]interface{}) map[string]interface{} {
+	var (
+		service    = route.RPC.Parent().(protoreflect.ServiceDescriptor)
+		tag        = strings.TrimSuffix(string(service.Name()), "Service")
+		parameters []interface{}
+		bound      = map[string]bool{}
+	)
+
+	for _, name := range route.Variables {
+		parameters = append(parameters, map[string]interface{}{
+			"type":     "string",
+			"name":     name,
+			"in":       "path",
+			"required": true,
+		})
+		bound[name] = true
+	}
+
+	switch route.Body {
+	case "":
+		fields := route.RPC.Input().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if bound[string(field.Name())] || field.Kind() == protoreflect.MessageKind {
+				continue
+			}
+			parameter := scalar(field)
+			parameter["name"] = string(field.Name())
+			parameter["in"] = "query"
+			if field.IsList() {
+				parameter["collectionFormat"] = "csv"
+			}
+			parameters = append(parameters, parameter)
+		}
+	case "*":
+		parameters = append(parameters, body(route.RPC.Input(), definitions))
+	default:
+		field := route.RPC.Input().Fields().ByName(protoreflect.Name(route.Body))
+		parameters = append(parameters, body(field.Message(), definitions))
+	}
+
+	success := map[string]interface{}{"description": "OK"}
+	if code := route.SuccessCode(); code != 204 {
+		success["schema"] = reference(route.RPC.Output(), definitions)
+	}
+
+	return map[string]interface{}{
+		"description": fmt.Sprintf("Calls %s, the route is generated from its google.api.http annotation", route.RPC.FullName()),
+		"consumes":    []string{"application/json"},
+		"produces":    []string{"application/json"},
+		"tags":        []string{tag},
+		"summary":     fmt.Sprintf("%s %s", route.RPC.Name(), tag),
+		"operationId": strings.ToLower(fmt.Sprintf("%s_%s", tag, route.RPC.Name())),
+		"parameters":  parameters,
+		"responses": map[string]interface{}{
+			fmt.Sprint(route.SuccessCode()): success,
+			"400":                           errorResponse("Invalid Argument"),
+			"500":                           errorResponse("Server Error"),
+		},
+	}
+}
+
+func body(message protoreflect.MessageDescriptor, definitions map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": string(message.Name()),
+		"name":        "body",
+		"in":          "body",
+		"required":    true,
+		"schema":      reference(message, definitions),
+	}
+}
+
+func errorResponse(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"schema":      map[string]interface{}{"type": "string"},
+	}
+}
+
+// reference points to the definition of the message, named like swag names the generated types,
+// the definition is generated when the document has none
+func reference(message protoreflect.MessageDescriptor, definitions map[string]interface{}) map[string]interface{} {
+	name := fmt.Sprintf("%s.%s", message.ParentFile().Package(), message.Name())
+
+	if _, ok := definitions[name]; !ok {
+		properties := map[string]interface{}{}
+		definitions[name] = map[string]interface{}{"type": "object", "properties": properties}
+
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			properties[string(fields.Get(i).Name())] = schema(fields.Get(i), definitions)
+		}
+	}
+
+	return map[string]interface{}{"$ref": "#/definitions/" + name}
+}
+
+func schema(field protoreflect.FieldDescriptor, definitions map[string]interface{}) map[string]interface{} {
+	var item map[string]interface{}
+
+	switch {
+	case field.IsMap():
+		return map[string]interface{}{"type": "object"}
+	case field.Kind() == protoreflect.MessageKind && field.Message().FullName() == "google.protobuf.Struct":
+		item = map[string]interface{}{"type": "object"}
+	case field.Kind() == protoreflect.MessageKind:
+		item = reference(field.Message(), definitions)
+	default:
+		item = scalar(field)
+	}
+
+	if field.IsList() {
+		return map[string]interface{}{"type": "array", "items": item}
+	}
+	return item
+}
+
+func scalar(field protoreflect.FieldDescriptor) map[string]interface{} {
+	var kind string
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		kind = "boolean"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		kind = "number"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		kind = "integer"
+	default:
+		kind = "string"
+	}
+
+	if field.IsList() {
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": kind}}
+	}
+	return map[string]interface{}{"type": kind}
+}
+
+func object(parent map[string]interface{}, key string) map[string]interface{} {
+	child, ok := parent[key].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		parent[key] = child
+	}
+	return child
+}
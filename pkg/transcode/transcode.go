@@ -0,0 +1,337 @@
+package transcode
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/media"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// variablePattern matches the "{id}" segments of a google.api.http path template
+var variablePattern = regexp.MustCompile(`\{([a-z_][a-z0-9_]*)(=[^}]*)?\}`)
+
+// Route is an rpc exposed over REST by its google.api.http annotation
+type Route struct {
+	// Method is the HTTP method, like GET
+	Method string
+	// Template is the annotated path, like /model/{id}
+	Template string
+	// Path is the template in gin syntax, like /model/:id
+	Path string
+	// Variables are the request fields set from the path
+	Variables []string
+	// Body is "*" when the whole request is read from the JSON body, a field name when only that field is
+	Body string
+	RPC  protoreflect.MethodDescriptor
+}
+
+// Routes reads the google.api.http rules of the rpcs of the files, rpcs without a rule are not exposed
+func Routes(files ...protoreflect.FileDescriptor) ([]Route, error) {
+	var routes []Route
+
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for k := 0; k < methods.Len(); k++ {
+				rpc := methods.Get(k)
+
+				rule, ok := proto.GetExtension(rpc.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if !ok || rule == nil {
+					continue
+				}
+
+				route, err := newRoute(rpc, rule)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", rpc.FullName(), err)
+				}
+				routes = append(routes, route)
+			}
+		}
+	}
+
+	return routes, nil
+}
+
+func newRoute(rpc protoreflect.MethodDescriptor, rule *annotations.HttpRule) (Route, error) {
+	route := Route{RPC: rpc, Body: rule.GetBody()}
+
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		route.Method, route.Template = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		route.Method, route.Template = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		route.Method, route.Template = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		route.Method, route.Template = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		route.Method, route.Template = http.MethodDelete, pattern.Delete
+	default:
+		return route, fmt.Errorf("http rule has no supported method")
+	}
+
+	input := rpc.Input().Fields()
+
+	route.Path = variablePattern.ReplaceAllStringFunc(route.Template, func(variable string) string {
+		name := variablePattern.FindStringSubmatch(variable)[1]
+		route.Variables = append(route.Variables, name)
+		return ":" + name
+	})
+	if strings.ContainsAny(route.Path, "{}") {
+		return route, fmt.Errorf("path %q has a variable that is not a plain field name", route.Template)
+	}
+
+	for _, name := range route.Variables {
+		field := input.ByName(protoreflect.Name(name))
+		if field == nil || field.IsList() || field.Kind() == protoreflect.MessageKind {
+			return route, fmt.Errorf("path variable %q is not a scalar field of %s", name, rpc.Input().FullName())
+		}
+	}
+
+	if route.Body != "" && route.Body != "*" {
+		field := input.ByName(protoreflect.Name(route.Body))
+		if field == nil || field.Kind() != protoreflect.MessageKind || field.IsList() {
+			return route, fmt.Errorf("body %q is not a message field of %s", route.Body, rpc.Input().FullName())
+		}
+	}
+
+	return route, nil
+}
+
+// Missing returns the routes that are not registered yet, a hand written handler for the same method
+// and path overrides the annotated rpc
+func Missing(routes []Route, registered gin.RoutesInfo) []Route {
+	taken := map[string]bool{}
+	for _, info := range registered {
+		taken[info.Method+" "+shape(info.Path)] = true
+	}
+
+	var missing []Route
+	for _, route := range routes {
+		if !taken[route.Method+" "+shape(route.Path)] {
+			missing = append(missing, route)
+		}
+	}
+	return missing
+}
+
+// shape drops the names of the path parameters, /order/:order_id/payments and /order/:id/payments
+// are the same route for gin
+func shape(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = ":"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// SuccessCode is the status of a successful call, 201 for creating rpcs and 204 for rpcs without a result
+func (r Route) SuccessCode() int {
+	switch {
+	case r.RPC.Output().FullName() == "google.protobuf.Empty":
+		return http.StatusNoContent
+	case r.Method == http.MethodPost && strings.HasPrefix(string(r.RPC.Name()), "Create"):
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+// Bind builds the rpc request from the JSON body, the path and, for the fields that are neither,
+// the query string, ids are checked to be UUIDs
+func (r Route) Bind(c *gin.Context) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(r.RPC.Input().FullName())
+	if err != nil {
+		return nil, err
+	}
+
+	message := messageType.New()
+	fields := message.Descriptor().Fields()
+	bound := map[string]bool{}
+
+	if r.Body != "" {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBody, err)
+		}
+
+		if len(bytes.TrimSpace(data)) > 0 {
+			target := message
+			if r.Body != "*" {
+				target = message.Mutable(fields.ByName(protoreflect.Name(r.Body))).Message()
+				bound[r.Body] = true
+			}
+
+			err = unmarshalBody(c.GetHeader("Content-Type"), data, target.Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, name := range r.Variables {
+		err = set(message, fields.ByName(protoreflect.Name(name)), c.Param(name))
+		if err != nil {
+			return nil, err
+		}
+		bound[name] = true
+	}
+
+	if r.Body != "*" {
+		for key, values := range c.Request.URL.Query() {
+			field := fields.ByName(protoreflect.Name(key))
+			if field == nil || bound[key] || field.Kind() == protoreflect.MessageKind || field.IsMap() {
+				continue
+			}
+
+			for _, value := range values {
+				err = set(message, field, value)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, name := range r.Variables {
+		if !isID(name) {
+			continue
+		}
+		if id := message.Get(fields.ByName(protoreflect.Name(name))).String(); !util.IsValidUUID(id) {
+			return nil, fmt.Errorf("%s is an invalid uuid", strings.ReplaceAll(name, "_", " "))
+		}
+	}
+
+	return message.Interface(), nil
+}
+
+func isID(name string) bool {
+	return name == "id" || strings.HasSuffix(name, "_id")
+}
+
+// set parses the text into the field, the values of repeated fields are separated by commas
+func set(message protoreflect.Message, field protoreflect.FieldDescriptor, text string) error {
+	if field.IsList() {
+		list := message.Mutable(field).List()
+		for _, item := range strings.Split(text, ",") {
+			value, err := parse(field, strings.TrimSpace(item))
+			if err != nil {
+				return err
+			}
+			list.Append(value)
+		}
+		return nil
+	}
+
+	value, err := parse(field, text)
+	if err != nil {
+		return err
+	}
+	message.Set(field, value)
+	return nil
+}
+
+func parse(field protoreflect.FieldDescriptor, text string) (protoreflect.Value, error) {
+	name := field.Name()
+
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(text), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(text)), nil
+	case protoreflect.BoolKind:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s must be true or false", name)
+		}
+		return protoreflect.ValueOfBool(value), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		value, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s must be an integer", name)
+		}
+		return protoreflect.ValueOfInt32(int32(value)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s must be an integer", name)
+		}
+		return protoreflect.ValueOfInt64(value), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		value, err := strconv.ParseUint(text, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s must be a positive integer", name)
+		}
+		return protoreflect.ValueOfUint32(uint32(value)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		value, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s must be a positive integer", name)
+		}
+		return protoreflect.ValueOfUint64(value), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s must be a number", name)
+		}
+		if field.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(value)), nil
+		}
+		return protoreflect.ValueOfFloat64(value), nil
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByName(protoreflect.Name(text)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		number, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%s has no value %q", name, text)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(number)), nil
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("%s cannot be set from the url", name)
+}
+
+// ErrInvalidBody is returned by Bind when the body cannot be decoded into the request message
+var ErrInvalidBody = errors.New("invalid body")
+
+// bodyOptions skip the fields the gateway does not know yet, a client built against a newer proto
+// is not refused by an older gateway
+var bodyOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// unmarshalBody decodes the body by its Content-Type, MessagePack is read as the JSON it maps to
+func unmarshalBody(contentType string, data []byte, target proto.Message) error {
+	mediaType, err := media.Type(contentType)
+	if err != nil {
+		return err
+	}
+
+	if mediaType == media.Protobuf {
+		err = proto.Unmarshal(data, target)
+	} else {
+		data, err = media.ToJSON(mediaType, data)
+		if err == nil {
+			err = bodyOptions.Unmarshal(data, target)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBody, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package transcode
+
+import (
+	"Projects/Car24/car24_api_gateway/genproto/client_service"
+	"Projects/Car24/car24_api_gateway/pkg/media"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBindBody(t *testing.T) {
+	routes, err := Routes(client_service.File_client_service_proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var create Route
+	for _, route := range routes {
+		if route.Method == http.MethodPost && route.Template == "/user" {
+			create = route
+		}
+	}
+	if create.RPC == nil {
+		t.Fatal("no POST /user route")
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		firstName   string
+		err         error
+	}{
+		{name: "valid", contentType: "application/json", body: `{"first_name":"Ali"}`, firstName: "Ali"},
+		{name: "unknown field", contentType: "application/json", body: `{"first_name":"Ali","added_later":1}`, firstName: "Ali"},
+		{name: "empty", contentType: "application/json", body: ``},
+		{name: "malformed", contentType: "application/json", body: `{"first_name":`, err: ErrInvalidBody},
+		{name: "wrong type", contentType: "application/json", body: `{"first_name":1}`, err: ErrInvalidBody},
+		{name: "malformed protobuf", contentType: "application/x-protobuf", body: "\xff\xff", err: ErrInvalidBody},
+		{name: "unsupported media type", contentType: "text/plain", body: `first_name=Ali`, err: media.ErrUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/user", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", tt.contentType)
+
+			request, err := create.Bind(c)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if got := request.(*client_service.CreateClient).FirstName; got != tt.firstName {
+				t.Fatalf("first_name = %q, want %q", got, tt.firstName)
+			}
+		})
+	}
+}
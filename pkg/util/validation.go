@@ -36,3 +36,31 @@ func IsValidPrice(price string) bool {
 	r := regexp.MustCompile(`^\d+$`)
 	return r.MatchString(price)
 }
+
+// IsValidPINFL checks the 14 digit personal identification number and its check digit
+func IsValidPINFL(pinfl string) bool {
+	r := regexp.MustCompile(`^[1-6][0-9]{13}$`)
+	if !r.MatchString(pinfl) {
+		return false
+	}
+
+	weights := []int{7, 3, 1}
+	sum := 0
+	for i := 0; i < 13; i++ {
+		sum += int(pinfl[i]-'0') * weights[i%3]
+	}
+
+	return sum%10 == int(pinfl[13]-'0')
+}
+
+// IsValidPassport checks the passport series and number, e.g. AA1234567
+func IsValidPassport(passport string) bool {
+	r := regexp.MustCompile(`^[A-Z]{2} ?[0-9]{7}$`)
+	return r.MatchString(passport)
+}
+
+// IsValidDrivingLicense checks the driving license series and number, e.g. AF1234567
+func IsValidDrivingLicense(license string) bool {
+	r := regexp.MustCompile(`^[A-Z]{2} ?[0-9]{7}$`)
+	return r.MatchString(license)
+}
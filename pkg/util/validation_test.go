@@ -0,0 +1,74 @@
+package util
+
+import "testing"
+
+func TestIsValidPINFL(t *testing.T) {
+	tests := []struct {
+		name  string
+		pinfl string
+		valid bool
+	}{
+		{name: "valid", pinfl: "31506870020077", valid: true},
+		{name: "wrong check digit", pinfl: "31506870020078"},
+		{name: "too short", pinfl: "3150687002007"},
+		{name: "too long", pinfl: "315068700200770"},
+		{name: "unknown first digit", pinfl: "71506870020077"},
+		{name: "letters", pinfl: "3150687002007A"},
+		{name: "empty", pinfl: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidPINFL(tt.pinfl); got != tt.valid {
+				t.Fatalf("IsValidPINFL(%q) = %v, want %v", tt.pinfl, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestIsValidPassport(t *testing.T) {
+	tests := []struct {
+		name     string
+		passport string
+		valid    bool
+	}{
+		{name: "valid", passport: "AA1234567", valid: true},
+		{name: "with a space", passport: "AA 1234567", valid: true},
+		{name: "lower case series", passport: "aa1234567"},
+		{name: "one letter", passport: "A1234567"},
+		{name: "six digits", passport: "AA123456"},
+		{name: "eight digits", passport: "AA12345678"},
+		{name: "empty", passport: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidPassport(tt.passport); got != tt.valid {
+				t.Fatalf("IsValidPassport(%q) = %v, want %v", tt.passport, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestIsValidDrivingLicense(t *testing.T) {
+	tests := []struct {
+		name    string
+		license string
+		valid   bool
+	}{
+		{name: "valid", license: "AF1234567", valid: true},
+		{name: "with a space", license: "AF 1234567", valid: true},
+		{name: "two spaces", license: "AF  1234567"},
+		{name: "digits first", license: "1234567AF"},
+		{name: "cyrillic series", license: "АФ1234567"},
+		{name: "empty", license: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidDrivingLicense(tt.license); got != tt.valid {
+				t.Fatalf("IsValidDrivingLicense(%q) = %v, want %v", tt.license, got, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/helper"
+	"Projects/Car24/car24_api_gateway/pkg/util"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FieldError describes why a single field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Client fields checked by the validation
+const (
+	FieldPhoneNumber            = "phone_number"
+	FieldAdditionalPhoneNumber  = "additional_phone_number"
+	FieldPassportPinfl          = "passport_pinfl"
+	FieldPassportNumber         = "passport_number"
+	FieldDrivingLicenseNumber   = "driving_license_number"
+	FieldDrivingNumberGivenDate = "driving_number_given_date"
+	FieldDrivingNumberExpired   = "driving_number_expired"
+)
+
+var clientFormats = []struct {
+	field   string
+	isValid func(string) bool
+	message string
+}{
+	{FieldPhoneNumber, util.IsValidPhone, "must be in +998XXXXXXXXX format"},
+	{FieldAdditionalPhoneNumber, util.IsValidPhone, "must be in +998XXXXXXXXX format"},
+	{FieldPassportPinfl, util.IsValidPINFL, "must be 14 digits with a valid check digit"},
+	{FieldPassportNumber, util.IsValidPassport, "must be two capital letters followed by 7 digits"},
+	{FieldDrivingLicenseNumber, util.IsValidDrivingLicense, "must be two capital letters followed by 7 digits"},
+}
+
+// Client validates client fields and returns every problem found, required fields must be present and not empty
+func Client(values map[string]string, required ...string) []FieldError {
+	errs := Required(values, required...)
+
+	for _, format := range clientFormats {
+		value, ok := values[format.field]
+		if !ok || value == "" {
+			continue
+		}
+		if !format.isValid(value) {
+			errs = append(errs, FieldError{Field: format.field, Message: format.message})
+		}
+	}
+
+	return append(errs, drivingLicenseDates(values[FieldDrivingNumberGivenDate], values[FieldDrivingNumberExpired])...)
+}
+
+// Required returns an error for each of the required fields that is missing or empty
+func Required(values map[string]string, required ...string) []FieldError {
+	var errs []FieldError
+
+	for _, field := range required {
+		if strings.TrimSpace(values[field]) == "" {
+			errs = append(errs, FieldError{Field: field, Message: "is required"})
+		}
+	}
+
+	return errs
+}
+
+func drivingLicenseDates(givenValue, expiredValue string) []FieldError {
+	var (
+		errs           []FieldError
+		given, expired time.Time
+		err            error
+	)
+
+	if givenValue != "" {
+		given, err = helper.ParseDateTime(givenValue)
+		if err != nil {
+			errs = append(errs, FieldError{Field: FieldDrivingNumberGivenDate, Message: err.Error()})
+		} else if given.After(time.Now()) {
+			errs = append(errs, FieldError{Field: FieldDrivingNumberGivenDate, Message: "must not be in the future"})
+		}
+	}
+
+	if expiredValue != "" {
+		expired, err = helper.ParseDateTime(expiredValue)
+		if err != nil {
+			errs = append(errs, FieldError{Field: FieldDrivingNumberExpired, Message: err.Error()})
+		}
+	}
+
+	if !given.IsZero() && !expired.IsZero() && !expired.After(given) {
+		errs = append(errs, FieldError{
+			Field:   FieldDrivingNumberExpired,
+			Message: fmt.Sprintf("must be after %s %s", FieldDrivingNumberGivenDate, given.Format("2006-01-02")),
+		})
+	}
+
+	return errs
+}
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		required []string
+		invalid  []string
+	}{
+		{name: "valid", values: map[string]string{FieldPhoneNumber: "+998901234567", FieldPassportNumber: "AA1234567"}, required: []string{FieldPhoneNumber}},
+		{name: "required missing", values: map[string]string{FieldPassportNumber: "AA1234567"}, required: []string{FieldPhoneNumber}, invalid: []string{FieldPhoneNumber}},
+		{name: "required empty", values: map[string]string{FieldPhoneNumber: ""}, required: []string{FieldPhoneNumber}, invalid: []string{FieldPhoneNumber}},
+		{name: "empty optional field", values: map[string]string{FieldAdditionalPhoneNumber: ""}},
+		{name: "bad format", values: map[string]string{FieldPassportNumber: "bad"}, invalid: []string{FieldPassportNumber}},
+		{name: "license expired before given", values: map[string]string{FieldDrivingNumberGivenDate: "2020-01-01", FieldDrivingNumberExpired: "2019-01-01"}, invalid: []string{FieldDrivingNumberExpired}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalid []string
+			for _, err := range Client(tt.values, tt.required...) {
+				invalid = append(invalid, err.Field)
+			}
+			if !reflect.DeepEqual(invalid, tt.invalid) {
+				t.Fatalf("invalid fields %v, want %v", invalid, tt.invalid)
+			}
+		})
+	}
+}
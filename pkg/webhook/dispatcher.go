@@ -0,0 +1,541 @@
+package webhook
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned for an unknown subscription or dead letter
+	ErrNotFound = errors.New("not found")
+	// ErrSubscriptionRemoved is returned when the dead letter belongs to a removed subscription
+	ErrSubscriptionRemoved = errors.New("the subscription of the delivery was removed")
+	// ErrQueueFull is returned when a dead letter cannot be replayed because too many deliveries wait
+	ErrQueueFull = errors.New("the delivery queue is full")
+)
+
+// Options configure the retries of the dispatcher
+type Options struct {
+	// MaxAttempts is how many times a delivery is sent before it becomes a dead letter
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles with every retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds a single attempt and a call of the store
+	Timeout time.Duration
+	// MaxDeadLetters is how many failed deliveries are kept, the oldest are dropped first
+	MaxDeadLetters int
+	// Concurrency is how many attempts are sent at the same time
+	Concurrency int
+	// QueueSize is how many deliveries may wait for an attempt, a delivery that finds the queue full
+	// becomes a dead letter right away. As many wait to be saved as dead letters, the ones that find
+	// no room are dropped and counted by Dropped
+	QueueSize int
+	// Refresh is how often the subscriptions are loaded from the store, the ones added or removed on
+	// another replica are seen after it
+	Refresh time.Duration
+}
+
+// Dispatcher delivers the published events to the subscriptions of the store in the background,
+// failed deliveries are retried with exponential backoff. A fixed number of workers sends the queued
+// deliveries, a retry waits on a timer and is queued again when it is due. The deliveries that failed
+// are saved as dead letters by another goroutine, the ones that find the queue full included, so
+// Publish never calls the store
+type Dispatcher struct {
+	options Options
+	store   Store
+	client  *http.Client
+	log     logger.LoggerI
+	queue   chan *Delivery
+	dead    chan *Delivery
+	// ready is closed once the subscriptions were loaded from the store the first time
+	ready chan struct{}
+	// done is closed by Close
+	done      chan struct{}
+	closeOnce sync.Once
+	dropped   uint64
+
+	mu sync.Mutex
+	// subscriptions is the copy of the subscriptions of the store the events are published to
+	subscriptions map[string]*Subscription
+	// changes counts the subscriptions added and removed here, a copy loaded before one of them is stale
+	changes uint64
+	// early are the events published before ready, they are sent once the subscriptions are known
+	early []Event
+}
+
+// NewDispatcher creates a dispatcher of the subscriptions of the store and starts its workers, the
+// subscriptions are loaded in the background
+func NewDispatcher(options Options, store Store, log logger.LoggerI) *Dispatcher {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+	if options.QueueSize < 1 {
+		options.QueueSize = 1
+	}
+
+	d := &Dispatcher{
+		options:       options,
+		store:         store,
+		client:        &http.Client{Timeout: options.Timeout},
+		log:           log,
+		queue:         make(chan *Delivery, options.QueueSize),
+		dead:          make(chan *Delivery, options.QueueSize),
+		ready:         make(chan struct{}),
+		done:          make(chan struct{}),
+		subscriptions: map[string]*Subscription{},
+	}
+
+	for i := 0; i < options.Concurrency; i++ {
+		go d.work()
+	}
+	go d.buryAll()
+	go d.refresh()
+
+	return d
+}
+
+// Close stops the workers and the refresh, the deliveries that still wait are dropped
+func (d *Dispatcher) Close() {
+	d.closeOnce.Do(func() {
+		close(d.done)
+	})
+}
+
+// Dropped counts the failed deliveries that were not saved as dead letters because too many waited
+func (d *Dispatcher) Dropped() uint64 {
+	return atomic.LoadUint64(&d.dropped)
+}
+
+// Subscribe adds a subscription, the arguments are checked with Validate
+func (d *Dispatcher) Subscribe(ctx context.Context, target string, events []string, secret string) (*Subscription, error) {
+	err := Validate(target, events, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		ID:        newID(),
+		URL:       target,
+		Events:    events,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = d.store.SaveSubscription(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.subscriptions[subscription.ID] = subscription
+	d.changes++
+	d.mu.Unlock()
+
+	return subscription, nil
+}
+
+// Subscriptions lists the subscriptions of the store, the oldest first
+func (d *Dispatcher) Subscriptions(ctx context.Context) ([]*Subscription, error) {
+	return d.load(ctx)
+}
+
+// Unsubscribe removes the subscription, the deliveries on their way to it are dropped
+func (d *Dispatcher) Unsubscribe(ctx context.Context, id string) error {
+	err := d.store.DeleteSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	delete(d.subscriptions, id)
+	d.changes++
+	d.mu.Unlock()
+
+	return nil
+}
+
+// Publish sends the event with the data encoded as JSON to every subscription that wants it
+func (d *Dispatcher) Publish(eventType string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		d.log.Error("webhook event", logger.String("event", eventType), logger.Error(err))
+		return
+	}
+
+	event := Event{
+		ID:        newID(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      payload,
+	}
+
+	d.mu.Lock()
+	select {
+	case <-d.ready:
+	default:
+		if len(d.early) < d.options.QueueSize {
+			d.early = append(d.early, event)
+		} else {
+			d.drop(&Delivery{Event: event})
+		}
+		d.mu.Unlock()
+		return
+	}
+	d.mu.Unlock()
+
+	d.fanOut(event)
+}
+
+// fanOut queues a delivery of the event to every subscription that wants it
+func (d *Dispatcher) fanOut(event Event) {
+	d.mu.Lock()
+	var deliveries []*Delivery
+	for _, subscription := range d.subscriptions {
+		if !subscription.Wants(event.Type) {
+			continue
+		}
+
+		deliveries = append(deliveries, &Delivery{
+			ID:             newID(),
+			SubscriptionID: subscription.ID,
+			URL:            subscription.URL,
+			Event:          event,
+		})
+	}
+	d.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		d.enqueue(delivery)
+	}
+}
+
+// DeadLetters lists the deliveries that failed every attempt, the latest first
+func (d *Dispatcher) DeadLetters(ctx context.Context) ([]*Delivery, error) {
+	return d.store.DeadLetters(ctx)
+}
+
+// Replay sends the dead letter again with the current secret of its subscription, the attempts start over
+func (d *Dispatcher) Replay(ctx context.Context, id string) error {
+	delivery, err := d.store.DeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return d.restart(ctx, delivery)
+}
+
+// ReplayAll sends every dead letter of the existing subscriptions again and returns how many were
+// sent, it stops when the queue is full
+func (d *Dispatcher) ReplayAll(ctx context.Context) (int, error) {
+	deliveries, err := d.store.DeadLetters(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, delivery := range deliveries {
+		err = d.restart(ctx, delivery)
+		switch {
+		case errors.Is(err, ErrSubscriptionRemoved):
+			continue
+		case errors.Is(err, ErrQueueFull):
+			return replayed, nil
+		case err != nil:
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// restart resets the dead letter and queues it, the dead letter is only deleted once it is queued
+func (d *Dispatcher) restart(ctx context.Context, delivery *Delivery) error {
+	select {
+	case <-d.ready:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	d.mu.Lock()
+	subscription, ok := d.subscriptions[delivery.SubscriptionID]
+	d.mu.Unlock()
+	if !ok {
+		return ErrSubscriptionRemoved
+	}
+
+	if len(d.queue) == cap(d.queue) {
+		return ErrQueueFull
+	}
+
+	err := d.store.DeleteDeadLetter(ctx, delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	delivery.URL = subscription.URL
+	delivery.Attempts, delivery.LastStatus, delivery.LastError, delivery.FailedAt = 0, 0, "", time.Time{}
+	d.enqueue(delivery)
+
+	return nil
+}
+
+// enqueue hands the delivery to the workers, it becomes a dead letter when the queue is full
+func (d *Dispatcher) enqueue(delivery *Delivery) {
+	select {
+	case d.queue <- delivery:
+	default:
+		delivery.LastStatus, delivery.LastError = 0, ErrQueueFull.Error()
+		d.bury(delivery)
+	}
+}
+
+// work sends the queued deliveries one at a time until Close
+func (d *Dispatcher) work() {
+	for {
+		select {
+		case <-d.done:
+			return
+		case delivery := <-d.queue:
+			d.attempt(delivery)
+		}
+	}
+}
+
+// attempt sends the delivery once, a retryable failure is queued again after the backoff
+func (d *Dispatcher) attempt(delivery *Delivery) {
+	d.mu.Lock()
+	subscription, ok := d.subscriptions[delivery.SubscriptionID]
+	d.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		d.log.Error("webhook delivery", logger.String("delivery", delivery.ID), logger.Error(err))
+		return
+	}
+
+	status, err := d.send(subscription, delivery, body)
+	delivery.Attempts++
+	if err == nil {
+		return
+	}
+	delivery.LastStatus, delivery.LastError = status, err.Error()
+
+	if !retryable(status) || delivery.Attempts >= d.options.MaxAttempts {
+		d.bury(delivery)
+		return
+	}
+
+	time.AfterFunc(d.backoff(delivery.Attempts), func() {
+		select {
+		case <-d.done:
+		default:
+			d.enqueue(delivery)
+		}
+	})
+}
+
+// refresh loads the subscriptions of the store, first right away and then every Refresh until Close
+func (d *Dispatcher) refresh() {
+	d.loadInBackground()
+	d.start()
+
+	if d.options.Refresh <= 0 {
+		return
+	}
+	ticker := time.NewTicker(d.options.Refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+			d.loadInBackground()
+		}
+	}
+}
+
+// loadInBackground loads the subscriptions bounded by Timeout, a failure is only logged
+func (d *Dispatcher) loadInBackground() {
+	ctx, cancel := d.storeContext()
+	defer cancel()
+
+	_, err := d.load(ctx)
+	if err != nil {
+		d.log.Error("load webhook subscriptions", logger.Error(err))
+	}
+}
+
+// start marks the subscriptions as known and sends the events published before
+func (d *Dispatcher) start() {
+	d.mu.Lock()
+	close(d.ready)
+	early := d.early
+	d.early = nil
+	d.mu.Unlock()
+
+	for _, event := range early {
+		d.fanOut(event)
+	}
+}
+
+// load reads the subscriptions of the store and keeps them as the copy, unless they were changed here
+// while the store was read. Before the first copy the changes made here are added to the loaded ones
+func (d *Dispatcher) load(ctx context.Context) ([]*Subscription, error) {
+	d.mu.Lock()
+	changes := d.changes
+	d.mu.Unlock()
+
+	subscriptions, err := d.store.Subscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Subscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID] = subscription
+	}
+
+	d.mu.Lock()
+	select {
+	case <-d.ready:
+		if d.changes == changes {
+			d.subscriptions = byID
+		}
+	default:
+		for id, subscription := range d.subscriptions {
+			byID[id] = subscription
+		}
+		d.subscriptions = byID
+	}
+	d.mu.Unlock()
+
+	return subscriptions, nil
+}
+
+// storeContext bounds the calls of the store made in the background by Timeout
+func (d *Dispatcher) storeContext() (context.Context, context.CancelFunc) {
+	if d.options.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), d.options.Timeout)
+}
+
+// send makes one attempt, an answer outside 2xx is an error
+func (d *Dispatcher) send(subscription *Subscription, delivery *Delivery, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event.Type)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("answered %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// bury hands the failed delivery to buryAll, it is dropped when too many wait
+func (d *Dispatcher) bury(delivery *Delivery) {
+	delivery.FailedAt = time.Now().UTC()
+
+	select {
+	case d.dead <- delivery:
+	default:
+		d.drop(delivery)
+	}
+}
+
+// drop counts and logs a failed delivery that is not kept as a dead letter
+func (d *Dispatcher) drop(delivery *Delivery) {
+	dropped := atomic.AddUint64(&d.dropped, 1)
+	d.log.Error(
+		"webhook delivery dropped",
+		logger.String("delivery", delivery.ID),
+		logger.String("event", delivery.Event.Type),
+		logger.String("event_id", delivery.Event.ID),
+		logger.Int("dropped", int(dropped)),
+	)
+}
+
+// buryAll saves the failed deliveries as dead letters one at a time until Close
+func (d *Dispatcher) buryAll() {
+	for {
+		select {
+		case <-d.done:
+			return
+		case delivery := <-d.dead:
+			d.save(delivery)
+		}
+	}
+}
+
+// save keeps the failed delivery as a dead letter in the store
+func (d *Dispatcher) save(delivery *Delivery) {
+	d.log.Warn(
+		"webhook delivery failed",
+		logger.String("delivery", delivery.ID),
+		logger.String("event", delivery.Event.Type),
+		logger.String("url", delivery.URL),
+		logger.Int("attempts", delivery.Attempts),
+		logger.String("error", delivery.LastError),
+	)
+
+	ctx, cancel := d.storeContext()
+	defer cancel()
+
+	err := d.store.SaveDeadLetter(ctx, delivery, d.options.MaxDeadLetters)
+	if err != nil {
+		d.log.Error("save webhook dead letter", logger.String("delivery", delivery.ID), logger.Error(err))
+	}
+}
+
+// backoff is the wait after the given number of attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.options.Backoff
+	for i := 1; i < attempts && wait < d.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if d.options.MaxBackoff > 0 && wait > d.options.MaxBackoff {
+		wait = d.options.MaxBackoff
+	}
+	return wait
+}
+
+// retryable reports whether a failed attempt may succeed later: network errors, timeouts, rate limits
+// and server errors, the other client errors mean the receiver refuses the delivery
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
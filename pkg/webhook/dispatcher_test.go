@@ -0,0 +1,260 @@
+package webhook
+
+import (
+	"Projects/Car24/car24_api_gateway/pkg/logger"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef"
+
+// newTestDispatcher creates a dispatcher that loaded the subscriptions of the store
+func newTestDispatcher(t *testing.T, store Store, options Options) *Dispatcher {
+	t.Helper()
+	options.Timeout = time.Second
+	options.MaxDeadLetters = 10
+	d := NewDispatcher(options, store, logger.NewLogger("test", logger.LevelError))
+	t.Cleanup(d.Close)
+	<-d.ready
+	return d
+}
+
+// blockedStore answers the subscriptions once released
+type blockedStore struct {
+	Store
+	release chan struct{}
+}
+
+func (s blockedStore) Subscriptions(ctx context.Context) ([]*Subscription, error) {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return s.Store.Subscriptions(ctx)
+}
+
+// waitFor polls until the condition holds or a second passed
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+	t.Fatal("timed out")
+}
+
+func deadLetters(t *testing.T, store Store) []*Delivery {
+	t.Helper()
+	deliveries, err := store.DeadLetters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return deliveries
+}
+
+func TestDeliveries(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int32
+		dead     bool
+	}{
+		{name: "delivered", status: http.StatusNoContent, attempts: 1},
+		{name: "retried until the attempts run out", status: http.StatusServiceUnavailable, attempts: 3, dead: true},
+		{name: "refused", status: http.StatusGone, attempts: 1, dead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			store := NewMemoryStore()
+			d := newTestDispatcher(t, store, Options{MaxAttempts: 3, Backoff: time.Millisecond, QueueSize: 10})
+			if _, err := d.Subscribe(context.Background(), server.URL, []string{OrderPaid}, testSecret); err != nil {
+				t.Fatal(err)
+			}
+
+			d.Publish(OrderPaid, map[string]string{"id": "o1"})
+
+			waitFor(t, func() bool { return atomic.LoadInt32(&attempts) == tt.attempts })
+			if tt.dead {
+				waitFor(t, func() bool { return len(deadLetters(t, store)) == 1 })
+			}
+			time.Sleep(20 * time.Millisecond)
+			if got := atomic.LoadInt32(&attempts); got != tt.attempts {
+				t.Fatalf("sent %d attempts, want %d", got, tt.attempts)
+			}
+			if got := len(deadLetters(t, store)) == 1; got != tt.dead {
+				t.Fatalf("dead letter %v, want %v", got, tt.dead)
+			}
+		})
+	}
+}
+
+func TestFullQueueBuriesTheDelivery(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	store := NewMemoryStore()
+	d := newTestDispatcher(t, store, Options{Concurrency: 1, QueueSize: 1})
+	if _, err := d.Subscribe(context.Background(), server.URL, []string{OrderPaid}, testSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	// the worker holds the first, the queue the second, the third does not fit
+	d.Publish(OrderPaid, 1)
+	waitFor(t, func() bool { return len(d.queue) == 0 })
+	d.Publish(OrderPaid, 2)
+	d.Publish(OrderPaid, 3)
+
+	waitFor(t, func() bool { return len(deadLetters(t, store)) == 1 })
+	letters := deadLetters(t, store)
+	if len(letters) != 1 || letters[0].LastError != ErrQueueFull.Error() {
+		t.Fatalf("dead letters %+v, want one of a full queue", letters)
+	}
+	if err := d.Replay(context.Background(), letters[0].ID); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("replay err = %v, want %v", err, ErrQueueFull)
+	}
+	if len(deadLetters(t, store)) != 1 {
+		t.Fatal("the dead letter was dropped although the queue had no room")
+	}
+}
+
+func TestSubscriptionsOfTheStore(t *testing.T) {
+	store := NewMemoryStore()
+	saved := &Subscription{ID: "s1", URL: "http://127.0.0.1:9/hook", Events: []string{OrderPaid}, Secret: testSecret}
+	if err := store.SaveSubscription(context.Background(), saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveDeadLetter(context.Background(), &Delivery{ID: "d1", SubscriptionID: "s1"}, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveDeadLetter(context.Background(), &Delivery{ID: "d2", SubscriptionID: "removed"}, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// a restarted replica knows the subscriptions and dead letters saved before
+	d := newTestDispatcher(t, store, Options{QueueSize: 10})
+	waitFor(t, func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.subscriptions["s1"] != nil
+	})
+
+	tests := []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{name: "unknown", id: "d0", err: ErrNotFound},
+		{name: "removed subscription", id: "d2", err: ErrSubscriptionRemoved},
+		{name: "replayed", id: "d1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := d.Replay(context.Background(), tt.id); !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	if err := d.Unsubscribe(context.Background(), "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Unsubscribe(context.Background(), "s1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrNotFound)
+	}
+	if subscriptions, _ := store.Subscriptions(context.Background()); len(subscriptions) != 0 {
+		t.Fatalf("the store still has %d subscriptions", len(subscriptions))
+	}
+}
+
+func TestStartup(t *testing.T) {
+	tests := []struct {
+		name      string
+		queueSize int
+		published int
+		delivered int32
+		dropped   uint64
+	}{
+		{name: "events before the load are sent after it", queueSize: 10, published: 2, delivered: 2},
+		{name: "events beyond the queue size are dropped", queueSize: 1, published: 3, delivered: 1, dropped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delivered int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&delivered, 1)
+			}))
+			defer server.Close()
+
+			memory := NewMemoryStore()
+			saved := &Subscription{ID: "s1", URL: server.URL, Events: []string{OrderPaid}, Secret: testSecret}
+			if err := memory.SaveSubscription(context.Background(), saved); err != nil {
+				t.Fatal(err)
+			}
+
+			// the store does not answer yet, the dispatcher is created and publishes all the same
+			store := blockedStore{Store: memory, release: make(chan struct{})}
+			d := NewDispatcher(Options{Timeout: time.Second, QueueSize: tt.queueSize}, store, logger.NewLogger("test", logger.LevelError))
+			defer d.Close()
+			for i := 0; i < tt.published; i++ {
+				d.Publish(OrderPaid, i)
+			}
+			close(store.release)
+
+			waitFor(t, func() bool { return atomic.LoadInt32(&delivered) == tt.delivered })
+			time.Sleep(20 * time.Millisecond)
+			if got := atomic.LoadInt32(&delivered); got != tt.delivered {
+				t.Fatalf("delivered %d, want %d", got, tt.delivered)
+			}
+			if got := d.Dropped(); got != tt.dropped {
+				t.Fatalf("dropped %d, want %d", got, tt.dropped)
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	store := NewMemoryStore()
+	d := newTestDispatcher(t, store, Options{MaxAttempts: 3, Backoff: 20 * time.Millisecond, QueueSize: 10})
+	if _, err := d.Subscribe(context.Background(), server.URL, []string{OrderPaid}, testSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	d.Publish(OrderPaid, 1)
+	waitFor(t, func() bool { return atomic.LoadInt32(&attempts) == 1 })
+	d.Close()
+	d.Close()
+
+	// the retry that was due after the close is not sent
+	time.Sleep(60 * time.Millisecond)
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("sent %d attempts after the close, want 1", got)
+	}
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Store keeps the subscriptions and the dead letters so they outlive a restart and are shared by the
+// replicas of the gateway
+type Store interface {
+	SaveSubscription(ctx context.Context, subscription *Subscription) error
+	// DeleteSubscription returns ErrNotFound for an unknown subscription
+	DeleteSubscription(ctx context.Context, id string) error
+	Subscriptions(ctx context.Context) ([]*Subscription, error)
+
+	// SaveDeadLetter keeps the delivery and drops the oldest dead letters over keep
+	SaveDeadLetter(ctx context.Context, delivery *Delivery, keep int) error
+	// DeadLetters lists the dead letters, the latest first
+	DeadLetters(ctx context.Context) ([]*Delivery, error)
+	// DeadLetter and DeleteDeadLetter return ErrNotFound for an unknown dead letter
+	DeadLetter(ctx context.Context, id string) (*Delivery, error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
+
+// MemoryStore keeps the subscriptions and dead letters in the process, for the tools that build the
+// routes without a backend and for tests
+type MemoryStore struct {
+	mu            sync.Mutex
+	subscriptions map[string]*Subscription
+	deadLetters   []*Delivery
+}
+
+// NewMemoryStore creates an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{subscriptions: map[string]*Subscription{}}
+}
+
+func (s *MemoryStore) SaveSubscription(_ context.Context, subscription *Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *subscription
+	s.subscriptions[subscription.ID] = &saved
+	return nil
+}
+
+func (s *MemoryStore) DeleteSubscription(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscriptions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.subscriptions, id)
+	return nil
+}
+
+func (s *MemoryStore) Subscriptions(context.Context) ([]*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]*Subscription, 0, len(s.subscriptions))
+	for _, subscription := range s.subscriptions {
+		saved := *subscription
+		list = append(list, &saved)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+
+	return list, nil
+}
+
+func (s *MemoryStore) SaveDeadLetter(_ context.Context, delivery *Delivery, keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *delivery
+	s.deadLetters = append(s.deadLetters, &saved)
+	if extra := len(s.deadLetters) - keep; extra > 0 {
+		s.deadLetters = append([]*Delivery(nil), s.deadLetters[extra:]...)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeadLetters(context.Context) ([]*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]*Delivery, 0, len(s.deadLetters))
+	for i := len(s.deadLetters) - 1; i >= 0; i-- {
+		saved := *s.deadLetters[i]
+		list = append(list, &saved)
+	}
+	return list, nil
+}
+
+func (s *MemoryStore) DeadLetter(_ context.Context, id string) (*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, delivery := range s.deadLetters {
+		if delivery.ID == id {
+			saved := *delivery
+			return &saved, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) DeleteDeadLetter(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, delivery := range s.deadLetters {
+		if delivery.ID == id {
+			s.deadLetters = append(s.deadLetters[:i], s.deadLetters[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// The events the gateway sends after a successful change
+const (
+	OrderCreated         = "order.created"
+	OrderUpdated         = "order.updated"
+	OrderDeleted         = "order.deleted"
+	OrderConfirmed       = "order.confirmed"
+	OrderPickedUp        = "order.picked_up"
+	OrderReturned        = "order.returned"
+	OrderOverdue         = "order.overdue"
+	OrderCompleted       = "order.completed"
+	OrderCancelled       = "order.cancelled"
+	OrderPaid            = "order.paid"
+	OrderDepositHeld     = "order.deposit_held"
+	OrderDepositCaptured = "order.deposit_captured"
+	OrderDepositReleased = "order.deposit_released"
+
+	ClientCreated   = "client.created"
+	ClientUpdated   = "client.updated"
+	ClientDeleted   = "client.deleted"
+	ClientBlocked   = "client.blocked"
+	ClientUnblocked = "client.unblocked"
+
+	CarCreated = "car.created"
+	CarUpdated = "car.updated"
+	CarDeleted = "car.deleted"
+)
+
+// Events are all the event types a subscription can ask for
+var Events = []string{
+	OrderCreated, OrderUpdated, OrderDeleted, OrderConfirmed, OrderPickedUp, OrderReturned, OrderOverdue,
+	OrderCompleted, OrderCancelled, OrderPaid, OrderDepositHeld, OrderDepositCaptured, OrderDepositReleased,
+	ClientCreated, ClientUpdated, ClientDeleted, ClientBlocked, ClientUnblocked,
+	CarCreated, CarUpdated, CarDeleted,
+}
+
+// The headers of a delivery, the signature is checked with Verify
+const (
+	HeaderEvent     = "X-Car24-Event"
+	HeaderDelivery  = "X-Car24-Delivery"
+	HeaderTimestamp = "X-Car24-Timestamp"
+	HeaderSignature = "X-Car24-Signature"
+)
+
+// MinSecretLength is the shortest secret a subscription may be signed with
+const MinSecretLength = 16
+
+// Event is the body of a delivery
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// Subscription is an endpoint that receives the events of the given types, the secret is never answered
+type Subscription struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Wants reports whether the subscription receives the event type
+func (s *Subscription) Wants(eventType string) bool {
+	for _, wanted := range s.Events {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is an event on its way to a subscription, after the last failed attempt it is kept as a dead letter
+type Delivery struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	URL            string    `json:"url"`
+	Event          Event     `json:"event"`
+	Attempts       int       `json:"attempts"`
+	LastStatus     int       `json:"last_status,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	FailedAt       time.Time `json:"failed_at,omitempty"`
+}
+
+// Validate checks the url, the event types and the secret of a new subscription
+func Validate(target string, events []string, secret string) error {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https url")
+	}
+
+	if len(events) == 0 {
+		return fmt.Errorf("events must not be empty")
+	}
+	for _, event := range events {
+		known := false
+		for _, name := range Events {
+			known = known || name == event
+		}
+		if !known {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+
+	if len(secret) < MinSecretLength {
+		return fmt.Errorf("secret must be at least %d characters", MinSecretLength)
+	}
+
+	return nil
+}
+
+// Sign is the signature of the body sent at the unix timestamp, the hex HMAC-SHA256 of "timestamp.body"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and timestamp headers of a received delivery, deliveries older than
+// tolerance are refused so a captured one cannot be sent again
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration) bool {
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	if age := time.Since(time.Unix(sent, 0)); age > tolerance || age < -tolerance {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, sent, body)))
+}
+
+// newID is a random version 4 uuid
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	// computed by a receiver with the documented recipe, the hex HMAC-SHA256 of "timestamp.body"
+	const want = "sha256=35709ca2ecce8f3d15c806503243fd398425fec4a2629cd90ef9a7a8e7ce56b3"
+
+	if got := Sign(testSecret, 1700000000, []byte(`{"id":"e1"}`)); got != want {
+		t.Fatalf("Sign = %q, want %q", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"e1","type":"order.paid"}`)
+	now := time.Now().Unix()
+	signature := Sign(testSecret, now, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		valid     bool
+	}{
+		{name: "valid", secret: testSecret, signature: signature, timestamp: strconv.FormatInt(now, 10), body: body, valid: true},
+		{name: "other secret", secret: "fedcba9876543210", signature: signature, timestamp: strconv.FormatInt(now, 10), body: body},
+		{name: "changed body", secret: testSecret, signature: signature, timestamp: strconv.FormatInt(now, 10), body: []byte(`{"id":"e2","type":"order.paid"}`)},
+		{name: "changed timestamp", secret: testSecret, signature: signature, timestamp: strconv.FormatInt(now+1, 10), body: body},
+		{name: "too old", secret: testSecret, signature: Sign(testSecret, now-600, body), timestamp: strconv.FormatInt(now-600, 10), body: body},
+		{name: "from the future", secret: testSecret, signature: Sign(testSecret, now+600, body), timestamp: strconv.FormatInt(now+600, 10), body: body},
+		{name: "timestamp not a number", secret: testSecret, signature: signature, timestamp: "now", body: body},
+		{name: "no signature", secret: testSecret, timestamp: strconv.FormatInt(now, 10), body: body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, 5*time.Minute); got != tt.valid {
+				t.Fatalf("Verify = %v, want %v", got, tt.valid)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 #!/bin/bash
 CURRENT_DIR=$1
 rm -rf ${CURRENT_DIR}/genproto
-for x in $(find ${CURRENT_DIR}/protos/* -type d); do
+for x in $(find ${CURRENT_DIR}/protos/* -type d -not -path "*/protos/google*"); do
   protoc -I=${x} -I=${CURRENT_DIR}/protos -I /usr/local/include --go_out=${CURRENT_DIR} \
    --go-grpc_out=${CURRENT_DIR} ${x}/*.proto
 done
\ No newline at end of file